@@ -12,9 +12,12 @@ import (
 
 // Build 解析文档并输出文档内容
 //
+// 如果项目中包含多份文档，且未通过 Output.Doc 指定输出的文档，
+// 则每份文档都会输出一个文件，文件名由 Output.Path 和文档的 ID 组成，具体可参考 Output.DocPath。
+//
 // 如果是配置文件有问题，则直接返回错误信息，文档错误则输出至 h 对象。
 func Build(h *core.MessageHandler, o *Output, i ...*Input) error {
-	d, err := parse(h, i...)
+	docs, err := parse(h, i...)
 	if err != nil {
 		return err
 	}
@@ -22,19 +25,16 @@ func Build(h *core.MessageHandler, o *Output, i ...*Input) error {
 		return err
 	}
 
-	buf, err := o.buffer(d)
-	if err != nil {
-		return err
-	}
-
-	return o.Path.WriteAll(buf.Bytes())
+	return o.write(h, docs)
 }
 
 // Buffer 生成文档内容并返回
 //
+// 仅返回由 Output.Doc 指定的文档，具体规则可参考 Output.Doc。
+//
 // 如果是配置文件有问题，则直接返回错误信息，文档错误则输出至 h 对象。
 func Buffer(h *core.MessageHandler, o *Output, i ...*Input) (*bytes.Buffer, error) {
-	d, err := parse(h, i...)
+	docs, err := parse(h, i...)
 	if err != nil {
 		return nil, err
 	}
@@ -42,7 +42,11 @@ func Buffer(h *core.MessageHandler, o *Output, i ...*Input) (*bytes.Buffer, erro
 		return nil, err
 	}
 
-	return o.buffer(d)
+	doc, err := o.doc(docs)
+	if err != nil {
+		return nil, err
+	}
+	return o.buffer(h, doc)
 }
 
 // CheckSyntax 测试文档语法
@@ -53,17 +57,52 @@ func CheckSyntax(h *core.MessageHandler, i ...*Input) error {
 	return err
 }
 
-func parse(h *core.MessageHandler, i ...*Input) (*ast.APIDoc, error) {
+func parse(h *core.MessageHandler, i ...*Input) (*ast.Documents, error) {
 	for _, item := range i {
 		if err := item.sanitize(); err != nil {
 			return nil, err
 		}
 	}
 
-	d := &ast.APIDoc{}
-	d.ParseBlocks(h, func(blocks chan core.Block) {
+	docs := NewDocuments(i...)
+	docs.ParseBlocks(h, func(blocks chan core.Block) {
 		ParseInputs(blocks, h, i...)
 	})
 
-	return d, nil
+	return docs, nil
+}
+
+// 复制 docs 中的所有文档
+func cloneDocuments(docs *ast.Documents) *ast.Documents {
+	cloned := &ast.Documents{Docs: make([]*ast.APIDoc, 0, len(docs.Docs))}
+	for _, doc := range docs.Docs {
+		cloned.Docs = append(cloned.Docs, doc.Clone())
+	}
+	return cloned
+}
+
+// NewDocuments 根据输入项声明 ast.Documents 实例
+//
+// 未指定所属文档的 api，会归属于其所在输入项的 Input.Doc 指定的文档。
+// src 属性和 include 元素引用的文件，在相对于当前文件找不到时会在 Input.Dir 中查找。
+// 调用者需要保证 i 已经初始化。
+func NewDocuments(i ...*Input) *ast.Documents {
+	ids := make(map[core.URI]string, 10)
+	dirs := make(map[core.URI]core.URI, 10)
+	for _, input := range i {
+		for _, path := range input.paths {
+			dirs[path] = input.Dir
+			if input.Doc != "" {
+				ids[path] = input.Doc
+			}
+		}
+	}
+
+	docs := &ast.Documents{
+		Dir: func(uri core.URI) core.URI { return dirs[uri] },
+	}
+	if len(ids) > 0 {
+		docs.DocID = func(uri core.URI) string { return ids[uri] }
+	}
+	return docs
 }
@@ -3,10 +3,13 @@
 package build
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/issue9/assert/v3"
 
+	"github.com/caixw/apidoc/v7/core"
 	"github.com/caixw/apidoc/v7/core/messagetest"
 )
 
@@ -27,13 +30,103 @@ func TestParse(t *testing.T) {
 	}
 
 	rslt := messagetest.NewMessageHandler()
-	doc, err := parse(rslt.Handler, php, c)
-	a.NotError(err).NotNil(doc)
+	docs, err := parse(rslt.Handler, php, c)
+	a.NotError(err).NotNil(docs)
 	rslt.Handler.Stop()
-	a.Empty(rslt.Errors)
+	a.Empty(rslt.Errors).Length(docs.Docs, 1)
+
+	doc := docs.Docs[0]
 
 	a.Equal(2, len(doc.APIs)).
 		Equal(doc.Version.V(), "1.1.1")
 	api := doc.APIs[0]
 	a.Equal(api.Method.V(), "GET")
 }
+
+func TestBuild_docs(t *testing.T) {
+	a := assert.New(t, false)
+	dir := t.TempDir()
+
+	write := func(path, data string) {
+		a.NotError(os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		a.NotError(os.WriteFile(path, []byte(data), os.ModePerm))
+	}
+	write(filepath.Join(dir, "public", "doc.go"), `package public
+
+// <apidoc version="1.0.0"><title>public</title><mimetype>json</mimetype></apidoc>
+
+// <api method="GET"><path path="/users" /><response status="200" /></api>
+`)
+	write(filepath.Join(dir, "admin", "doc.go"), `package admin
+
+// <apidoc version="1.0.0" id="admin"><title>admin</title><mimetype>json</mimetype></apidoc>
+
+// <api method="GET"><path path="/admins" /><response status="200" /></api>
+`)
+
+	public := &Input{Lang: "go", Dir: core.FileURI(filepath.Join(dir, "public"))}
+	admin := &Input{Lang: "go", Dir: core.FileURI(filepath.Join(dir, "admin")), Doc: "admin"}
+	o := &Output{Path: core.FileURI(filepath.Join(dir, "apidoc.xml"))}
+
+	rslt := messagetest.NewMessageHandler()
+	a.NotError(Build(rslt.Handler, o, public, admin))
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors)
+
+	data, err := os.ReadFile(filepath.Join(dir, "apidoc.xml"))
+	a.NotError(err).Contains(string(data), "/users").NotContains(string(data), "/admins")
+	data, err = os.ReadFile(filepath.Join(dir, "apidoc.admin.xml"))
+	a.NotError(err).Contains(string(data), "/admins").NotContains(string(data), "/users")
+
+	// 指定了 Output.Doc
+	o = &Output{Path: core.FileURI(filepath.Join(dir, "admin.xml")), Doc: "admin"}
+	rslt = messagetest.NewMessageHandler()
+	buf, err := Buffer(rslt.Handler, o, public, admin)
+	rslt.Handler.Stop()
+	a.NotError(err).Empty(rslt.Errors).Contains(buf.String(), "/admins")
+
+	// Output.Doc 指定的文档不存在
+	o = &Output{Path: core.FileURI(filepath.Join(dir, "not-exists.xml")), Doc: "not-exists"}
+	rslt = messagetest.NewMessageHandler()
+	a.Error(Build(rslt.Handler, o, public, admin))
+	rslt.Handler.Stop()
+	_, err = os.Stat(filepath.Join(dir, "not-exists.xml"))
+	a.True(os.IsNotExist(err))
+
+	rslt = messagetest.NewMessageHandler()
+	buf, err = Buffer(rslt.Handler, o, public, admin)
+	rslt.Handler.Stop()
+	a.Error(err).Nil(buf)
+}
+
+func TestBuild_src(t *testing.T) {
+	a := assert.New(t, false)
+	dir := t.TempDir()
+
+	write := func(path, data string) {
+		a.NotError(os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		a.NotError(os.WriteFile(path, []byte(data), os.ModePerm))
+	}
+	write(filepath.Join(dir, "doc.go"), `package doc
+
+// <apidoc version="1.0.0"><title>doc</title><mimetype>json</mimetype></apidoc>
+`)
+	// examples 位于 Input.Dir 之下，而不是 api.go 所在的目录。
+	write(filepath.Join(dir, "sub", "api.go"), `package sub
+
+// <api method="GET">
+//   <path path="/users" />
+//   <response status="200" type="string">
+//     <example mimetype="json" src="examples/user.json" />
+//   </response>
+// </api>
+`)
+	write(filepath.Join(dir, "examples", "user.json"), `{"id":1024}`)
+
+	i := &Input{Lang: "go", Dir: core.FileURI(dir), Recursive: true}
+	o := &Output{Path: core.FileURI(filepath.Join(dir, "apidoc.xml"))}
+	rslt := messagetest.NewMessageHandler()
+	buf, err := Buffer(rslt.Handler, o, i)
+	rslt.Handler.Stop()
+	a.NotError(err).Empty(rslt.Errors).Contains(buf.String(), `{"id":1024}`)
+}
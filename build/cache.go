@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: MIT
+
+package build
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/internal/lang"
+)
+
+// 缓存文件的内容
+//
+// 每个源文件对应一个缓存文件，文件名由源文件的地址以及 Input 中影响解析结果的配置项决定，
+// 源文件的内容或是程序的版本号有变化时，缓存失效。
+type cache struct {
+	Version string       `json:"version"` // 生成缓存的程序版本号
+	Hash    string       `json:"hash"`    // 源文件内容的 sha256 值
+	Blocks  []core.Block `json:"blocks"`
+}
+
+// 从缓存中读取 block 中的代码块并输出到 blocks
+//
+// 如果缓存不存在或是已经失效，则调用 lang.Parse 重新解析并写入缓存。
+// 解析过程中有错误的，不会写入缓存，以保证下次依然能输出这些错误。
+func (o *Input) parseCache(blocks chan core.Block, h *core.MessageHandler, block core.Block) {
+	path := o.cachePath(block.Location.URI)
+	hash := sha256.Sum256(block.Data)
+	c := &cache{
+		Version: core.FullVersion(),
+		Hash:    hex.EncodeToString(hash[:]),
+	}
+
+	if cached := readCache(path); cached != nil && cached.Version == c.Version && cached.Hash == c.Hash {
+		for _, b := range cached.Blocks {
+			blocks <- b
+		}
+		return
+	}
+
+	var failed bool
+	proxy := core.NewMessageHandler(func(msg *core.Message) {
+		failed = failed || msg.Type == core.Erro
+		h.Message(msg.Type, msg.Message)
+	})
+
+	done := make(chan struct{})
+	parsed := make(chan core.Block, 10)
+	go func() {
+		for b := range parsed {
+			c.Blocks = append(c.Blocks, b)
+			blocks <- b
+		}
+		done <- struct{}{}
+	}()
+
+	lang.Parse(proxy, o.Lang, block, parsed)
+	close(parsed)
+	<-done
+	proxy.Stop()
+
+	if failed {
+		return
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		h.Error(err)
+		return
+	}
+	if err := path.WriteAll(data); err != nil {
+		h.Error((core.Location{URI: path}).WithError(err))
+	}
+}
+
+// 返回 uri 对应的缓存文件地址
+func (o *Input) cachePath(uri core.URI) core.URI {
+	key := strings.Join([]string{string(uri), o.Lang, o.Encoding, strings.Join(o.Exts, ",")}, "\n")
+	hash := sha256.Sum256([]byte(key))
+	return o.Cache.Append(hex.EncodeToString(hash[:]) + ".json")
+}
+
+// 读取缓存文件，不存在或是内容无法解析时返回 nil。
+func readCache(path core.URI) *cache {
+	data, err := path.ReadAll(nil)
+	if err != nil {
+		return nil
+	}
+
+	c := &cache{}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil
+	}
+	return c
+}
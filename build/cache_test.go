@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: MIT
+
+package build
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/issue9/assert/v3"
+
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/core/messagetest"
+)
+
+func TestInput_parseCache(t *testing.T) {
+	a := assert.New(t, false)
+	dir := t.TempDir()
+	cacheDir := filepath.Join(dir, ".cache")
+
+	src := filepath.Join(dir, "api.go")
+	a.NotError(os.WriteFile(src, []byte("package api\n\n// <api method=\"GET\" />\n"), os.ModePerm))
+	uri := core.FileURI(src)
+
+	i := &Input{Lang: "go", Dir: core.FileURI(dir), Cache: core.FileURI(cacheDir)}
+	a.NotError(i.sanitize())
+	_, err := os.Stat(cacheDir)
+	a.NotError(err)
+
+	parse := func(i *Input) ([]core.Block, *messagetest.Result) {
+		rslt := messagetest.NewMessageHandler()
+		blocks := make(chan core.Block, 10)
+		i.ParseFile(blocks, rslt.Handler, uri)
+		close(blocks)
+		rslt.Handler.Stop()
+
+		list := make([]core.Block, 0, 10)
+		for b := range blocks {
+			list = append(list, b)
+		}
+		return list, rslt
+	}
+
+	blocks, rslt := parse(i)
+	a.Empty(rslt.Errors).Length(blocks, 1).
+		Equal(string(blocks[0].Data), "   <api method=\"GET\" />\n").
+		Equal(blocks[0].Location.URI, uri)
+	path := i.cachePath(uri)
+	c := readCache(path)
+	a.NotNil(c).Equal(c.Version, core.FullVersion()).Equal(c.Blocks, blocks)
+
+	// 修改缓存内容，可以证明未重新解析源文件。
+	c.Blocks[0].Data = []byte("cached")
+	data, err := json.Marshal(c)
+	a.NotError(err).NotError(path.WriteAll(data))
+	blocks, rslt = parse(i)
+	a.Empty(rslt.Errors).Length(blocks, 1).Equal(string(blocks[0].Data), "cached")
+
+	// 程序版本号不同
+	c.Version = "0.1.0"
+	data, err = json.Marshal(c)
+	a.NotError(err).NotError(path.WriteAll(data))
+	blocks, rslt = parse(i)
+	a.Empty(rslt.Errors).Length(blocks, 1).Equal(string(blocks[0].Data), "   <api method=\"GET\" />\n")
+
+	// 配置项不同
+	i2 := &Input{Lang: "go", Dir: core.FileURI(dir), Cache: core.FileURI(cacheDir), Exts: []string{".go", ".txt"}}
+	a.NotError(i2.sanitize())
+	a.NotEqual(i2.cachePath(uri), path)
+
+	// 源文件内容有变化
+	a.NotError(os.WriteFile(src, []byte("package api\n\n// <api method=\"POST\" />\n"), os.ModePerm))
+	blocks, rslt = parse(i)
+	a.Empty(rslt.Errors).Length(blocks, 1).Equal(string(blocks[0].Data), "   <api method=\"POST\" />\n")
+
+	// 有错误的内容不会写入缓存
+	local, err := path.File()
+	a.NotError(err).NotError(os.Remove(local))
+	a.NotError(os.WriteFile(src, []byte("package api\n\n/* <api method=\"POST\" />\n"), os.ModePerm))
+	blocks, rslt = parse(i)
+	a.NotEmpty(rslt.Errors).Empty(blocks)
+	a.Nil(readCache(path))
+}
@@ -39,7 +39,16 @@ type Config struct {
 	Inputs []*Input `yaml:"inputs"`
 
 	// 输出配置项
-	Output *Output `yaml:"output"`
+	//
+	// 与 Outputs 至少需要指定一项。
+	Output *Output `yaml:"output,omitempty"`
+
+	// 多个输出配置项
+	//
+	// 源文件只会解析一次，之后按各个输出项的配置分别输出。
+	Outputs []*Output `yaml:"outputs,omitempty"`
+
+	path core.URI // 配置文件的路径
 }
 
 // LoadConfig 加载指定目录下的配置文件
@@ -83,6 +92,7 @@ func loadFile(wd, path core.URI) (*Config, error) {
 	if err := cfg.sanitize(wd); err != nil {
 		return nil, err
 	}
+	cfg.path = path
 
 	return cfg, nil
 }
@@ -104,7 +114,7 @@ func (cfg *Config) sanitize(wd core.URI) error {
 		return (core.Location{URI: file}).NewError(locale.ErrIsEmpty, "inputs").WithField("inputs")
 	}
 
-	if cfg.Output == nil {
+	if cfg.Output == nil && len(cfg.Outputs) == 0 {
 		return (core.Location{URI: file}).NewError(locale.ErrIsEmpty, "output").WithField("output")
 	}
 
@@ -119,6 +129,12 @@ func (cfg *Config) sanitize(wd core.URI) error {
 			return (core.Location{URI: file}).WithError(err).WithField(field + ".path")
 		}
 
+		if i.Cache != "" {
+			if i.Cache, err = abs(i.Cache, wd); err != nil {
+				return (core.Location{URI: file}).WithError(err).WithField(field + ".cache")
+			}
+		}
+
 		if err := i.sanitize(); err != nil {
 			if serr, ok := err.(*core.Error); ok {
 				serr.Location.URI = file
@@ -128,10 +144,58 @@ func (cfg *Config) sanitize(wd core.URI) error {
 		}
 	}
 
-	if cfg.Output.Path, err = abs(cfg.Output.Path, wd); err != nil {
-		return (core.Location{URI: file}).WithError(err).WithField("output.path")
+	if cfg.Output != nil {
+		if err := sanitizeOutput(cfg.Output, wd, file, "output"); err != nil {
+			return err
+		}
+	}
+
+	paths := make(map[core.URI]string, len(cfg.Outputs))
+	if cfg.Output != nil {
+		paths[cfg.Output.Path] = "output"
 	}
-	return cfg.Output.sanitize()
+	for index, o := range cfg.Outputs {
+		field := "outputs[" + strconv.Itoa(index) + "]"
+
+		if o == nil {
+			return (core.Location{URI: file}).NewError(locale.ErrIsEmpty, field).WithField(field)
+		}
+
+		if err := sanitizeOutput(o, wd, file, field); err != nil {
+			return err
+		}
+
+		if _, found := paths[o.Path]; found {
+			return (core.Location{URI: file}).NewError(locale.ErrDuplicateValue).WithField(field + ".path")
+		}
+		paths[o.Path] = field
+	}
+
+	return nil
+}
+
+// 初始化输出项 o，field 为 o 在配置文件中的字段名。
+func sanitizeOutput(o *Output, wd, file core.URI, field string) (err error) {
+	if o.Path, err = abs(o.Path, wd); err != nil {
+		return (core.Location{URI: file}).WithError(err).WithField(field + ".path")
+	}
+
+	if err := o.sanitize(); err != nil {
+		if serr, ok := err.(*core.Error); ok {
+			serr.Location.URI = file
+			serr.Field = field + "." + serr.Field
+		}
+		return err
+	}
+	return nil
+}
+
+// 返回所有的输出项
+func (cfg *Config) outputs() []*Output {
+	if cfg.Output == nil {
+		return cfg.Outputs
+	}
+	return append([]*Output{cfg.Output}, cfg.Outputs...)
 }
 
 // Save 将内容保存至 wd 目录下的 .apidoc.yaml 文件
@@ -142,11 +206,19 @@ func (cfg *Config) Save(wd core.URI) (err error) {
 		if input.Dir, err = rel(input.Dir, wd); err != nil {
 			return err
 		}
+
+		if input.Cache != "" {
+			if input.Cache, err = rel(input.Cache, wd); err != nil {
+				return err
+			}
+		}
 	}
 
-	if cfg.Output.Path != "" { // 调整成相对路径
-		if cfg.Output.Path, err = rel(cfg.Output.Path, wd); err != nil {
-			return err
+	for _, o := range cfg.outputs() {
+		if o.Path != "" { // 调整成相对路径
+			if o.Path, err = rel(o.Path, wd); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -157,20 +229,37 @@ func (cfg *Config) Save(wd core.URI) (err error) {
 	return wd.Append(allowConfigFilenames[0]).WriteAll(data)
 }
 
-// Build 解析文档并输出文档内容
+// Build 解析文档并输出到所有的输出项
 //
-// 具体信息可参考 Build 函数的相关文档。
+// 源文件只会解析一次，每个输出项的结果都会通过 h 报告，
+// 某一输出项出错，并不会影响其它输出项。
+// 输出的具体规则可参考 Build 函数的相关文档。
 func (cfg *Config) Build(h *core.MessageHandler) {
-	if err := Build(h, cfg.Output, cfg.Inputs...); err != nil {
+	docs, err := parse(h, cfg.Inputs...)
+	if err != nil {
 		panic(err) // 由 loadConfig 保证配置项的正确，如果还出错则直接 panic
 	}
+	cfg.write(h, docs)
+}
+
+// 将 docs 写入所有的输出项
+//
+// 输出时会修改文档内容，所以每个输出项采用的都是 docs 的副本。
+func (cfg *Config) write(h *core.MessageHandler, docs *ast.Documents) {
+	for _, o := range cfg.outputs() {
+		if err := o.write(h, cloneDocuments(docs)); err != nil {
+			h.Error((core.Location{URI: o.Path}).WithError(err))
+			continue
+		}
+		h.Locale(core.Succ, locale.OutputSuccess, o.Path)
+	}
 }
 
 // Buffer 根据 wd 目录下的配置文件生成文档内容并保存至内存
 //
-// 具体信息可参考 Buffer 函数的相关文档。
+// 仅采用第一个输出项，具体信息可参考 Buffer 函数的相关文档。
 func (cfg *Config) Buffer(h *core.MessageHandler) *bytes.Buffer {
-	buf, err := Buffer(h, cfg.Output, cfg.Inputs...)
+	buf, err := Buffer(h, cfg.outputs()[0], cfg.Inputs...)
 	if err != nil {
 		panic(err) // 由 loadConfig 保证配置项的正确，如果还出错则直接 panic
 	}
@@ -4,6 +4,8 @@ package build
 
 import (
 	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/issue9/assert/v3"
@@ -87,6 +89,40 @@ func TestConfig_sanitize(t *testing.T) {
 	a.Error(err).
 		True(ok).
 		Equal(err2.Field, "output")
+
+	// outputs 中的错误
+	wd := core.FileURI(t.TempDir())
+	conf = &Config{
+		Version: "6.0.1",
+		Inputs:  []*Input{{Lang: "go", Dir: docs.Dir().Append("example"), Exts: []string{".cpp"}}},
+		Outputs: []*Output{{Path: "apidoc.xml"}, nil},
+	}
+	err = conf.sanitize(wd)
+	err2, ok = err.(*core.Error)
+	a.Error(err).
+		True(ok).
+		Equal(err2.Field, "outputs[1]")
+
+	conf.Outputs = []*Output{{Path: "apidoc.xml"}, {Path: "openapi.json", Type: "invalid"}}
+	err = conf.sanitize(wd)
+	err2, ok = err.(*core.Error)
+	a.Error(err).
+		True(ok).
+		Equal(err2.Field, "outputs[1].type")
+
+	conf.Output = &Output{Path: "apidoc.xml"}
+	conf.Outputs = []*Output{{Path: "openapi.json", Type: OpenapiJSON}, {Path: "apidoc.xml"}}
+	err = conf.sanitize(wd)
+	err2, ok = err.(*core.Error)
+	a.Error(err).
+		True(ok).
+		Equal(err2.Field, "outputs[1].path")
+
+	conf.Outputs = []*Output{{Path: "openapi.json", Type: OpenapiJSON}}
+	a.NotError(conf.sanitize(wd))
+	a.Length(conf.outputs(), 2).
+		Equal(conf.outputs()[0].Path, wd.Append("apidoc.xml")).
+		Equal(conf.outputs()[1].Path, wd.Append("openapi.json"))
 }
 
 func TestConfig_Save(t *testing.T) {
@@ -131,6 +167,49 @@ func TestConfig_Build(t *testing.T) {
 	a.Empty(rslt.Errors)
 }
 
+func TestConfig_Build_outputs(t *testing.T) {
+	a := assert.New(t, false)
+	dir := t.TempDir()
+	wd := core.FileURI(dir)
+
+	data := `package doc
+
+// <apidoc version="1.0.0"><title>doc</title><mimetype>json</mimetype><tag name="t1" title="t1" /></apidoc>
+
+// <api method="GET"><path path="/users" /><tag>t1</tag><response status="200" summary="ok" /></api>
+
+// <api method="GET"><path path="/admins" /><response status="200" summary="ok" /></api>
+`
+	a.NotError(os.WriteFile(filepath.Join(dir, "doc.go"), []byte(data), os.ModePerm))
+
+	cfg := &Config{
+		Version: "6.0.1",
+		Inputs:  []*Input{{Lang: "go"}},
+		Outputs: []*Output{
+			{Path: "apidoc.xml"},
+			{Path: "openapi.json", Type: OpenapiJSON, Tags: []string{"t1"}},
+			{Path: "openapi.yaml", Type: OpenapiYAML},
+			{Path: "not-exists/apidoc.xml"},
+		},
+	}
+	a.NotError(cfg.sanitize(wd))
+
+	rslt := messagetest.NewMessageHandler()
+	cfg.Build(rslt.Handler)
+	rslt.Handler.Stop()
+	a.Length(rslt.Successes, 3).Length(rslt.Errors, 1)
+
+	content, err := os.ReadFile(filepath.Join(dir, "apidoc.xml"))
+	a.NotError(err).Contains(string(content), "/users").Contains(string(content), "/admins")
+
+	// 标签过滤不影响其它输出项
+	content, err = os.ReadFile(filepath.Join(dir, "openapi.json"))
+	a.NotError(err).Contains(string(content), "/users").NotContains(string(content), "/admins")
+
+	content, err = os.ReadFile(filepath.Join(dir, "openapi.yaml"))
+	a.NotError(err).Contains(string(content), "/users").Contains(string(content), "/admins")
+}
+
 func TestConfig_Buffer(t *testing.T) {
 	a := assert.New(t, false)
 
@@ -0,0 +1,176 @@
+// SPDX-License-Identifier: MIT
+
+package build
+
+import (
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/issue9/sliceutil"
+
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/internal/ast"
+	"github.com/caixw/apidoc/v7/internal/locale"
+)
+
+// Filter 输出 API 时的过滤条件
+//
+// 各个字段之间是与的关系，同一字段中的多个值之间是或的关系，为空的字段表示不作限制。
+type Filter struct {
+	// 服务的名称
+	Servers []string `yaml:"servers,omitempty"`
+
+	// 请求方法，不区分大小写。
+	Methods []string `yaml:"methods,omitempty"`
+
+	// 路径，可以使用 path.Match 格式的通配符，比如 /users/*。
+	Paths []string `yaml:"paths,omitempty"`
+
+	// API 的 ID
+	IDs []string `yaml:"ids,omitempty"`
+
+	// 是否为已弃用的 API
+	//
+	// 指定了 deprecated 属性的 API 即为已弃用的 API，不考虑其具体的版本号。
+	Deprecated *bool `yaml:"deprecated,omitempty"`
+}
+
+// field 为当前对象在 Output 中的字段名
+func (f *Filter) sanitize(field string) error {
+	for i, method := range f.Methods {
+		f.Methods[i] = strings.ToUpper(method)
+	}
+
+	for i, p := range f.Paths {
+		if _, err := path.Match(p, ""); err != nil {
+			return core.NewError(locale.ErrInvalidFormat).WithField(field + ".paths[" + strconv.Itoa(i) + "]")
+		}
+	}
+
+	return nil
+}
+
+// 是否未指定任何条件
+func (f *Filter) isEmpty() bool {
+	return f == nil ||
+		(len(f.Servers) == 0 && len(f.Methods) == 0 && len(f.Paths) == 0 && len(f.IDs) == 0 && f.Deprecated == nil)
+}
+
+// api 是否符合所有的条件
+//
+// d 为 api 所在的文档，未指定服务的 API 采用文档中的所有服务。
+func (f *Filter) match(d *ast.APIDoc, api *ast.API) bool {
+	if len(f.Servers) > 0 && !f.matchServer(d, api) {
+		return false
+	}
+
+	if len(f.Methods) > 0 && !contains(f.Methods, strings.ToUpper(api.Method.V())) {
+		return false
+	}
+
+	if len(f.Paths) > 0 && !f.matchPath(api) {
+		return false
+	}
+
+	if len(f.IDs) > 0 && !contains(f.IDs, api.ID.V()) {
+		return false
+	}
+
+	if f.Deprecated != nil && *f.Deprecated != (api.Deprecated != nil) {
+		return false
+	}
+
+	return true
+}
+
+func (f *Filter) matchServer(d *ast.APIDoc, api *ast.API) bool {
+	if len(api.Servers) == 0 {
+		for _, srv := range d.Servers {
+			if contains(f.Servers, srv.Name.V()) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, srv := range api.Servers {
+		if contains(f.Servers, srv.V()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *Filter) matchPath(api *ast.API) bool {
+	if api.Path == nil {
+		return false
+	}
+
+	p := api.Path.Path.V()
+	for _, pattern := range f.Paths {
+		if match, err := path.Match(pattern, p); err == nil && match { // 格式已由 sanitize 检测
+			return true
+		}
+	}
+	return false
+}
+
+func contains(list []string, v string) bool {
+	return sliceutil.Count(list, func(i string) bool { return i == v }) > 0
+}
+
+// 删除文档中不再被 API 引用的标签、服务和公共的返回内容
+//
+// 未指定服务的 API 会继承文档中的所有服务，只要还存在这样的 API，文档中的服务就都会被保留，
+// 但是被过滤条件排除的服务始终会被删除。
+//
+// 同时也会删除 API 中指向已经不存在的标签和服务的引用，以及 link 元素中指向已被删除的 API 的链接。
+func pruneDoc(d *ast.APIDoc, o *Output) {
+	tags := make(map[string]struct{}, len(d.Tags))
+	servers := make(map[string]struct{}, len(d.Servers))
+	inherited := false // 是否有 API 未指定服务，未指定服务的 API 采用文档中的所有服务。
+
+	for _, api := range d.APIs {
+		api.Tags = sliceutil.Delete(api.Tags, func(tag *ast.TagValue) bool { return !o.contains(tag.V()) })
+		for _, tag := range api.Tags {
+			tags[tag.V()] = struct{}{}
+		}
+
+		api.Servers = sliceutil.Delete(api.Servers, func(srv *ast.ServerValue) bool { return o.excludeServer(srv.V()) })
+		if len(api.Servers) == 0 {
+			inherited = true
+		}
+		for _, srv := range api.Servers {
+			servers[srv.V()] = struct{}{}
+		}
+	}
+
+	d.Tags = sliceutil.Delete(d.Tags, func(tag *ast.Tag) bool {
+		_, found := tags[tag.Name.V()]
+		return !found
+	})
+
+	d.Servers = sliceutil.Delete(d.Servers, func(srv *ast.Server) bool {
+		name := srv.Name.V()
+		if o.excludeServer(name) {
+			return true
+		}
+		_, found := servers[name]
+		return !inherited && !found
+	})
+
+	if len(d.APIs) == 0 {
+		d.Responses = nil
+	}
+
+	d.PruneLinks()
+}
+
+// 名为 name 的服务是否被过滤条件排除
+func (o *Output) excludeServer(name string) bool {
+	if o.Include != nil && len(o.Include.Servers) > 0 && !contains(o.Include.Servers, name) {
+		return true
+	}
+	return o.Exclude != nil && contains(o.Exclude.Servers, name)
+}
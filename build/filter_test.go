@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: MIT
+
+package build
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v3"
+
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/internal/ast"
+	"github.com/caixw/apidoc/v7/internal/ast/asttest"
+	"github.com/caixw/apidoc/v7/internal/xmlenc"
+)
+
+func TestFilter_sanitize(t *testing.T) {
+	a := assert.New(t, false)
+
+	f := &Filter{Methods: []string{"get", "Post"}, Paths: []string{"/users/*"}}
+	a.NotError(f.sanitize("include")).
+		Equal(f.Methods, []string{"GET", "POST"})
+
+	f = &Filter{Paths: []string{"/users/*", "/users/["}}
+	err := f.sanitize("include")
+	a.Error(err).Equal(err.(*core.Error).Field, "include.paths[1]")
+
+	o := &Output{Exclude: &Filter{Paths: []string{"["}}}
+	err = o.sanitize()
+	a.Error(err).Equal(err.(*core.Error).Field, "exclude.paths[0]")
+}
+
+func TestFilter_match(t *testing.T) {
+	a := assert.New(t, false)
+
+	var nilFilter *Filter
+	a.True(nilFilter.isEmpty()).
+		True((&Filter{}).isEmpty()).
+		False((&Filter{IDs: []string{"id"}}).isEmpty())
+
+	deprecated := true
+	d := asttest.Get()
+	get, post := d.APIs[0], d.APIs[1]
+	get.ID = &ast.Attribute{Value: xmlenc.String{Value: "get-users"}}
+
+	data := []*struct {
+		filter    *Filter
+		get, post bool
+	}{
+		{filter: &Filter{}, get: true, post: true},
+		{filter: &Filter{Servers: []string{"client"}}, get: false, post: true},
+		{filter: &Filter{Servers: []string{"client", "admin"}}, get: true, post: true},
+		{filter: &Filter{Methods: []string{"GET"}}, get: true, post: false},
+		{filter: &Filter{Paths: []string{"/user*"}}, get: true, post: true},
+		{filter: &Filter{Paths: []string{"/admins/*"}}, get: false, post: false},
+		{filter: &Filter{IDs: []string{"get-users"}}, get: true, post: false},
+		{filter: &Filter{Deprecated: &deprecated}, get: false, post: true},
+		{filter: &Filter{Methods: []string{"POST"}, Servers: []string{"admin"}}, get: false, post: true},
+		{filter: &Filter{Methods: []string{"POST"}, IDs: []string{"get-users"}}, get: false, post: false},
+	}
+
+	for i, item := range data {
+		a.NotError(item.filter.sanitize("include"))
+		a.Equal(item.filter.match(d, get), item.get, "get not match at %d", i).
+			Equal(item.filter.match(d, post), item.post, "post not match at %d", i)
+	}
+
+	// 未指定服务的 API 采用文档中的所有服务
+	get.Servers = nil
+	a.True((&Filter{Servers: []string{"client"}}).match(d, get)).
+		True((&Filter{Servers: []string{"admin"}}).match(d, get)).
+		False((&Filter{Servers: []string{"not-exists"}}).match(d, get))
+}
+
+func TestPruneDoc(t *testing.T) {
+	a := assert.New(t, false)
+	deprecated := true
+
+	// 按服务过滤，API 中的其它服务也会被删除。
+	d := asttest.Get()
+	o := &Output{Include: &Filter{Servers: []string{"client"}}}
+	a.NotError(o.sanitize())
+	filterDoc(d, o)
+	a.Length(d.APIs, 1).
+		Equal(d.APIs[0].Method.V(), "POST").
+		Length(d.APIs[0].Servers, 1).
+		Length(d.Servers, 1).
+		Equal(d.Servers[0].Name.V(), "client").
+		Length(d.Tags, 2)
+
+	// 排除已弃用的 API，未被引用的标签和服务都会被删除。
+	d = asttest.Get()
+	o = &Output{Exclude: &Filter{Deprecated: &deprecated}}
+	a.NotError(o.sanitize())
+	filterDoc(d, o)
+	a.Length(d.APIs, 1).
+		Equal(d.APIs[0].Method.V(), "GET").
+		Length(d.Servers, 1).
+		Equal(d.Servers[0].Name.V(), "admin").
+		Length(d.Tags, 2).
+		Equal(d.Tags[0].Name.V(), "t1").
+		Equal(d.Tags[1].Name.V(), "t2")
+
+	// 与 tags 同时使用，API 中不再存在的标签也会被删除。
+	d = asttest.Get()
+	o = &Output{Tags: []string{"t1"}, Exclude: &Filter{Methods: []string{"post"}}}
+	a.NotError(o.sanitize())
+	filterDoc(d, o)
+	a.Length(d.APIs, 1).
+		Length(d.APIs[0].Tags, 1).
+		Length(d.Tags, 1)
+
+	// 指向已删除 API 的链接
+	d = asttest.Get()
+	d.APIs[0].ID = &ast.Attribute{Value: xmlenc.String{Value: "get-users"}}
+	d.APIs[1].ID = &ast.Attribute{Value: xmlenc.String{Value: "post-users"}}
+	link := func(id string) *ast.ResponseLink {
+		return &ast.ResponseLink{
+			Name: &ast.Attribute{Value: xmlenc.String{Value: id}},
+			API:  &ast.Attribute{Value: xmlenc.String{Value: id}},
+		}
+	}
+	d.APIs[1].Responses[0].Links = []*ast.ResponseLink{link("get-users"), link("post-users")}
+	d.Responses = []*ast.Request{{Links: []*ast.ResponseLink{link("get-users")}}}
+	o = &Output{Include: &Filter{IDs: []string{"post-users"}}}
+	a.NotError(o.sanitize())
+	filterDoc(d, o)
+	a.Length(d.APIs, 1).
+		Length(d.APIs[0].Responses[0].Links, 1).
+		Equal(d.APIs[0].Responses[0].Links[0].API.V(), "post-users").
+		Length(d.Responses, 1).
+		Empty(d.Responses[0].Links)
+
+	// 没有 API 时，公共的返回内容也会被删除。
+	o = &Output{Exclude: &Filter{IDs: []string{"post-users"}}}
+	a.NotError(o.sanitize())
+	filterDoc(d, o)
+	a.Empty(d.APIs).Empty(d.Tags).Empty(d.Servers).Empty(d.Responses)
+
+	// 未指定服务的 API 继承文档中的所有服务，这些服务不会被删除。
+	d = asttest.Get()
+	d.APIs[0].Servers = nil
+	o = &Output{Exclude: &Filter{Methods: []string{"POST"}}}
+	a.NotError(o.sanitize())
+	filterDoc(d, o)
+	a.Length(d.APIs, 1).
+		Empty(d.APIs[0].Servers).
+		Length(d.Servers, 2)
+
+	// 按服务排除，未指定服务的 API 也采用了被排除的服务。
+	d = asttest.Get()
+	d.APIs[0].Servers = nil
+	o = &Output{Exclude: &Filter{Servers: []string{"admin"}}}
+	a.NotError(o.sanitize())
+	filterDoc(d, o)
+	a.Empty(d.APIs).Empty(d.Servers)
+
+	// 被排除的服务，即使被未指定服务的 API 继承，也会从文档中删除。
+	d = asttest.Get()
+	d.APIs[0].Servers = nil
+	o = &Output{Exclude: &Filter{Servers: []string{"admin"}, Methods: []string{"POST"}}}
+	a.NotError(o.sanitize())
+	filterDoc(d, o)
+	a.Length(d.APIs, 1).
+		Equal(d.APIs[0].Method.V(), "GET").
+		Empty(d.APIs[0].Servers).
+		Length(d.Servers, 1).
+		Equal(d.Servers[0].Name.V(), "client")
+
+	// 按服务包含，未指定服务的 API 同样会被保留，但文档中只保留包含的服务。
+	d = asttest.Get()
+	d.APIs[0].Servers = nil
+	o = &Output{Include: &Filter{Servers: []string{"client"}}}
+	a.NotError(o.sanitize())
+	filterDoc(d, o)
+	a.Length(d.APIs, 2).
+		Length(d.Servers, 1).
+		Equal(d.Servers[0].Name.V(), "client")
+}
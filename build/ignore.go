@@ -0,0 +1,217 @@
+// SPDX-License-Identifier: MIT
+
+package build
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// gitignoreFilename 在 Input.GitIgnore 为 true 时，需要加载的规则文件名
+const gitignoreFilename = ".gitignore"
+
+// 以 .gitignore 语法表示的规则列表
+//
+// 支持 *、?、[...] 以及 ** 通配符，以 / 结尾表示仅匹配目录，以 ! 开头表示取反。
+// 与 .gitignore 相同，后面的规则优先级高于前面的规则。
+// 由 load 加载的 .gitignore 文件中的规则始终位于由 append 添加的规则之前，
+// 保证用户明确指定的规则优先级最高。
+type gitignore struct {
+	patterns []*gitignorePattern
+	loaded   int // patterns 中由 load 加载的规则数量
+}
+
+type gitignorePattern struct {
+	base    string // 规则所在的目录，相对于 Input.Dir，为空表示根目录。
+	expr    *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// 将 lines 作为规则添加到列表中
+//
+// base 为规则所在的目录，规则仅对该目录下的内容有效。
+// 如果规则格式不正确，返回该规则在 lines 中的下标以及错误信息。
+func (g *gitignore) append(base string, lines ...string) (int, error) {
+	ps, index, err := newGitignorePatterns(base, lines...)
+	if err != nil {
+		return index, err
+	}
+	g.patterns = append(g.patterns, ps...)
+	return 0, nil
+}
+
+// 加载 dir 目录下 .gitignore 文件中的规则
+//
+// 文件不存在时不作任何操作，base 为 dir 相对于 Input.Dir 的路径。
+// 加载的规则位于所有由 append 添加的规则之前。
+func (g *gitignore) load(dir, base string) error {
+	data, err := os.ReadFile(filepath.Join(dir, gitignoreFilename))
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	lines := make([]string, 0, 20)
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		lines = append(lines, s.Text())
+	}
+	if err := s.Err(); err != nil {
+		return err
+	}
+
+	ps, _, err := newGitignorePatterns(base, lines...)
+	if err != nil {
+		return err
+	}
+
+	patterns := make([]*gitignorePattern, 0, len(g.patterns)+len(ps))
+	patterns = append(patterns, g.patterns[:g.loaded]...)
+	patterns = append(patterns, ps...)
+	g.patterns = append(patterns, g.patterns[g.loaded:]...)
+	g.loaded += len(ps)
+	return nil
+}
+
+// path 是否被规则排除
+//
+// path 为相对于 Input.Dir 且以 / 作为分隔符的路径，dir 表示 path 是否为目录。
+func (g *gitignore) match(path string, dir bool) (matched bool) {
+	for _, p := range g.patterns {
+		if p.dirOnly && !dir {
+			continue
+		}
+
+		rel := path
+		if p.base != "" {
+			if !strings.HasPrefix(path, p.base+"/") {
+				continue
+			}
+			rel = path[len(p.base)+1:]
+		}
+
+		if p.expr.MatchString(rel) {
+			matched = !p.negate
+		}
+	}
+	return matched
+}
+
+// 将多行 .gitignore 规则转换成 gitignorePattern 列表
+//
+// 如果规则格式不正确，返回该规则在 lines 中的下标以及错误信息。
+func newGitignorePatterns(base string, lines ...string) ([]*gitignorePattern, int, error) {
+	ps := make([]*gitignorePattern, 0, len(lines))
+	for i, line := range lines {
+		p, err := newGitignorePattern(base, line)
+		if err != nil {
+			return nil, i, err
+		}
+		if p != nil {
+			ps = append(ps, p)
+		}
+	}
+	return ps, 0, nil
+}
+
+// 将一行 .gitignore 规则转换成 gitignorePattern，空行和注释返回 nil。
+func newGitignorePattern(base, line string) (*gitignorePattern, error) {
+	if strings.HasSuffix(line, `\ `) {
+		line = strings.TrimRight(line[:len(line)-2], " ") + `\ `
+	} else {
+		line = strings.TrimRight(line, " \t\r")
+	}
+	if line == "" || line[0] == '#' {
+		return nil, nil
+	}
+
+	p := &gitignorePattern{base: base}
+	if line[0] == '!' {
+		p.negate = true
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		p.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if line == "" {
+		return nil, nil
+	}
+
+	// 不包含 / 的规则可以匹配任意层级的内容，否则仅匹配相对于 base 的路径。
+	var expr strings.Builder
+	expr.WriteByte('^')
+	if !strings.Contains(line, "/") {
+		expr.WriteString("(?:.*/)?")
+	}
+	line = strings.TrimPrefix(line, "/")
+
+	segments := strings.Split(line, "/")
+	last := len(segments) - 1
+	for i, seg := range segments {
+		switch {
+		case seg == "**" && i == last: // 以 /** 结尾，匹配目录下的所有内容。
+			expr.WriteString(".*")
+		case seg == "**": // 以 **/ 开头或是中间的 /**/，匹配零个或是多个目录。
+			expr.WriteString("(?:.*/)?")
+		default:
+			expr.WriteString(globToRegexp(seg))
+			if i != last {
+				expr.WriteByte('/')
+			}
+		}
+	}
+	expr.WriteByte('$')
+
+	re, err := regexp.Compile(expr.String())
+	if err != nil {
+		return nil, err
+	}
+	p.expr = re
+	return p, nil
+}
+
+// 将不包含 / 的通配符转换成正则表达式
+func globToRegexp(glob string) string {
+	runes := []rune(glob)
+
+	var expr strings.Builder
+	for i := 0; i < len(runes); i++ {
+		switch r := runes[i]; r {
+		case '*':
+			expr.WriteString("[^/]*")
+		case '?':
+			expr.WriteString("[^/]")
+		case '\\':
+			if i+1 < len(runes) {
+				i++
+				expr.WriteString(regexp.QuoteMeta(string(runes[i])))
+			}
+		case '[':
+			end := i + 1
+			for end < len(runes) && runes[end] != ']' {
+				end++
+			}
+			if end == len(runes) { // 没有结束符，当作普通字符处理。
+				expr.WriteString(`\[`)
+				continue
+			}
+
+			class := string(runes[i+1 : end])
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			expr.WriteString("[" + class + "]")
+			i = end
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	return expr.String()
+}
@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: MIT
+
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/issue9/assert/v3"
+)
+
+func TestGitignore_match(t *testing.T) {
+	a := assert.New(t, false)
+
+	g := &gitignore{}
+	_, err := g.append("",
+		"# comment",
+		"",
+		"*.log",
+		"!keep.log",
+		"/root.txt",
+		"**/testdata/**",
+		"build/",
+		"a/**/z.go",
+		"doc/*.md",
+		"[abc].txt",
+	)
+	a.NotError(err).Length(g.patterns, 8)
+
+	data := []*struct {
+		path    string
+		dir     bool
+		matched bool
+	}{
+		{path: "x.log", matched: true},
+		{path: "sub/x.log", matched: true},
+		{path: "keep.log", matched: false},
+		{path: "sub/keep.log", matched: false},
+		{path: "root.txt", matched: true},
+		{path: "sub/root.txt", matched: false},
+		{path: "testdata/x.go", matched: true},
+		{path: "pkg/testdata/sub/x.go", matched: true},
+		{path: "testdata", dir: true, matched: false},
+		{path: "build", dir: true, matched: true},
+		{path: "sub/build", dir: true, matched: true},
+		{path: "build", matched: false},
+		{path: "a/z.go", matched: true},
+		{path: "a/b/c/z.go", matched: true},
+		{path: "b/a/z.go", matched: false},
+		{path: "doc/x.md", matched: true},
+		{path: "doc/sub/x.md", matched: false},
+		{path: "b.txt", matched: true},
+		{path: "d.txt", matched: false},
+	}
+	for _, item := range data {
+		a.Equal(g.match(item.path, item.dir), item.matched, "%s not match", item.path)
+	}
+
+	// 子目录中的规则
+	g = &gitignore{}
+	_, err = g.append("sub", "*.go", "/x.txt")
+	a.NotError(err)
+	a.True(g.match("sub/a.go", false)).
+		True(g.match("sub/dir/a.go", false)).
+		False(g.match("a.go", false)).
+		False(g.match("subdir/a.go", false)).
+		True(g.match("sub/x.txt", false)).
+		False(g.match("sub/dir/x.txt", false))
+
+	// 格式错误
+	g = &gitignore{}
+	index, err := g.append("", "*.go", "[z-a]")
+	a.Error(err).Equal(index, 1)
+}
+
+func TestGitignore_load(t *testing.T) {
+	a := assert.New(t, false)
+	dir := t.TempDir()
+
+	g := &gitignore{}
+	a.NotError(g.load(dir, "")).Empty(g.patterns)
+
+	a.NotError(os.WriteFile(filepath.Join(dir, gitignoreFilename), []byte("# comment\n*.log\r\n!keep.log\n"), os.ModePerm))
+	a.NotError(g.load(dir, "sub")).Length(g.patterns, 2)
+	a.True(g.match("sub/x.log", false)).
+		False(g.match("sub/keep.log", false)).
+		False(g.match("x.log", false))
+
+	// 由 append 添加的规则优先于 .gitignore 文件中的规则
+	g = &gitignore{}
+	_, err := g.append("", "keep.log")
+	a.NotError(err)
+	a.NotError(g.load(dir, "")).Length(g.patterns, 3).Equal(g.loaded, 2)
+	a.True(g.match("keep.log", false)).
+		True(g.match("x.log", false))
+}
@@ -5,7 +5,7 @@ package build
 import (
 	"os"
 	"path/filepath"
-	"strings"
+	"strconv"
 	"sync"
 
 	"github.com/issue9/sliceutil"
@@ -24,9 +24,13 @@ type Input struct {
 	Exts      []string `yaml:"exts,omitempty"`      // 需要扫描的文件扩展名，为空则表示采用默认规则。
 	Recursive bool     `yaml:"recursive,omitempty"` // 是否查找 Dir 的子目录
 	Encoding  string   `yaml:"encoding,omitempty"`  // 源文件的编码，默认为 UTF-8
-	Ignores   []string `yaml:"ignores,omitempty"`   // 忽略的文件或目录，比如 node_modules 等可在此指定
+	Ignores   []string `yaml:"ignores,omitempty"`   // 忽略的文件或目录，采用 .gitignore 的语法，比如 node_modules、**/testdata/** 等。
+	GitIgnore bool     `yaml:"gitignore,omitempty"` // 是否同时采用 Dir 及其子目录中 .gitignore 文件的规则，优先级低于 Ignores。
+	Files     []string `yaml:"files,omitempty"`     // 需要解析的文件，采用 .gitignore 的语法，指定之后不再根据 Exts 查找文件。
+	Doc       string   `yaml:"doc,omitempty"`       // 未指定 doc 属性的 api 所属的文档 ID
+	Cache     core.URI `yaml:"cache,omitempty"`     // 缓存目录，从源文件中提取的代码块会缓存在此，为空表示不缓存。
 
-	paths     []core.URI        // 根据 Dir、Exts、Ignores 和 Recursive 生成
+	paths     []core.URI        // 根据 Dir、Exts、Files、Ignores 和 Recursive 生成
 	encoding  encoding.Encoding // 根据 Encoding 生成
 	sanitized bool
 }
@@ -89,6 +93,16 @@ func (o *Input) sanitize() error {
 		}
 	}
 
+	if o.Cache != "" {
+		local, err := o.Cache.File()
+		if err != nil {
+			return core.WithError(err).WithField("cache")
+		}
+		if err := os.MkdirAll(local, os.ModePerm); err != nil {
+			return core.WithError(err).WithField("cache")
+		}
+	}
+
 	o.sanitized = true
 	return nil
 }
@@ -101,23 +115,46 @@ func (o *Input) recursivePath() error {
 	}
 	local = filepath.Clean(local)
 
-	for i, pattern := range o.Ignores {
-		o.Ignores[i] = filepath.FromSlash(pattern)
+	ignores := &gitignore{}
+	if index, err := ignores.append("", o.Ignores...); err != nil {
+		return core.NewError(locale.ErrInvalidFormat).WithField("ignores[" + strconv.Itoa(index) + "]")
+	}
+
+	var files *gitignore
+	if len(o.Files) > 0 {
+		files = &gitignore{}
+		if index, err := files.append("", o.Files...); err != nil {
+			return core.NewError(locale.ErrInvalidFormat).WithField("files[" + strconv.Itoa(index) + "]")
+		}
 	}
 
 	walk := func(path string, fi os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
-		if fi.IsDir() && !o.Recursive && path != local {
-			return filepath.SkipDir
-		}
 
-		ignore, err := o.isIgnore(local, path)
+		rel, err := filepath.Rel(local, path)
 		if err != nil {
 			return err
 		}
-		if !ignore {
+		rel = filepath.ToSlash(rel)
+
+		if fi.IsDir() {
+			if path != local && (!o.Recursive || ignores.match(rel, true)) {
+				return filepath.SkipDir
+			}
+
+			if o.GitIgnore {
+				base := rel
+				if path == local {
+					base = ""
+				}
+				return ignores.load(path, base)
+			}
+			return nil
+		}
+
+		if !o.isIgnore(ignores, files, rel) {
 			o.paths = append(o.paths, core.FileURI(path))
 		}
 		return nil
@@ -133,32 +170,29 @@ func (o *Input) recursivePath() error {
 	return nil
 }
 
-func (o *Input) isIgnore(root, path string) (bool, error) {
-	ext := filepath.Ext(path)
-	if sliceutil.Count(o.Exts, func(i string) bool { return i == ext }) == 0 {
-		return true, nil
-	}
-
-	path = strings.TrimPrefix(filepath.FromSlash(path), filepath.FromSlash(root))
-	if path == "" {
-		return false, nil
-	}
-
-	if path[0] == '/' || path[0] == os.PathSeparator {
-		path = path[1:]
-	}
+// 重新查找符合条件的文件列表
+func (o *Input) refresh() error {
+	o.paths = nil
+	return o.recursivePath()
+}
 
-	for _, ignore := range o.Ignores {
-		match, err := filepath.Match(ignore, path)
-		if err != nil {
-			return false, err
+// 文件是否需要忽略
+//
+// 指定了 files 时，仅保留与之匹配的文件，否则仅保留扩展名在 Exts 中的文件，
+// 之后再排除与 ignores 匹配的文件。path 为相对于 Dir 且以 / 作为分隔符的路径。
+func (o *Input) isIgnore(ignores, files *gitignore, path string) bool {
+	if files != nil {
+		if !files.match(path, false) {
+			return true
 		}
-		if match {
-			return true, nil
+	} else {
+		ext := filepath.Ext(path)
+		if sliceutil.Count(o.Exts, func(i string) bool { return i == ext }) == 0 {
+			return true
 		}
 	}
 
-	return false, nil
+	return ignores.match(path, false)
 }
 
 // ParseInputs 分析 opt 中所指定的内容并输出到 blocks
@@ -186,8 +220,13 @@ func (o *Input) ParseFile(blocks chan core.Block, h *core.MessageHandler, uri co
 		return
 	}
 
-	lang.Parse(h, o.Lang, core.Block{
+	block := core.Block{
 		Data:     data,
 		Location: core.Location{URI: uri},
-	}, blocks)
+	}
+	if o.Cache != "" {
+		o.parseCache(blocks, h, block)
+		return
+	}
+	lang.Parse(h, o.Lang, block, blocks)
 }
@@ -3,6 +3,8 @@
 package build
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/issue9/assert/v3"
@@ -216,4 +218,67 @@ func TestInput_recursivePath(t *testing.T) {
 	}
 	err = opt.recursivePath()
 	a.Error(err).Empty(opt.paths)
+
+	// 格式错误的 ignores
+	opt = &Input{
+		Dir:     "./testdata",
+		Exts:    []string{".c"},
+		Ignores: []string{"*.h", "[z-a]"},
+	}
+	err = opt.recursivePath()
+	a.Error(err).Equal(err.(*core.Error).Field, "ignores[1]")
+}
+
+func TestInput_recursivePath_gitignore(t *testing.T) {
+	a := assert.New(t, false)
+	dir := t.TempDir()
+
+	write := func(path, content string) {
+		path = filepath.Join(dir, filepath.FromSlash(path))
+		a.NotError(os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		a.NotError(os.WriteFile(path, []byte(content), os.ModePerm))
+	}
+	write("api.go", "package api")
+	write("vendor/v.go", "package vendor")
+	write("pkg/testdata/t.go", "package testdata")
+	write("pkg/p.go", "package pkg")
+	write("pkg/p_gen.go", "package pkg")
+	write("pkg/keep_gen.go", "package pkg")
+	write("pkg/"+gitignoreFilename, "*_gen.go\n!keep_gen.go\n")
+	write(gitignoreFilename, "vendor/\n")
+
+	paths := func(o *Input) []string {
+		a.NotError(o.recursivePath())
+		list := make([]string, 0, len(o.paths))
+		for _, uri := range o.paths {
+			local, err := uri.File()
+			a.NotError(err)
+			rel, err := filepath.Rel(dir, local)
+			a.NotError(err)
+			list = append(list, filepath.ToSlash(rel))
+		}
+		return list
+	}
+
+	o := &Input{Dir: core.FileURI(dir), Recursive: true, Exts: []string{".go"}, Ignores: []string{"**/testdata/**"}}
+	a.Equal(paths(o), []string{"api.go", "pkg/keep_gen.go", "pkg/p.go", "pkg/p_gen.go", "vendor/v.go"})
+
+	o = &Input{Dir: core.FileURI(dir), Recursive: true, Exts: []string{".go"}, Ignores: []string{"**/testdata/**"}, GitIgnore: true}
+	a.Equal(paths(o), []string{"api.go", "pkg/keep_gen.go", "pkg/p.go"})
+
+	// ignores 的优先级高于 .gitignore 中的规则
+	o = &Input{Dir: core.FileURI(dir), Recursive: true, Exts: []string{".go"}, Ignores: []string{"**/testdata/**", "keep_gen.go"}, GitIgnore: true}
+	a.Equal(paths(o), []string{"api.go", "pkg/p.go"})
+
+	// ignores 中的取反规则
+	o = &Input{Dir: core.FileURI(dir), Recursive: true, Exts: []string{".go"}, Ignores: []string{"pkg/*", "!pkg/p.go"}}
+	a.Equal(paths(o), []string{"api.go", "pkg/p.go", "vendor/v.go"})
+
+	// 指定 files 之后不再使用 exts
+	o = &Input{Dir: core.FileURI(dir), Recursive: true, Exts: []string{".php"}, Files: []string{"pkg/*.go"}, Ignores: []string{"*_gen.go"}}
+	a.Equal(paths(o), []string{"pkg/p.go"})
+
+	o = &Input{Dir: core.FileURI(dir), Recursive: true, Files: []string{"*.go", "[z-a]"}}
+	err := o.recursivePath()
+	a.Error(err).Equal(err.(*core.Error).Field, "files[1]")
 }
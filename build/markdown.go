@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: MIT
+
+package build
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/internal/ast"
+	"github.com/caixw/apidoc/v7/internal/locale"
+	"github.com/caixw/apidoc/v7/internal/markdown"
+)
+
+// 链接地址中表示文档内 api 的前缀，比如 api:get-users 表示 id 为 get-users 的 api。
+const apiLinkPrefix = "api:"
+
+// 将 d 中所有 markdown 格式的富文本转换成 HTML
+//
+// 以 api: 开头的链接地址会被转换成文档中对应 api 的锚点，
+// 其它的相对地址会相对于 Output.BaseURL 进行转换。
+// 无效的链接以警告的形式输出至 h，同时只保留链接的文本内容。
+func (o *Output) renderMarkdown(h *core.MessageHandler, d *ast.APIDoc) {
+	walkRichtext(reflect.ValueOf(d), func(r *ast.Richtext) {
+		if r.Type.V() != ast.RichtextTypeMarkdown || r.Text == nil {
+			return
+		}
+
+		r.Text.Value.Value = markdown.Render(r.V(), func(link string) string {
+			ret, ok := o.resolveLink(d, link)
+			if !ok {
+				h.Warning(r.Location.NewError(locale.ErrInvalidURI, link).WithField(r.StartTag.String()))
+			}
+			return ret
+		})
+		r.Type.Value.Value = ast.RichtextTypeHTML
+	})
+}
+
+// 转换链接地址，如果链接无效，则返回 false。
+func (o *Output) resolveLink(d *ast.APIDoc, link string) (string, bool) {
+	if strings.HasPrefix(link, apiLinkPrefix) {
+		id := strings.TrimPrefix(link, apiLinkPrefix)
+		for _, api := range d.APIs {
+			if api.ID.V() == id {
+				return "#" + apiAnchor(api), true
+			}
+		}
+		return "", false
+	}
+
+	u, err := url.Parse(link)
+	if err != nil {
+		return "", false
+	}
+	if o.baseURL == nil || u.IsAbs() || strings.HasPrefix(link, "#") {
+		return link, true
+	}
+	return o.baseURL.ResolveReference(u).String(), true
+}
+
+// 返回 api 在文档中的锚点
+//
+// 与 apidoc.xsl 中生成的 id 保持一致。
+func apiAnchor(api *ast.API) string {
+	var srv string
+	if len(api.Servers) > 0 {
+		srv = api.Servers[0].V()
+	}
+
+	path := strings.NewReplacer("{", "_", "}", "_", "/", "-").Replace(api.Path.Path.V())
+	return srv + api.Method.V() + path
+}
+
+// 查找 v 中所有的 ast.Richtext 并调用 f
+//
+// 仅查找公开的字段，同一个对象只会被处理一次。
+func walkRichtext(v reflect.Value, f func(*ast.Richtext)) {
+	visited := make(map[uintptr]struct{}, 100)
+	richtextType := reflect.TypeOf(&ast.Richtext{})
+
+	var walk func(reflect.Value)
+	walk = func(v reflect.Value) {
+		switch v.Kind() {
+		case reflect.Pointer:
+			if v.IsNil() {
+				return
+			}
+			if _, found := visited[v.Pointer()]; found {
+				return
+			}
+			visited[v.Pointer()] = struct{}{}
+
+			if v.Type() == richtextType {
+				f(v.Interface().(*ast.Richtext))
+				return
+			}
+			walk(v.Elem())
+		case reflect.Slice:
+			for i := 0; i < v.Len(); i++ {
+				walk(v.Index(i))
+			}
+		case reflect.Struct:
+			t := v.Type()
+			for i := 0; i < t.NumField(); i++ {
+				if t.Field(i).IsExported() {
+					walk(v.Field(i))
+				}
+			}
+		}
+	}
+	walk(v)
+}
@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: MIT
+
+package build
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v3"
+
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/core/messagetest"
+	"github.com/caixw/apidoc/v7/internal/ast"
+	"github.com/caixw/apidoc/v7/internal/xmlenc"
+)
+
+func newRichtext(typ, text string) *ast.Richtext {
+	return &ast.Richtext{
+		BaseTag: xmlenc.BaseTag{StartTag: xmlenc.Name{Local: xmlenc.String{Value: "description"}}},
+		Type:    &ast.Attribute{Value: xmlenc.String{Value: typ}},
+		Text:    &ast.CData{Value: xmlenc.String{Value: text}},
+	}
+}
+
+func TestOutput_renderMarkdown(t *testing.T) {
+	a := assert.New(t, false)
+
+	api := &ast.API{
+		ID:          &ast.Attribute{Value: xmlenc.String{Value: "get-user"}},
+		Method:      &ast.MethodAttribute{Value: xmlenc.String{Value: "GET"}},
+		Path:        &ast.Path{Path: &ast.Attribute{Value: xmlenc.String{Value: "/users/{id}"}}},
+		Servers:     []*ast.ServerValue{{Content: ast.Content{Value: "admin"}}},
+		Description: newRichtext(ast.RichtextTypeMarkdown, "[users](api:get-user) [x](api:not-exists)"),
+	}
+	html := newRichtext(ast.RichtextTypeHTML, "[html](docs/a.md)")
+	d := &ast.APIDoc{
+		Description: newRichtext(ast.RichtextTypeMarkdown, "# title\n\n[a](docs/a.md) [b](https://example.org/b) [c](#c)"),
+		APIs:        []*ast.API{api},
+		Types: []*ast.TypeDef{{
+			Items: []*ast.Param{{Description: html}},
+		}},
+	}
+
+	o := &Output{Markdown: true, BaseURL: "https://example.com/v1/"}
+	a.NotError(o.sanitize())
+	rslt := messagetest.NewMessageHandler()
+	o.renderMarkdown(rslt.Handler, d)
+	rslt.Handler.Stop()
+
+	a.Equal(d.Description.Type.V(), ast.RichtextTypeHTML).
+		Equal(d.Description.V(), `<h1>title</h1>
+<p><a href="https://example.com/v1/docs/a.md">a</a> <a href="https://example.org/b">b</a> <a href="#c">c</a></p>`)
+
+	a.Equal(api.Description.V(), `<p><a href="#adminGET-users-_id_">users</a> x</p>`)
+	a.Equal(html.V(), "[html](docs/a.md)")
+
+	a.Empty(rslt.Errors).Length(rslt.Warns, 1)
+	err, ok := rslt.Warns[0].(*core.Error)
+	a.True(ok).Equal(err.Field, "description")
+}
@@ -5,11 +5,15 @@ package build
 import (
 	"bytes"
 	"encoding/xml"
+	"net/url"
+	"path"
 	"strings"
 	"time"
 
 	"github.com/issue9/errwrap"
+	"github.com/issue9/sliceutil"
 	"github.com/issue9/version"
+	textlang "golang.org/x/text/language"
 
 	"github.com/caixw/apidoc/v7/core"
 	"github.com/caixw/apidoc/v7/internal/ast"
@@ -47,6 +51,35 @@ type Output struct {
 	// 只输出该标签的文档，若为空，则表示所有。
 	Tags []string `yaml:"tags,omitempty"`
 
+	// 只输出符合这些条件的 API，与 Tags 同时指定时，需要同时满足两者。
+	Include *Filter `yaml:"include,omitempty"`
+
+	// 不输出符合这些条件的 API，优先级高于 Tags 和 Include。
+	Exclude *Filter `yaml:"exclude,omitempty"`
+
+	// 只输出该 ID 的文档
+	//
+	// 若为空，Build 会为每一份文档输出一个文件，
+	// 而 Buffer 则采用未指定 ID 的文档，如果不存在这样的文档，则采用第一份文档。
+	// 指定的文档不存在时，Build 和 Buffer 都会返回错误。
+	Doc string `yaml:"doc,omitempty"`
+
+	// 只输出该版本号中可用的内容
+	//
+	// since 属性晚于该版本号的内容将不会被输出，若为空，则表示所有。
+	Target string `yaml:"target,omitempty"`
+
+	// 是否删除在 Target 之前的版本就已经被弃用的内容
+	//
+	// 仅在 Target 不为空时有效。
+	RemoveDeprecated bool `yaml:"remove-deprecated,omitempty"`
+
+	// 输出文档的语言
+	//
+	// 应该使用 BCP47 指定的格式，文档中与该语言匹配的 translation 会替换原有的内容，
+	// 不存在匹配项的则保留文档中的默认内容。若为空，则原样输出所有语言的内容。
+	Locale string `yaml:"locale,omitempty"`
+
 	// xslt 文件地址
 	//
 	// 默认值为 https://apidoc.tools/docs/ 下当前版本的 apidoc.xsl，比如：
@@ -65,9 +98,22 @@ type Output struct {
 	Namespace       bool   `yaml:"namespace,omitempty"`
 	NamespacePrefix string `yaml:"namespace-prefix,omitempty"`
 
+	// 是否将 markdown 格式的富文本转换成 HTML
+	//
+	// 转换后的内容会过滤掉其中的 HTML 代码以及不安全的链接。
+	// 链接地址可以使用 api:id 的形式指向文档中指定 id 的 api。
+	Markdown bool `yaml:"markdown,omitempty"`
+
+	// markdown 中相对链接的基地址
+	//
+	// 仅在 Markdown 为 true 时有效，若为空，则不对相对链接作转换。
+	BaseURL string `yaml:"base-url,omitempty"`
+
 	procInst []string  // 保存所有 xml 的指令内容，包括编码信息
 	marshal  marshaler // Type 对应的转换函数
 	xml      bool      // 是否为 xml 内容
+	baseURL  *url.URL  // BaseURL 解析后的值
+	locale   textlang.Tag
 }
 
 func (o *Output) contains(tags ...string) bool {
@@ -96,6 +142,30 @@ func (o *Output) sanitize() error {
 		}
 	}
 
+	if o.Target != "" && !version.SemVerValid(o.Target) {
+		return core.NewError(locale.ErrInvalidFormat).WithField("target")
+	}
+
+	if o.Include != nil {
+		if err := o.Include.sanitize("include"); err != nil {
+			return err
+		}
+	}
+
+	if o.Exclude != nil {
+		if err := o.Exclude.sanitize("exclude"); err != nil {
+			return err
+		}
+	}
+
+	if o.Locale != "" {
+		tag, err := textlang.Parse(o.Locale)
+		if err != nil {
+			return core.NewError(locale.ErrInvalidFormat).WithField("locale")
+		}
+		o.locale = tag
+	}
+
 	switch o.Type {
 	case APIDocXML:
 		o.marshal = o.apidocMarshaler
@@ -107,6 +177,14 @@ func (o *Output) sanitize() error {
 		return core.NewError(locale.ErrInvalidValue).WithField("type")
 	}
 
+	if o.BaseURL != "" {
+		u, err := url.Parse(o.BaseURL)
+		if err != nil || !u.IsAbs() {
+			return core.NewError(locale.ErrInvalidFormat).WithField("base-url")
+		}
+		o.baseURL = u
+	}
+
 	o.xml = strings.HasSuffix(o.Type, "+xml")
 	if o.xml {
 		if o.Style == "" {
@@ -129,6 +207,65 @@ func (o *Output) sanitize() error {
 	return nil
 }
 
+// DocPath 返回 ID 为 id 的文档的保存路径
+//
+// id 为空时即为 Path，否则会在 Path 的扩展名之前插入 id，
+// 比如 Path 为 apidoc.xml，id 为 admin，则返回 apidoc.admin.xml。
+func (o *Output) DocPath(id string) core.URI {
+	if id == "" {
+		return o.Path
+	}
+
+	p := string(o.Path)
+	ext := path.Ext(p)
+	return core.URI(strings.TrimSuffix(p, ext) + "." + id + ext)
+}
+
+// 从 docs 中查找需要输出的文档
+//
+// 如果 Doc 指定的文档不存在，则返回错误；未指定 Doc 且 docs 中没有文档时，返回一个空的文档对象。
+func (o *Output) doc(docs *ast.Documents) (*ast.APIDoc, error) {
+	if doc := docs.Doc(o.Doc); doc != nil {
+		return doc, nil
+	}
+
+	if o.Doc != "" {
+		return nil, core.NewError(locale.ErrNotFound).WithField("doc")
+	}
+	if len(docs.Docs) > 0 {
+		return docs.Docs[0], nil
+	}
+	return &ast.APIDoc{}, nil
+}
+
+// 将 docs 中的文档输出到 Path
+//
+// 具体规则可参考 Build 函数的相关文档。
+func (o *Output) write(h *core.MessageHandler, docs *ast.Documents) error {
+	if o.Doc != "" || len(docs.Docs) <= 1 {
+		doc, err := o.doc(docs)
+		if err != nil {
+			return err
+		}
+		buf, err := o.buffer(h, doc)
+		if err != nil {
+			return err
+		}
+		return o.Path.WriteAll(buf.Bytes())
+	}
+
+	for _, doc := range docs.Docs {
+		buf, err := o.buffer(h, doc)
+		if err != nil {
+			return err
+		}
+		if err := o.DocPath(doc.ID.V()).WriteAll(buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (o *Output) apidocMarshaler(d *ast.APIDoc) ([]byte, error) {
 	if !o.Namespace {
 		return xmlenc.Encode("\t", d, "", "")
@@ -136,8 +273,17 @@ func (o *Output) apidocMarshaler(d *ast.APIDoc) ([]byte, error) {
 	return xmlenc.Encode("\t", d, core.XMLNamespace, o.NamespacePrefix)
 }
 
-func (o *Output) buffer(d *ast.APIDoc) (*bytes.Buffer, error) {
+func (o *Output) buffer(h *core.MessageHandler, d *ast.APIDoc) (*bytes.Buffer, error) {
 	filterDoc(d, o)
+	if o.Target != "" {
+		d.FilterVersion(o.Target, o.RemoveDeprecated)
+	}
+	if o.Locale != "" {
+		d.Translate(o.locale)
+	}
+	if o.Markdown {
+		o.renderMarkdown(h, d)
+	}
 
 	if o.Version != "" {
 		d.Version = &ast.VersionAttribute{Value: xmlenc.String{Value: o.Version}}
@@ -165,28 +311,27 @@ func (o *Output) buffer(d *ast.APIDoc) (*bytes.Buffer, error) {
 	return &buf.Buffer, nil
 }
 
+// 按 Tags、Include 和 Exclude 过滤文档中的 API
+//
+// 如果有过滤条件，还会删除文档中不再被引用的内容，具体可参考 pruneDoc。
 func filterDoc(d *ast.APIDoc, o *Output) {
-	if len(o.Tags) == 0 {
+	if len(o.Tags) == 0 && o.Include.isEmpty() && o.Exclude.isEmpty() {
 		return
 	}
 
-	tags := make([]*ast.Tag, 0, len(o.Tags))
-	for _, tag := range d.Tags {
-		if o.contains(tag.Name.V()) {
-			tags = append(tags, tag)
-		}
+	d.APIs = sliceutil.Delete(d.APIs, func(api *ast.API) bool { return !o.match(d, api) })
+	pruneDoc(d, o)
+}
+
+// api 是否需要输出
+func (o *Output) match(d *ast.APIDoc, api *ast.API) bool {
+	if len(o.Tags) > 0 && sliceutil.Count(api.Tags, func(tag *ast.TagValue) bool { return o.contains(tag.V()) }) == 0 {
+		return false
 	}
-	d.Tags = tags
 
-	apis := make([]*ast.API, 0, len(d.APIs))
-LOOP:
-	for _, api := range d.APIs {
-		for _, tag := range api.Tags {
-			if o.contains(tag.V()) {
-				apis = append(apis, api)
-				continue LOOP
-			}
-		}
+	if !o.Include.isEmpty() && !o.Include.match(d, api) {
+		return false
 	}
-	d.APIs = apis
+
+	return o.Exclude.isEmpty() || !o.Exclude.match(d, api)
 }
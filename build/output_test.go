@@ -8,8 +8,11 @@ import (
 	"github.com/issue9/assert/v3"
 
 	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/core/messagetest"
+	"github.com/caixw/apidoc/v7/internal/ast"
 	"github.com/caixw/apidoc/v7/internal/ast/asttest"
 	"github.com/caixw/apidoc/v7/internal/docs"
+	"github.com/caixw/apidoc/v7/internal/xmlenc"
 )
 
 func TestOptions_contains(t *testing.T) {
@@ -47,6 +50,21 @@ func TestOutput_Sanitize(t *testing.T) {
 	a.NotError(o.sanitize())
 	o.Version = "1"
 	a.Error(o.sanitize())
+
+	o = &Output{Target: "1.0.0"}
+	a.NotError(o.sanitize())
+	o.Target = "1"
+	a.Error(o.sanitize())
+
+	o = &Output{BaseURL: "https://example.com/docs/"}
+	a.NotError(o.sanitize()).NotNil(o.baseURL)
+	o.BaseURL = "/docs/"
+	a.Error(o.sanitize())
+
+	o = &Output{Locale: "cmn-Hant"}
+	a.NotError(o.sanitize()).Equal(o.locale.String(), "cmn-Hant")
+	o.Locale = "not a locale"
+	a.Error(o.sanitize())
 }
 
 func TestOptions_buffer(t *testing.T) {
@@ -58,14 +76,33 @@ func TestOptions_buffer(t *testing.T) {
 		Path: "./openapi.json",
 	}
 	a.NotError(o.sanitize())
-	_, err := o.buffer(doc)
+	rslt := messagetest.NewMessageHandler()
+	_, err := o.buffer(rslt.Handler, doc)
 	a.NotError(err)
 
 	doc = asttest.Get()
 	o = &Output{}
 	a.NotError(o.sanitize())
-	buf, err := o.buffer(doc)
+	buf, err := o.buffer(rslt.Handler, doc)
 	a.NotError(err).NotNil(buf)
+
+	doc = asttest.Get()
+	l := len(doc.APIs)
+	o = &Output{Target: "1.1.0", RemoveDeprecated: true}
+	a.NotError(o.sanitize())
+	buf, err = o.buffer(rslt.Handler, doc)
+	a.NotError(err).NotNil(buf).
+		Equal(len(doc.APIs), l-1).
+		NotContains(buf.String(), `deprecated="1.0.1"`)
+
+	doc = asttest.Get()
+	o = &Output{Type: OpenapiYAML, Locale: "en"}
+	a.NotError(o.sanitize())
+	buf, err = o.buffer(rslt.Handler, doc)
+	a.NotError(err).NotNil(buf).
+		Empty(doc.Lang.V()) // 文档中没有翻译内容，不会修改 lang
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors)
 }
 
 func TestFilterDoc(t *testing.T) {
@@ -113,3 +150,41 @@ func TestFilterDoc(t *testing.T) {
 	a.Equal(0, len(d.Tags)).
 		Equal(0, len(d.APIs))
 }
+
+func TestOutput_DocPath(t *testing.T) {
+	a := assert.New(t, false)
+
+	o := &Output{Path: "./docs/apidoc.xml"}
+	a.Equal(o.DocPath(""), o.Path).
+		Equal(o.DocPath("admin"), core.URI("./docs/apidoc.admin.xml"))
+
+	o = &Output{Path: "./docs/apidoc"}
+	a.Equal(o.DocPath("admin"), core.URI("./docs/apidoc.admin"))
+}
+
+func TestOutput_doc(t *testing.T) {
+	a := assert.New(t, false)
+
+	public := asttest.Get()
+	admin := asttest.Get()
+	admin.ID = &ast.Attribute{Value: xmlenc.String{Value: "admin"}}
+	d := &ast.Documents{Docs: []*ast.APIDoc{admin, public}}
+
+	o := &Output{}
+	doc, err := o.doc(d)
+	a.NotError(err).Equal(doc, public)
+
+	o.Doc = "admin"
+	doc, err = o.doc(d)
+	a.NotError(err).Equal(doc, admin)
+
+	o.Doc = "not-exists"
+	doc, err = o.doc(d)
+	a.Error(err).Nil(doc)
+
+	o.Doc = ""
+	doc, err = o.doc(&ast.Documents{Docs: []*ast.APIDoc{admin}})
+	a.NotError(err).Equal(doc, admin)
+	doc, err = o.doc(&ast.Documents{})
+	a.NotError(err).Empty(doc.Title.V())
+}
@@ -0,0 +1,244 @@
+// SPDX-License-Identifier: MIT
+
+package build
+
+import (
+	"context"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/internal/ast"
+	"github.com/caixw/apidoc/v7/internal/xmlenc"
+)
+
+// Watcher 监视配置文件以及输入项中的文件，在文件发生变化时重新解析文档
+//
+// 如果仅是文件内容有变化，那么只会重新解析这些文件，其它文件的内容保持不变；
+// 配置文件有变化、文件被添加或删除，又或是有变化的文件中包含了 apidoc 元素，
+// 都会重新解析所有的文件。
+type Watcher struct {
+	h      *core.MessageHandler
+	wd     core.URI
+	output bool
+
+	cfg   *Config
+	docs  *ast.Documents
+	files map[core.URI]*watchedFile // 所有被监视的文件，包括配置文件。
+	apis  map[string]string         // 各个 API 编码后的内容，用于比较两次解析之间的差异。
+}
+
+type watchedFile struct {
+	input   *Input // 文件所属的输入项，配置文件为空。
+	modTime time.Time
+	size    int64
+}
+
+// Diff 表示两次解析之间 API 的变化
+//
+// 每个元素表示一个 API，由请求方法和路径组成，比如 GET /users，
+// 如果 API 所属的文档指定了 ID，还会加上该 ID 作为前缀，比如 admin: GET /users。
+type Diff struct {
+	Added   []string
+	Changed []string
+	Removed []string
+}
+
+// NewWatcher 加载 wd 目录下的配置文件并声明 Watcher 实例
+//
+// output 表示每次解析之后是否输出到配置文件中的所有输出项，为 false 时仅检测语法。
+// 声明时会完整地解析一次文档。
+//
+// 如果是配置文件有问题，则直接返回错误信息，文档错误则输出至 h 对象。
+func NewWatcher(h *core.MessageHandler, wd core.URI, output bool) (*Watcher, error) {
+	cfg, err := LoadConfig(wd)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Watcher{
+		h:      h,
+		wd:     wd,
+		output: output,
+	}
+	w.reload(cfg)
+	return w, nil
+}
+
+// Watch 每隔 interval 检测一次文件的变化，直到 ctx 被取消
+//
+// 每次重新解析之后，都会以 API 的变化作为参数调用 f。
+// 之后配置文件中的错误也会输出至 h 对象，并继续使用原来的配置。
+func (w *Watcher) Watch(ctx context.Context, interval time.Duration, f func(*Diff)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if diff := w.check(); diff != nil {
+				f(diff)
+			}
+		}
+	}
+}
+
+// 检测文件的变化并重新解析，没有变化时返回 nil。
+func (w *Watcher) check() *Diff {
+	if f := statFile(w.cfg.path, nil); !f.equal(w.files[w.cfg.path]) {
+		cfg, err := LoadConfig(w.wd)
+		if err != nil {
+			w.h.Error(err)
+			w.files[w.cfg.path] = f // 配置文件未再次修改之前，不再重复报错。
+			return nil
+		}
+		return w.reload(cfg)
+	}
+
+	files := w.scan(w.cfg)
+	if len(files) != len(w.files) {
+		return w.reload(w.cfg)
+	}
+
+	modified := make(map[core.URI]*Input, 10)
+	for uri, f := range files {
+		old, found := w.files[uri]
+		if !found {
+			return w.reload(w.cfg)
+		}
+		if !f.equal(old) {
+			modified[uri] = f.input
+		}
+	}
+	if len(modified) == 0 {
+		return nil
+	}
+
+	for _, doc := range w.docs.Docs {
+		if _, found := modified[doc.URI]; found {
+			return w.reload(w.cfg)
+		}
+	}
+
+	w.files = files
+	for uri := range modified {
+		w.docs.DeleteURI(uri)
+	}
+	w.docs.ParseBlocks(w.h, func(blocks chan core.Block) {
+		wg := &sync.WaitGroup{}
+		for uri, i := range modified {
+			wg.Add(1)
+			go func(uri core.URI, i *Input) {
+				i.ParseFile(blocks, w.h, uri)
+				wg.Done()
+			}(uri, i)
+		}
+		wg.Wait()
+	})
+
+	return w.rebuild()
+}
+
+// 采用 cfg 重新解析所有的文件
+func (w *Watcher) reload(cfg *Config) *Diff {
+	w.cfg = cfg
+	w.files = w.scan(cfg)
+	w.docs = NewDocuments(cfg.Inputs...)
+	w.docs.ParseBlocks(w.h, func(blocks chan core.Block) {
+		ParseInputs(blocks, w.h, cfg.Inputs...)
+	})
+
+	return w.rebuild()
+}
+
+// 查找 cfg 中需要监视的所有文件
+func (w *Watcher) scan(cfg *Config) map[core.URI]*watchedFile {
+	files := make(map[core.URI]*watchedFile, 100)
+	files[cfg.path] = statFile(cfg.path, nil)
+
+	for _, i := range cfg.Inputs {
+		if err := i.refresh(); err != nil {
+			w.h.Error(err)
+		}
+
+		for _, path := range i.paths {
+			files[path] = statFile(path, i)
+		}
+	}
+
+	return files
+}
+
+// 输出文档并返回与上一次解析之间的差异
+func (w *Watcher) rebuild() *Diff {
+	if w.output {
+		w.cfg.write(w.h, w.docs)
+	}
+
+	apis := make(map[string]string, len(w.apis))
+	for _, doc := range w.docs.Docs {
+		for _, api := range doc.APIs {
+			data, err := xmlenc.Encode("", api, "", "")
+			if err != nil {
+				w.h.Error(err)
+				continue
+			}
+			apis[apiName(doc, api)] = string(data)
+		}
+	}
+
+	diff := &Diff{}
+	for name, data := range apis {
+		if old, found := w.apis[name]; !found {
+			diff.Added = append(diff.Added, name)
+		} else if old != data {
+			diff.Changed = append(diff.Changed, name)
+		}
+	}
+	for name := range w.apis {
+		if _, found := apis[name]; !found {
+			diff.Removed = append(diff.Removed, name)
+		}
+	}
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Changed)
+	sort.Strings(diff.Removed)
+
+	w.apis = apis
+	return diff
+}
+
+func apiName(doc *ast.APIDoc, api *ast.API) string {
+	name := api.Method.V()
+	if api.Path != nil {
+		name += " " + api.Path.Path.V()
+	}
+
+	if id := doc.ID.V(); id != "" {
+		name = id + ": " + name
+	}
+	return name
+}
+
+// 获取文件的状态，文件不存在或是无法访问时，仅记录 input 的值。
+func statFile(uri core.URI, input *Input) *watchedFile {
+	f := &watchedFile{input: input}
+
+	local, err := uri.File()
+	if err != nil {
+		return f
+	}
+	if stat, err := os.Stat(local); err == nil {
+		f.modTime = stat.ModTime()
+		f.size = stat.Size()
+	}
+	return f
+}
+
+func (f *watchedFile) equal(v *watchedFile) bool {
+	return v != nil && f.modTime.Equal(v.modTime) && f.size == v.size
+}
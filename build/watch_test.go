@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: MIT
+
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/issue9/assert/v3"
+
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/core/messagetest"
+	"github.com/caixw/apidoc/v7/internal/ast"
+)
+
+func TestWatcher(t *testing.T) {
+	a := assert.New(t, false)
+	dir := t.TempDir()
+	wd := core.FileURI(dir)
+
+	modTime := time.Now()
+	write := func(path, data string) {
+		path = filepath.Join(dir, path)
+		a.NotError(os.WriteFile(path, []byte(data), os.ModePerm))
+		modTime = modTime.Add(time.Second) // 保证每次修改之后的时间都不相同
+		a.NotError(os.Chtimes(path, modTime, modTime))
+	}
+
+	cfg := &Config{
+		Version: ast.Version,
+		Inputs:  []*Input{{Lang: "go", Dir: wd}},
+		Output:  &Output{Path: wd.Append("apidoc.xml")},
+	}
+	a.NotError(cfg.Save(wd))
+	write("doc.go", `package doc
+
+// <apidoc version="1.0.0"><title>doc</title><mimetype>json</mimetype></apidoc>
+`)
+	write("api.go", `package doc
+
+// <api method="GET" summary="list"><path path="/users" /><response status="200" /></api>
+`)
+
+	rslt := messagetest.NewMessageHandler()
+	w, err := NewWatcher(rslt.Handler, wd, true)
+	a.NotError(err).NotNil(w)
+	a.Length(w.docs.Docs, 1).Length(w.apis, 1)
+	data, err := os.ReadFile(filepath.Join(dir, "apidoc.xml"))
+	a.NotError(err).Contains(string(data), "/users")
+
+	// 没有变化
+	a.Nil(w.check())
+
+	// 仅修改 api.go，文档对象保持不变。
+	doc := w.docs.Docs[0]
+	write("api.go", `package doc
+
+// <api method="GET" summary="users"><path path="/users" /><response status="200" /></api>
+
+// <api method="POST" summary="create"><path path="/users" /><response status="201" /></api>
+`)
+	diff := w.check()
+	a.NotNil(diff).
+		Equal(diff.Added, []string{"POST /users"}).
+		Equal(diff.Changed, []string{"GET /users"}).
+		Empty(diff.Removed)
+	a.True(w.docs.Docs[0] == doc).Length(doc.APIs, 2)
+	data, err = os.ReadFile(filepath.Join(dir, "apidoc.xml"))
+	a.NotError(err).Contains(string(data), "create")
+
+	// 输出的是副本，原文档不受影响。
+	a.Nil(doc.Created)
+
+	// 删除文件
+	a.NotError(os.Remove(filepath.Join(dir, "api.go")))
+	diff = w.check()
+	a.NotNil(diff).
+		Empty(diff.Added).
+		Empty(diff.Changed).
+		Equal(diff.Removed, []string{"GET /users", "POST /users"})
+	a.True(w.docs.Docs[0] != doc)
+
+	// 配置文件出错，依然采用原来的配置。
+	write(".apidoc.yaml", "version: 1.0.0\n")
+	a.Nil(w.check())
+	a.Nil(w.check())
+
+	rslt.Handler.Stop()
+	a.Length(rslt.Errors, 1)
+
+	// 配置文件不存在
+	rslt = messagetest.NewMessageHandler()
+	w, err = NewWatcher(rslt.Handler, core.FileURI(t.TempDir()), false)
+	a.Error(err).Nil(w)
+	rslt.Handler.Stop()
+}
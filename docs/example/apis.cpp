@@ -38,7 +38,7 @@
 //         ]]></example>
 //     </response>
 //
-//     <callback schema="https" summary="回调函数" method="POST">
+//     <callback name="notify" expression="{$request.body#/notify}" summary="回调函数" method="POST">
 //     <description type="html">
 // <![CDATA[
 //         <p style="color:red">这是一个回调函数的详细说明</p>
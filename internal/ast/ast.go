@@ -43,6 +43,38 @@ const (
 	TypeDate     = "string.date"      // RFC3339 full-date
 	TypeTime     = "string.time"      // RFC3339 full-time
 	TypeDateTime = "string.date-time" // RFC3339 full-date + full-time
+	TypeUUID     = "string.uuid"      // RFC4122 UUID
+	TypeIPv4     = "string.ipv4"
+	TypeIPv6     = "string.ipv6"
+	TypeHostname = "string.hostname" // RFC1123 主机名
+	TypeByte     = "string.byte"     // base64 编码的内容
+	TypeBinary   = "string.binary"   // 二进制内容，比如上传的文件
+	TypePassword = "string.password"
+	TypeInt32    = "number.int32"
+	TypeInt64    = "number.int64"
+	TypeDouble   = "number.double"
+)
+
+// 身份验证方案的类型
+const (
+	SecurityTypeHTTP   = "http"
+	SecurityTypeAPIKey = "apikey"
+	SecurityTypeOAuth2 = "oauth2"
+)
+
+// 身份验证方案为 apikey 时，令牌可能的位置
+const (
+	SecurityInHeader = "header"
+	SecurityInQuery  = "query"
+	SecurityInCookie = "cookie"
+)
+
+// OAuth2 的授权流程
+const (
+	OAuthFlowImplicit          = "implicit"
+	OAuthFlowPassword          = "password"
+	OAuthFlowClientCredentials = "client-credentials"
+	OAuthFlowAuthorizationCode = "authorization-code"
 )
 
 // 富文本可用的类型
@@ -51,6 +83,17 @@ const (
 	RichtextTypeMarkdown = "markdown"
 )
 
+// StatusDefault 表示未明确定义的其它状态码
+//
+// 除此之外，状态码还可以是 1XX 至 5XX 表示某一范围内的状态码。
+const StatusDefault = "default"
+
+// ExtensionPrefix 扩展内容的名称前缀
+//
+// 以此开头的属性以及 extension 元素会作为扩展内容原样输出，
+// 需要与各元素中 attrs 类型的结构体标签保持一致。
+const ExtensionPrefix = "x-"
+
 // 几种与时间类型相关的格式
 const (
 	DateFormat     = "2006-01-02"     // 对应 TypeDate
@@ -6,6 +6,7 @@ package asttest
 import (
 	"net/http"
 	"path/filepath"
+	"strconv"
 
 	"github.com/issue9/assert/v3"
 	"github.com/issue9/source"
@@ -96,7 +97,7 @@ func Get() *ast.APIDoc {
 							Text: &ast.CData{Value: xmlenc.String{Value: "<p>desc</p>"}},
 						},
 						Type:   &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeObject}},
-						Status: &ast.StatusAttribute{Value: ast.Number{Int: http.StatusOK}},
+						Status: &ast.StatusAttribute{Value: xmlenc.String{Value: strconv.Itoa(http.StatusOK)}},
 						Headers: []*ast.Param{
 							{
 								Type:    &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeString}},
@@ -172,7 +173,7 @@ func Get() *ast.APIDoc {
 				},
 				Responses: []*ast.Request{
 					{
-						Status: &ast.StatusAttribute{Value: ast.Number{Int: http.StatusCreated}},
+						Status: &ast.StatusAttribute{Value: xmlenc.String{Value: strconv.Itoa(http.StatusCreated)}},
 						Description: &ast.Richtext{
 							Type: &ast.Attribute{Value: xmlenc.String{Value: "html"}},
 							Text: &ast.CData{Value: xmlenc.String{Value: "<p>desc</p>"}},
@@ -56,7 +56,14 @@ type (
 	MethodAttribute Attribute
 
 	// StatusAttribute 状态码的 XML 属性
-	StatusAttribute NumberAttribute
+	//
+	// 除了具体的状态码之外，还可以是 1XX 至 5XX 表示某一范围内的状态码，
+	// 或是 default 表示未明确定义的其它状态码。
+	StatusAttribute struct {
+		xmlenc.BaseAttribute
+		Value    xmlenc.String `apidoc:"-"`
+		RootName struct{}      `apidoc:"status,meta,usage-status"`
+	}
 
 	// TypeAttribute 表示方法类型属性
 	TypeAttribute struct {
@@ -67,6 +74,40 @@ type (
 
 	// APIDocVersionAttribute 版本号属性，同时对版本号进行比较
 	APIDocVersionAttribute Attribute
+
+	// RefAttribute 引用 APIDoc.Types 中类型定义的属性
+	RefAttribute struct {
+		xmlenc.BaseAttribute
+		Value    xmlenc.String `apidoc:"-"`
+		RootName struct{}      `apidoc:"string,meta,usage-string"`
+
+		definition *Definition
+		inherited  Inheritance // 从类型定义中继承的内容
+	}
+
+	// Inheritance 表示引用了类型定义的元素中，哪些内容是从类型定义中继承而来的
+	//
+	// 用户自己指定的内容不会被标记为继承。
+	Inheritance struct {
+		Type         bool
+		Deprecated   bool
+		Items        bool
+		Enums        bool
+		Summary      bool
+		Description  bool
+		Translations bool
+	}
+
+	// SrcAttribute 引用外部文件内容的属性
+	//
+	// 路径相对于当前文件所在的目录，找不到时再相对于 Input.Dir 查找。
+	SrcAttribute struct {
+		xmlenc.BaseAttribute
+		Value    xmlenc.String `apidoc:"-"`
+		RootName struct{}      `apidoc:"string,meta,usage-string"`
+
+		definition *Definition
+	}
 )
 
 // DecodeXMLAttr AttrDecoder.DecodeXMLAttr
@@ -139,6 +180,17 @@ func (num *NumberAttribute) IsFloat() bool {
 	return num.Value.IsFloat
 }
 
+// Float64 以 float64 的形式返回当前属性的值，不区分整数与浮点数。
+func (num *NumberAttribute) Float64() float64 {
+	if num == nil {
+		return 0
+	}
+	if num.Value.IsFloat {
+		return num.Value.Float
+	}
+	return float64(num.Value.Int)
+}
+
 // DecodeXMLAttr AttrDecoder.DecodeXMLAttr
 func (b *BoolAttribute) DecodeXMLAttr(p *xmlenc.Parser, attr *xmlenc.Attribute) error {
 	v, err := strconv.ParseBool(attr.Value.Value)
@@ -188,27 +240,64 @@ func (a *MethodAttribute) V() string {
 
 // DecodeXMLAttr AttrDecoder.DecodeXMLAttr
 func (a *StatusAttribute) DecodeXMLAttr(p *xmlenc.Parser, attr *xmlenc.Attribute) error {
-	v := NumberAttribute{}
-	if err := v.DecodeXMLAttr(p, attr); err != nil {
-		return err
-	}
-
-	if !isValidStatus(v.Value.Int) {
+	a.Value = attr.Value
+	a.Value.Value = normalizeStatus(a.V())
+	if !isValidStatus(a.V()) {
 		return attr.Value.NewError(locale.ErrInvalidValue).WithField(attr.Name.String())
 	}
-
-	*a = StatusAttribute(v)
 	return nil
 }
 
 // EncodeXMLAttr AttrEncoder.EncodeXMLAttr
 func (a *StatusAttribute) EncodeXMLAttr() (string, error) {
-	return strconv.Itoa(a.V()), nil
+	return a.V(), nil
 }
 
 // V 返回当前属性实际表示的值
-func (a *StatusAttribute) V() int {
-	return (*NumberAttribute)(a).IntValue()
+//
+// 可能是具体的状态码，也可能是 1XX 至 5XX 或是 default。
+func (a *StatusAttribute) V() string {
+	if a == nil {
+		return ""
+	}
+	return a.Value.Value
+}
+
+// Code 返回具体的状态码
+//
+// 范围值返回该范围内的第一个状态码，比如 5XX 返回 500；
+// default 一般在没有其它返回内容时表示正常的返回，所以返回 200。
+// 无效的值返回 0。
+func (a *StatusAttribute) Code() int {
+	switch v := a.V(); {
+	case v == StatusDefault:
+		return http.StatusOK
+	case isStatusRange(v):
+		return int(v[0]-'0') * 100
+	default:
+		code, _ := strconv.Atoi(v)
+		return code
+	}
+}
+
+// IsRange 是否为 1XX 至 5XX 或是 default 这类表示多个状态码的值
+func (a *StatusAttribute) IsRange() bool {
+	v := a.V()
+	return v == StatusDefault || isStatusRange(v)
+}
+
+// Match 状态码 code 是否在当前值所表示的范围之内
+//
+// default 包含所有的状态码。
+func (a *StatusAttribute) Match(code int) bool {
+	switch v := a.V(); {
+	case v == StatusDefault:
+		return true
+	case isStatusRange(v):
+		return code/100 == int(v[0]-'0')
+	default:
+		return a.Code() == code
+	}
 }
 
 // DecodeXMLAttr AttrDecoder.DecodeXMLAttr
@@ -249,6 +338,9 @@ func (a *VersionAttribute) EncodeXMLAttr() (string, error) {
 
 // V 返回当前属性实际表示的值
 func (a *VersionAttribute) V() string {
+	if a == nil {
+		return ""
+	}
 	return a.Value.Value
 }
 
@@ -302,6 +394,64 @@ func (a *APIDocVersionAttribute) V() string {
 	return (*Attribute)(a).V()
 }
 
+// DecodeXMLAttr AttrDecoder.DecodeXMLAttr
+func (a *RefAttribute) DecodeXMLAttr(p *xmlenc.Parser, attr *xmlenc.Attribute) error {
+	a.Value = attr.Value
+	return nil
+}
+
+// EncodeXMLAttr AttrEncoder.EncodeXMLAttr
+func (a *RefAttribute) EncodeXMLAttr() (string, error) {
+	return a.V(), nil
+}
+
+// V 返回当前属性实际表示的值
+func (a *RefAttribute) V() string {
+	if a == nil {
+		return ""
+	}
+	return a.Value.Value
+}
+
+// Definition Definitioner.Definition
+func (a *RefAttribute) Definition() *Definition {
+	return a.definition
+}
+
+// Inherited 返回从 ref 指向的类型定义中继承的内容
+func (a *RefAttribute) Inherited() Inheritance {
+	if a == nil {
+		return Inheritance{}
+	}
+	return a.inherited
+}
+
+// DecodeXMLAttr AttrDecoder.DecodeXMLAttr
+func (a *SrcAttribute) DecodeXMLAttr(p *xmlenc.Parser, attr *xmlenc.Attribute) error {
+	a.Value = attr.Value
+	return nil
+}
+
+// EncodeXMLAttr AttrEncoder.EncodeXMLAttr
+func (a *SrcAttribute) EncodeXMLAttr() (string, error) {
+	return a.V(), nil
+}
+
+// V 返回当前属性实际表示的值
+func (a *SrcAttribute) V() string {
+	if a == nil {
+		return ""
+	}
+	return a.Value.Value
+}
+
+// Definition Definitioner.Definition
+//
+// 指向被引用的外部文件内容，未加载时返回 nil。
+func (a *SrcAttribute) Definition() *Definition {
+	return a.definition
+}
+
 var validMethods = []string{
 	http.MethodGet,
 	http.MethodPost,
@@ -322,9 +472,34 @@ func isValidMethod(method string) bool {
 	return false
 }
 
-func isValidStatus(status int) bool {
-	return (status >= http.StatusContinue) &&
-		(status <= http.StatusNetworkAuthenticationRequired)
+func isValidStatus(status string) bool {
+	if status == StatusDefault || isStatusRange(status) {
+		return true
+	}
+
+	code, err := strconv.Atoi(status)
+	return err == nil &&
+		(code >= http.StatusContinue) &&
+		(code <= http.StatusNetworkAuthenticationRequired)
+}
+
+// 是否为 1XX 至 5XX 的值，需要已经经过 normalizeStatus 处理。
+func isStatusRange(status string) bool {
+	return len(status) == 3 && status[0] >= '1' && status[0] <= '5' && status[1:] == "XX"
+}
+
+// 将状态码的范围值统一为大写，default 统一为小写，数值则去掉前导的 0 等内容。
+func normalizeStatus(status string) string {
+	if strings.EqualFold(status, StatusDefault) {
+		return StatusDefault
+	}
+	if len(status) == 3 && strings.EqualFold(status[1:], "XX") {
+		return strings.ToUpper(status)
+	}
+	if code, err := strconv.Atoi(status); err == nil {
+		return strconv.Itoa(code)
+	}
+	return status
 }
 
 func isValidType(t string) bool {
@@ -340,6 +515,16 @@ func isValidType(t string) bool {
 		t == TypeDate ||
 		t == TypeTime ||
 		t == TypeDateTime ||
+		t == TypeUUID ||
+		t == TypeIPv4 ||
+		t == TypeIPv6 ||
+		t == TypeHostname ||
+		t == TypeByte ||
+		t == TypeBinary ||
+		t == TypePassword ||
+		t == TypeInt32 ||
+		t == TypeInt64 ||
+		t == TypeDouble ||
 		t == TypeNone
 }
 
@@ -111,16 +111,51 @@ func TestStatusAttribute(t *testing.T) {
 	status := &StatusAttribute{}
 	attr := &xmlenc.Attribute{Value: xmlenc.String{Value: "201"}}
 	a.NotError(status.DecodeXMLAttr(p, attr))
-	a.Equal(status.V(), 201)
+	a.Equal(status.V(), "201").Equal(status.Code(), 201).False(status.IsRange())
 	v, err := status.EncodeXMLAttr()
 	a.NotError(err).Equal(v, "201")
 	rslt.Handler.Stop()
 
+	p, rslt = newParser(a, "", "uri1")
+	status = &StatusAttribute{}
+	attr = &xmlenc.Attribute{Value: xmlenc.String{Value: "0200"}}
+	a.NotError(status.DecodeXMLAttr(p, attr))
+	a.Equal(status.V(), "200").Equal(status.Code(), 200)
+	rslt.Handler.Stop()
+
 	p, rslt = newParser(a, "", "uri1")
 	status = &StatusAttribute{}
 	attr = &xmlenc.Attribute{Value: xmlenc.String{Value: "10000"}}
 	a.Error(status.DecodeXMLAttr(p, attr))
 	rslt.Handler.Stop()
+
+	p, rslt = newParser(a, "", "uri1")
+	status = &StatusAttribute{}
+	attr = &xmlenc.Attribute{Value: xmlenc.String{Value: "5xx"}}
+	a.NotError(status.DecodeXMLAttr(p, attr))
+	a.Equal(status.V(), "5XX").Equal(status.Code(), 500).True(status.IsRange()).
+		True(status.Match(503)).
+		False(status.Match(404))
+	v, err = status.EncodeXMLAttr()
+	a.NotError(err).Equal(v, "5XX")
+	rslt.Handler.Stop()
+
+	p, rslt = newParser(a, "", "uri1")
+	status = &StatusAttribute{}
+	attr = &xmlenc.Attribute{Value: xmlenc.String{Value: "Default"}}
+	a.NotError(status.DecodeXMLAttr(p, attr))
+	a.Equal(status.V(), StatusDefault).Equal(status.Code(), 200).True(status.IsRange()).
+		True(status.Match(404))
+	rslt.Handler.Stop()
+
+	p, rslt = newParser(a, "", "uri1")
+	status = &StatusAttribute{}
+	attr = &xmlenc.Attribute{Value: xmlenc.String{Value: "6XX"}}
+	a.Error(status.DecodeXMLAttr(p, attr))
+	rslt.Handler.Stop()
+
+	status = nil
+	a.Empty(status.V()).Equal(status.Code(), 0)
 }
 
 func TestTypeAttribute(t *testing.T) {
@@ -171,9 +206,16 @@ func TestIsValidMethod(t *testing.T) {
 func TestIsValidStatus(t *testing.T) {
 	a := assert.New(t, false)
 
-	a.True(isValidStatus(100))
-	a.True(isValidStatus(500))
-	a.False(isValidStatus(1000))
+	a.True(isValidStatus("100"))
+	a.True(isValidStatus("500"))
+	a.False(isValidStatus("1000"))
+	a.False(isValidStatus("abc"))
+
+	a.True(isValidStatus("1XX"))
+	a.True(isValidStatus("5XX"))
+	a.True(isValidStatus(StatusDefault))
+	a.False(isValidStatus("6XX"))
+	a.False(isValidStatus("5xx")) // 需要经过 normalizeStatus 处理
 }
 
 func TestDateAttribute(t *testing.T) {
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: MIT
+
+package ast
+
+import "reflect"
+
+// Clone 返回文档的深层复制
+//
+// FilterVersion、Translate 等操作会直接修改文档内容，
+// 在需要保留原始文档的情况下，可以对复制后的对象进行操作。
+// 原文档中共享的对象，在复制后的文档中依然是共享的。
+func (doc *APIDoc) Clone() *APIDoc {
+	d := deepCopy(doc)
+	for _, api := range d.APIs {
+		api.doc = d
+	}
+	return d
+}
+
+// 返回 v 的深层复制，v 中共享的对象在复制后依然是共享的。
+func deepCopy[T any](v T) T {
+	cloned := make(map[uintptr]reflect.Value, 100)
+
+	var clone func(reflect.Value) reflect.Value
+	clone = func(v reflect.Value) reflect.Value {
+		switch v.Kind() {
+		case reflect.Pointer:
+			if v.IsNil() {
+				return v
+			}
+			if c, found := cloned[v.Pointer()]; found {
+				return c
+			}
+			c := reflect.New(v.Type().Elem())
+			cloned[v.Pointer()] = c
+			c.Elem().Set(clone(v.Elem()))
+			return c
+		case reflect.Slice:
+			if v.IsNil() {
+				return v
+			}
+			c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+			for i := 0; i < v.Len(); i++ {
+				c.Index(i).Set(clone(v.Index(i)))
+			}
+			return c
+		case reflect.Struct:
+			c := reflect.New(v.Type()).Elem()
+			c.Set(v) // 未导出的字段保持原值
+
+			t := v.Type()
+			for i := 0; i < t.NumField(); i++ {
+				if t.Field(i).IsExported() {
+					c.Field(i).Set(clone(v.Field(i)))
+				}
+			}
+			return c
+		default:
+			return v
+		}
+	}
+
+	return clone(reflect.ValueOf(&v)).Elem().Interface().(T)
+}
@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: MIT
+
+package ast
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v3"
+	"golang.org/x/text/language"
+)
+
+func TestAPIDoc_Clone(t *testing.T) {
+	a := assert.New(t, false)
+
+	docs := &Documents{}
+	rslt := parseDocuments(docs,
+		newDocumentsBlock("doc.go", `<apidoc version="1.0.0">
+	<title>t</title>
+	<translation lang="en" title="title" />
+	<mimetype>json</mimetype>
+	<type name="user" type="object"><param name="id" type="number" summary="id" /></type>
+</apidoc>`),
+		newDocumentsBlock("api.go", `<api method="GET" since="1.1.0">
+	<path path="/users" />
+	<response status="200" ref="user" />
+</api>`),
+	)
+	a.Empty(rslt.Errors).Length(docs.Docs, 1)
+	doc := docs.Docs[0]
+
+	c := doc.Clone()
+	a.NotNil(c).True(c != doc)
+	a.Equal(c.Title.V(), doc.Title.V()).
+		Length(c.APIs, 1).
+		Equal(c.APIs[0].Path.Path.V(), "/users").
+		True(c.APIs[0].doc == c).
+		Equal(c.Location, doc.Location)
+
+	c.FilterVersion("1.0.0", false)
+	c.Translate(language.English)
+	a.Empty(c.APIs).Equal(c.Title.V(), "title")
+	a.Length(doc.APIs, 1).True(doc.APIs[0].doc == doc).Equal(doc.Title.V(), "t")
+}
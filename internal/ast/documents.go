@@ -0,0 +1,313 @@
+// SPDX-License-Identifier: MIT
+
+package ast
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/issue9/sliceutil"
+
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/internal/locale"
+	"github.com/caixw/apidoc/v7/internal/xmlenc"
+)
+
+// Documents 由多个 apidoc 元素组成的文档集合
+//
+// 每一个 apidoc 元素都是一份独立的文档，通过 id 属性进行区分。
+// api 通过 doc 属性指定其所属的文档，未指定时由 DocID 决定，
+// 如果依然无法确定，且仅有一份文档，则归属于该文档。
+type Documents struct {
+	Docs []*APIDoc
+
+	// 返回 uri 中未指定 doc 属性的 api 默认所属的文档 ID
+	//
+	// 可以为空，表示未指定。
+	DocID func(core.URI) string
+
+	// 返回 uri 中 src 属性和 include 元素在找不到文件时的备用查找目录
+	//
+	// 可以为空，表示仅相对于当前文件查找。
+	Dir func(core.URI) core.URI
+
+	apis     []*API                  // 尚未找到所属文档的 api
+	includes map[core.URI][]core.URI // 各个文件通过 src 属性和 include 元素引用的文件
+}
+
+// 通过 RegisterCheck 注册的检测函数
+var checks []func(*core.MessageHandler, *APIDoc)
+
+// RegisterCheck 注册在 Documents.ParseBlocks 完成之后对文档进行的额外检测
+//
+// 仅会对解析过程中没有产生错误的文档调用 f。
+// 一般在 init 函数中调用，非并发安全。
+func RegisterCheck(f ...func(*core.MessageHandler, *APIDoc)) {
+	checks = append(checks, f...)
+}
+
+// ParseBlocks 从多个 core.Block 实例中解析文档内容
+//
+// g 必须是一个阻塞函数，直到所有代码块都写入参数之后，才能返回。
+// 所有代码块解析完成之后，会为 api 分配所属的文档，关联各个文档中 ref 属性引用的类型定义，
+// 并检测 link 指向的 API，之后对没有错误的文档执行由 RegisterCheck 注册的检测。
+func (docs *Documents) ParseBlocks(h *core.MessageHandler, g func(chan core.Block)) {
+	if len(checks) == 0 {
+		docs.parseBlocks(h, g)
+		return
+	}
+
+	var errs []core.Location // 所有错误的定位信息，无法定位的错误以空值表示。
+	proxy := core.NewMessageHandler(func(msg *core.Message) {
+		if msg.Type == core.Erro {
+			var loc core.Location
+			if err, ok := msg.Message.(*core.Error); ok {
+				loc = err.Location
+			}
+			errs = append(errs, loc)
+		}
+		h.Message(msg.Type, msg.Message)
+	})
+	docs.parseBlocks(proxy, g)
+	proxy.Stop()
+
+	for _, doc := range docs.Docs {
+		if sliceutil.Count(errs, func(loc core.Location) bool { return loc.IsEmpty() || doc.owns(loc) }) > 0 {
+			continue
+		}
+		for _, check := range checks {
+			check(h, doc)
+		}
+	}
+}
+
+func (docs *Documents) parseBlocks(h *core.MessageHandler, g func(chan core.Block)) {
+	done := make(chan struct{})
+	blocks := make(chan core.Block, 50)
+
+	go func() {
+		for block := range blocks {
+			docs.Parse(h, block)
+		}
+		done <- struct{}{}
+	}()
+
+	g(blocks)
+	close(blocks)
+	<-done
+
+	docs.resolveAPIs(h)
+	for _, doc := range docs.Docs {
+		doc.resolveTypes(h)
+		doc.resolveLinks(h)
+	}
+}
+
+// Parse 将注释块的内容添加到文档集合中
+func (docs *Documents) Parse(h *core.MessageHandler, b core.Block) {
+	if !isValid(b) {
+		return
+	}
+
+	p, err := xmlenc.NewParser(h, b)
+	if err != nil {
+		h.Error(err)
+		return
+	}
+	if docs.Dir != nil {
+		p.Dir = docs.Dir(b.Location.URI)
+	}
+	defer func() { docs.addIncludes(b.Location.URI, p.Included()) }()
+
+	switch getTagName(p) {
+	case "api":
+		api := &API{}
+		xmlenc.Decode(p, api, core.XMLNamespace)
+
+		if doc := docs.Doc(docs.apiDocID(api)); doc != nil {
+			doc.appendAPI(p, api)
+		} else {
+			docs.apis = append(docs.apis, api)
+		}
+	case "apidoc":
+		doc := &APIDoc{}
+		xmlenc.Decode(p, doc, core.XMLNamespace)
+
+		if exists := docs.Doc(doc.ID.V()); exists != nil {
+			field := "apidoc"
+			if doc.ID != nil {
+				field = "@id"
+			}
+			err := b.Location.NewError(locale.ErrDuplicateValue).WithField(field).
+				Relate(exists.Location, locale.Sprintf(locale.ErrDuplicateValue))
+			h.Error(err)
+			return
+		}
+		docs.Docs = append(docs.Docs, doc)
+
+		apis := make([]*API, 0, len(docs.apis))
+		for _, api := range docs.apis {
+			if docs.apiDocID(api) == doc.ID.V() {
+				doc.appendAPI(p, api)
+			} else {
+				apis = append(apis, api)
+			}
+		}
+		docs.apis = apis
+	}
+}
+
+// Doc 返回 ID 为 id 的文档
+func (docs *Documents) Doc(id string) *APIDoc {
+	for _, doc := range docs.Docs {
+		if doc.ID.V() == id {
+			return doc
+		}
+	}
+	return nil
+}
+
+// loc 是否位于文档或是其中的 api 之内
+func (doc *APIDoc) owns(loc core.Location) bool {
+	if doc.Location.Contains(loc.URI, loc.Range.Start) {
+		return true
+	}
+	return sliceutil.Count(doc.APIs, func(api *API) bool { return api.Location.Contains(loc.URI, loc.Range.Start) }) > 0
+}
+
+func (docs *Documents) addIncludes(uri core.URI, included []core.URI) {
+	if len(included) == 0 {
+		return
+	}
+
+	if docs.includes == nil {
+		docs.includes = make(map[core.URI][]core.URI, 10)
+	}
+	for _, i := range included {
+		if !containsURI(docs.includes[uri], i) {
+			docs.includes[uri] = append(docs.includes[uri], i)
+		}
+	}
+}
+
+// Includers 返回通过 src 属性或是 include 元素引用了 uri 的文件
+//
+// 包括间接引用了 uri 的文件，uri 的内容发生变化时，这些文件也需要重新解析。
+func (docs *Documents) Includers(uri core.URI) []core.URI {
+	includers := make([]core.URI, 0, 5)
+	for includer, included := range docs.includes {
+		if containsURI(included, uri) {
+			includers = append(includers, includer)
+		}
+	}
+	sort.Slice(includers, func(i, j int) bool { return includers[i] < includers[j] })
+	return includers
+}
+
+func containsURI(uris []core.URI, uri core.URI) bool {
+	return sliceutil.Count(uris, func(u core.URI) bool { return u == uri }) > 0
+}
+
+// DeleteURI 删除所有来自 uri 的内容
+//
+// 如果被删除的是 apidoc 元素，那么原本属于该文档且来自其它文件的 api，
+// 会重新处于等待分配的状态。uri 引用其它文件的记录也会被删除。
+//
+// 仅删除 uri 所引用的文件并不会删除任何内容，
+// 被引用文件的内容发生变化时，应该通过 Includers 找到引用者并重新解析。
+func (docs *Documents) DeleteURI(uri core.URI) (deleted bool) {
+	delete(docs.includes, uri)
+	owned := func(api *API) bool { return api.URI == uri }
+
+	l := len(docs.apis)
+	docs.apis = sliceutil.Delete(docs.apis, owned)
+	deleted = l > len(docs.apis)
+
+	list := make([]*APIDoc, 0, len(docs.Docs))
+	for _, doc := range docs.Docs {
+		l := len(doc.APIs)
+		doc.APIs = sliceutil.Delete(doc.APIs, owned)
+		deleted = deleted || l > len(doc.APIs)
+
+		if doc.URI != uri {
+			list = append(list, doc)
+			continue
+		}
+
+		deleted = true
+		for _, api := range doc.APIs {
+			api.doc = nil
+			docs.apis = append(docs.apis, api)
+		}
+	}
+	docs.Docs = list
+
+	return deleted
+}
+
+// Search 搜索符合条件的对象并返回
+//
+// 依次在各个文档以及尚未分配文档的 api 中查找，具体规则可参考 APIDoc.Search。
+func (docs *Documents) Search(uri core.URI, pos core.Position, t reflect.Type) core.Searcher {
+	for _, doc := range docs.Docs {
+		if r := doc.Search(uri, pos, t); r != nil {
+			return r
+		}
+	}
+
+	for _, api := range docs.apis {
+		if r := search(reflect.ValueOf(api), uri, pos, t); r != nil {
+			return r
+		}
+	}
+	return nil
+}
+
+// 返回 api 所属文档的 ID
+func (docs *Documents) apiDocID(api *API) string {
+	if api.Document != nil {
+		return api.Document.V()
+	}
+	if docs.DocID != nil {
+		return docs.DocID(api.URI)
+	}
+	return ""
+}
+
+// 为所有未能在解析时找到所属文档的 api 分配文档
+//
+// 仅在只有一份文档时，未指定 doc 属性的 api 才会被归入该文档，其它情况都会报错。
+func (docs *Documents) resolveAPIs(h *core.MessageHandler) {
+	if len(docs.Docs) == 0 {
+		return
+	}
+
+	p := &xmlenc.Parser{MessageHandler: h}
+	apis := make([]*API, 0, len(docs.apis))
+	for _, api := range docs.apis {
+		if api.Document == nil && len(docs.Docs) == 1 {
+			docs.Docs[0].appendAPI(p, api)
+			continue
+		}
+
+		if api.Document != nil {
+			h.Error(api.Document.Location.NewError(locale.ErrNotFound).WithField("@doc"))
+		} else {
+			h.Error(api.Location.NewError(locale.ErrIsEmpty, "@doc").WithField("@doc"))
+		}
+		apis = append(apis, api)
+	}
+	docs.apis = apis
+}
+
+// 将 api 添加到 doc 中并检测与文档相关的字段
+func (doc *APIDoc) appendAPI(p *xmlenc.Parser, api *API) {
+	api.doc = doc
+	doc.APIs = append(doc.APIs, api)
+
+	if doc.Title.V() != "" {
+		api.sanitizeTags(p)
+	}
+
+	doc.sortAPIs()
+}
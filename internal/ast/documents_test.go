@@ -0,0 +1,251 @@
+// SPDX-License-Identifier: MIT
+
+package ast
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/issue9/assert/v3"
+
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/core/messagetest"
+	"github.com/caixw/apidoc/v7/internal/locale"
+	"github.com/caixw/apidoc/v7/internal/xmlenc"
+)
+
+func parseDocuments(docs *Documents, blocks ...core.Block) *messagetest.Result {
+	rslt := messagetest.NewMessageHandler()
+	docs.ParseBlocks(rslt.Handler, func(ch chan core.Block) {
+		for _, b := range blocks {
+			ch <- b
+		}
+	})
+	rslt.Handler.Stop()
+	return rslt
+}
+
+func newDocumentsBlock(uri core.URI, data string) core.Block {
+	return core.Block{Data: []byte(data), Location: core.Location{URI: uri}}
+}
+
+func TestDocuments_ParseBlocks(t *testing.T) {
+	a := assert.New(t, false)
+
+	docs := &Documents{}
+	rslt := parseDocuments(docs,
+		newDocumentsBlock("api1.go", `<api method="GET" doc="admin"><path path="/admin" /><response status="200" /></api>`),
+		newDocumentsBlock("api2.go", `<api method="GET"><path path="/users" /><response status="200" /></api>`),
+		newDocumentsBlock("doc1.go", `<apidoc version="1.0.0"><title>public</title><mimetype>json</mimetype></apidoc>`),
+		newDocumentsBlock("doc2.go", `<apidoc version="1.0.0" id="admin"><title>admin</title><mimetype>json</mimetype></apidoc>`),
+		newDocumentsBlock("api3.go", `<api method="POST" doc="admin"><path path="/admin" /><response status="200" /></api>`),
+	)
+	a.Empty(rslt.Errors).Length(docs.Docs, 2).Empty(docs.apis)
+
+	public := docs.Doc("")
+	a.NotNil(public).Equal(public.Title.V(), "public").Length(public.APIs, 1)
+	a.Equal(public.APIs[0].Path.Path.V(), "/users").Equal(public.APIs[0].doc, public)
+
+	admin := docs.Doc("admin")
+	a.NotNil(admin).Equal(admin.Title.V(), "admin").Length(admin.APIs, 2)
+	a.Equal(admin.APIs[0].Method.V(), "GET").Equal(admin.APIs[0].doc, admin)
+	a.Equal(admin.APIs[1].Method.V(), "POST")
+
+	a.Nil(docs.Doc("not-exists"))
+
+	// 仅有一份文档，未指定 doc 的 api 归属于该文档
+	docs = &Documents{}
+	rslt = parseDocuments(docs,
+		newDocumentsBlock("api.go", `<api method="GET"><path path="/users" /><response status="200" /></api>`),
+		newDocumentsBlock("doc.go", `<apidoc version="1.0.0" id="admin"><title>admin</title><mimetype>json</mimetype></apidoc>`),
+	)
+	a.Empty(rslt.Errors).Length(docs.Docs, 1).Length(docs.Docs[0].APIs, 1).Empty(docs.apis)
+
+	// 由 DocID 决定
+	docs = &Documents{DocID: func(uri core.URI) string {
+		if uri == "admin.go" {
+			return "admin"
+		}
+		return ""
+	}}
+	rslt = parseDocuments(docs,
+		newDocumentsBlock("admin.go", `<api method="GET"><path path="/admin" /><response status="200" /></api>`),
+		newDocumentsBlock("public.go", `<api method="GET"><path path="/users" /><response status="200" /></api>`),
+		newDocumentsBlock("doc1.go", `<apidoc version="1.0.0"><title>public</title><mimetype>json</mimetype></apidoc>`),
+		newDocumentsBlock("doc2.go", `<apidoc version="1.0.0" id="admin"><title>admin</title><mimetype>json</mimetype></apidoc>`),
+	)
+	a.Empty(rslt.Errors).Length(docs.Docs, 2)
+	a.Length(docs.Doc("").APIs, 1).Equal(docs.Doc("").APIs[0].Path.Path.V(), "/users")
+	a.Length(docs.Doc("admin").APIs, 1).Equal(docs.Doc("admin").APIs[0].Path.Path.V(), "/admin")
+
+	// 多份文档且无法确定所属的文档
+	docs = &Documents{}
+	rslt = parseDocuments(docs,
+		newDocumentsBlock("api1.go", `<api method="GET" doc="not-exists"><path path="/admin" /><response status="200" /></api>`),
+		newDocumentsBlock("api2.go", `<api method="GET"><path path="/users" /><response status="200" /></api>`),
+		newDocumentsBlock("doc1.go", `<apidoc version="1.0.0" id="public"><title>public</title><mimetype>json</mimetype></apidoc>`),
+		newDocumentsBlock("doc2.go", `<apidoc version="1.0.0" id="admin"><title>admin</title><mimetype>json</mimetype></apidoc>`),
+	)
+	a.Length(rslt.Errors, 2).Length(docs.apis, 2)
+
+	// 重复的 ID
+	docs = &Documents{}
+	rslt = parseDocuments(docs,
+		newDocumentsBlock("doc1.go", `<apidoc version="1.0.0" id="admin"><title>t1</title><mimetype>json</mimetype></apidoc>`),
+		newDocumentsBlock("doc2.go", `<apidoc version="1.0.0" id="admin"><title>t2</title><mimetype>json</mimetype></apidoc>`),
+	)
+	a.Length(rslt.Errors, 1).Length(docs.Docs, 1)
+
+	docs = &Documents{}
+	rslt = parseDocuments(docs,
+		newDocumentsBlock("doc1.go", `<apidoc version="1.0.0"><title>t1</title><mimetype>json</mimetype></apidoc>`),
+		newDocumentsBlock("doc2.go", `<apidoc version="1.0.0"><title>t2</title><mimetype>json</mimetype></apidoc>`),
+	)
+	a.Length(rslt.Errors, 1).Length(docs.Docs, 1)
+}
+
+func TestRegisterCheck(t *testing.T) {
+	a := assert.New(t, false)
+
+	old := checks
+	defer func() { checks = old }()
+	checks = nil
+
+	checked := make([]string, 0, 2)
+	RegisterCheck(func(h *core.MessageHandler, doc *APIDoc) {
+		checked = append(checked, doc.Title.V())
+		h.Warning(doc.Location.NewError(locale.ErrInvalidValue))
+	})
+
+	// 包含错误的文档不会被检测，错误位于 api 中时也是如此。
+	docs := &Documents{}
+	rslt := parseDocuments(docs,
+		newDocumentsBlock("api1.go", `<api method="GET" doc="admin"><path path="/admin" /><response status="200" type="xxx" /></api>`),
+		newDocumentsBlock("api2.go", `<api method="GET"><path path="/users" /><response status="200" /></api>`),
+		newDocumentsBlock("doc1.go", `<apidoc version="1.0.0"><title>public</title><mimetype>json</mimetype></apidoc>`),
+		newDocumentsBlock("doc2.go", `<apidoc version="1.0.0" id="admin"><title>admin</title><mimetype>json</mimetype></apidoc>`),
+	)
+	a.Length(rslt.Errors, 1).
+		Length(rslt.Warns, 1).
+		Equal(checked, []string{"public"})
+}
+
+func TestDocuments_DeleteURI(t *testing.T) {
+	a := assert.New(t, false)
+
+	d := &APIDoc{}
+	d.APIDoc = &APIDocVersionAttribute{Value: xmlenc.String{Value: "1.0.0"}}
+	d.URI = core.URI("uri1")
+	d.APIs = []*API{
+		{ //1
+			BaseTag: xmlenc.BaseTag{Base: xmlenc.Base{Location: core.Location{URI: "uri1"}}},
+		},
+		{ //2
+			BaseTag: xmlenc.BaseTag{Base: xmlenc.Base{Location: core.Location{URI: "uri2"}}},
+		},
+		{ //3
+			BaseTag: xmlenc.BaseTag{Base: xmlenc.Base{Location: core.Location{URI: "uri3"}}},
+		},
+	}
+	docs := &Documents{Docs: []*APIDoc{d}}
+
+	a.True(docs.DeleteURI("uri3"))
+	a.Equal(2, len(d.APIs)).Length(docs.Docs, 1)
+
+	a.True(docs.DeleteURI("uri1"))
+	a.Empty(docs.Docs).Length(docs.apis, 1)
+
+	a.True(docs.DeleteURI("uri2"))
+	a.Empty(docs.Docs).Empty(docs.apis)
+
+	a.False(docs.DeleteURI("uri2"))
+}
+
+func TestDocuments_Search(t *testing.T) {
+	a := assert.New(t, false)
+
+	docs := &Documents{}
+	rslt := parseDocuments(docs,
+		newDocumentsBlock("doc1.go", `<apidoc version="1.0.0"><title>public</title><mimetype>json</mimetype></apidoc>`),
+		newDocumentsBlock("doc2.go", `<apidoc version="1.0.0" id="admin"><title>admin</title><mimetype>json</mimetype></apidoc>`),
+	)
+	a.Empty(rslt.Errors)
+
+	r := docs.Search("doc2.go", core.Position{Line: 0, Character: 1}, nil)
+	a.NotNil(r).Equal(r.(APIDoc).ID.V(), "admin")
+
+	a.Nil(docs.Search("doc3.go", core.Position{Line: 0, Character: 1}, nil))
+}
+
+func TestDocuments_DeleteURI_typedef(t *testing.T) {
+	a := assert.New(t, false)
+
+	doc := func(typ string) core.Block {
+		return newDocumentsBlock("doc.go", `<apidoc version="1.0.0"><title>t</title><mimetype>json</mimetype>
+	<type name="user" type="`+typ+`" summary="`+typ+`"><enum value="1" summary="1" /></type>
+</apidoc>`)
+	}
+	api := newDocumentsBlock("api.go", `<api method="GET"><path path="/users" />
+	<response status="200" type="object" mimetype="json">
+		<param name="u1" ref="user" />
+		<param name="u2" ref="user" summary="local"><enum value="2" summary="2" /></param>
+	</response>
+</api>`)
+
+	docs := &Documents{}
+	rslt := parseDocuments(docs, doc("number"), api)
+	a.Empty(rslt.Errors).Length(docs.Docs, 1)
+	items := docs.Docs[0].APIs[0].Responses[0].Items
+	a.Equal(items[0].Type.V(), TypeNumber).
+		Equal(items[0].Summary.V(), "number").
+		Equal(items[0].Ref.Inherited(), Inheritance{Type: true, Enums: true, Summary: true}).
+		Equal(items[1].Summary.V(), "local").
+		Equal(items[1].Enums[0].Value.V(), "2").
+		Equal(items[1].Ref.Inherited(), Inheritance{Type: true})
+
+	// 仅重新解析类型定义所在的文件，引用处的内容也应该随之更新。
+	a.True(docs.DeleteURI("doc.go"))
+	rslt = parseDocuments(docs, doc("string"))
+	a.Empty(rslt.Errors).Length(docs.Docs, 1)
+	items = docs.Docs[0].APIs[0].Responses[0].Items
+	a.Equal(items[0].Type.V(), TypeString).
+		Equal(items[0].Summary.V(), "string").
+		Equal(items[1].Type.V(), TypeString).
+		Equal(items[1].Summary.V(), "local").
+		Equal(items[1].Enums[0].Value.V(), "2")
+}
+
+func TestDocuments_DeleteURI_include(t *testing.T) {
+	a := assert.New(t, false)
+
+	dir := t.TempDir()
+	write := func(name, data string) core.URI {
+		a.NotError(os.WriteFile(filepath.Join(dir, name), []byte(data), os.ModePerm))
+		return core.FileURI(filepath.Join(dir, name))
+	}
+	apisURI := write("apis.xml", `<api method="GET"><path path="/users" /><response status="200" /></api>`)
+	docURI := core.FileURI(filepath.Join(dir, "doc.go"))
+	doc := newDocumentsBlock(docURI, `<apidoc version="1.0.0"><title>t</title><mimetype>json</mimetype>
+	<include src="apis.xml" />
+</apidoc>`)
+
+	docs := &Documents{}
+	rslt := parseDocuments(docs, doc)
+	a.Empty(rslt.Errors).Length(docs.Docs, 1).
+		Length(docs.Docs[0].APIs, 1).
+		Equal(docs.Docs[0].APIs[0].Path.Location.URI, apisURI).
+		Equal(docs.Includers(apisURI), []core.URI{docURI}).
+		Empty(docs.Includers(docURI))
+
+	// 被引用的文件发生变化，需要重新解析引用它的文件。
+	write("apis.xml", `<api method="POST"><path path="/users" /><response status="200" /></api>`)
+	a.False(docs.DeleteURI(apisURI))
+	a.True(docs.DeleteURI(docURI)).Empty(docs.Docs).Empty(docs.apis).Empty(docs.Includers(apisURI))
+	rslt = parseDocuments(docs, doc)
+	a.Empty(rslt.Errors).Length(docs.Docs, 1).
+		Length(docs.Docs[0].APIs, 1).
+		Equal(docs.Docs[0].APIs[0].Method.V(), "POST")
+
+	a.True(docs.DeleteURI(docURI)).Empty(docs.Docs).Empty(docs.apis)
+}
@@ -2,7 +2,13 @@
 
 package ast
 
-import "github.com/caixw/apidoc/v7/internal/xmlenc"
+import (
+	"strings"
+
+	"github.com/issue9/sliceutil"
+
+	"github.com/caixw/apidoc/v7/internal/xmlenc"
+)
 
 type (
 	// APIDoc 对应 apidoc 元素
@@ -11,21 +17,26 @@ type (
 		RootName struct{} `apidoc:"apidoc,meta,usage-apidoc"`
 
 		APIDoc        *APIDocVersionAttribute `apidoc:"apidoc,attr,usage-apidoc-apidoc,omitempty"` // 文档格式的版本号
+		ID            *Attribute              `apidoc:"id,attr,usage-apidoc-id,omitempty"`         // 文档的唯一标识，存在多份文档时用于区分
 		Lang          *Attribute              `apidoc:"lang,attr,usage-apidoc-lang,omitempty"`     // 区域信息，应该使用 BCP47 指定的格式
 		Logo          *Attribute              `apidoc:"logo,attr,usage-apidoc-logo,omitempty"`
 		XMLNamespaces []*XMLNamespace         `apidoc:"xml-namespace,elem,usage-apidoc-xml-namespaces,omitempty"`
 		Created       *DateAttribute          `apidoc:"created,attr,usage-apidoc-created,omitempty"` // 生成时间
 		Version       *VersionAttribute       `apidoc:"version,attr,usage-apidoc-version,omitempty"`
 		Title         *Element                `apidoc:"title,elem,usage-apidoc-title"`
-		Description   *Richtext               `apidoc:"description,elem,usage-apidoc-description,omitempty"` // 说明内容
-		Contact       *Contact                `apidoc:"contact,elem,usage-apidoc-contact,omitempty"`         // 团队的联系方式
-		License       *Link                   `apidoc:"license,elem,usage-apidoc-license,omitempty"`         // 版权信息
-		Tags          []*Tag                  `apidoc:"tag,elem,usage-apidoc-tags,omitempty"`                // 标签列表
-		Servers       []*Server               `apidoc:"server,elem,usage-apidoc-servers,omitempty"`          // 服务器列表
-		APIs          []*API                  `apidoc:"api,elem,usage-apidoc-apis,omitempty"`                // API 列表
-		Headers       []*Param                `apidoc:"header,elem,usage-apidoc-headers,omitempty"`          // 公共报头
-		Responses     []*Request              `apidoc:"response,elem,usage-apidoc-responses,omitempty"`      // 所有 API 都有可能的返回内容
-		Mimetypes     []*Element              `apidoc:"mimetype,elem,usage-apidoc-mimetypes"`                // 所有接口都支持的 mimetypes
+		Description   *Richtext               `apidoc:"description,elem,usage-apidoc-description,omitempty"`  // 说明内容
+		Translations  []*Translation          `apidoc:"translation,elem,usage-apidoc-translations,omitempty"` // 其它语言的翻译内容
+		Contact       *Contact                `apidoc:"contact,elem,usage-apidoc-contact,omitempty"`          // 团队的联系方式
+		License       *Link                   `apidoc:"license,elem,usage-apidoc-license,omitempty"`          // 版权信息
+		Tags          []*Tag                  `apidoc:"tag,elem,usage-apidoc-tags,omitempty"`                 // 标签列表
+		Servers       []*Server               `apidoc:"server,elem,usage-apidoc-servers,omitempty"`           // 服务器列表
+		Securities    []*Security             `apidoc:"security,elem,usage-apidoc-securities,omitempty"`      // 身份验证方案列表
+		APIs          []*API                  `apidoc:"api,elem,usage-apidoc-apis,omitempty"`                 // API 列表
+		Webhooks      []*Callback             `apidoc:"webhook,elem,usage-apidoc-webhooks,omitempty"`         // 不由请求触发的回调
+		Headers       []*Param                `apidoc:"header,elem,usage-apidoc-headers,omitempty"`           // 公共报头
+		Responses     []*Request              `apidoc:"response,elem,usage-apidoc-responses,omitempty"`       // 所有 API 都有可能的返回内容
+		Mimetypes     []*Element              `apidoc:"mimetype,elem,usage-apidoc-mimetypes"`                 // 所有接口都支持的 mimetypes
+		Types         []*TypeDef              `apidoc:"type,elem,usage-apidoc-types,omitempty"`               // 可复用的类型定义
 	}
 
 	// XMLNamespace 定义命名空间的相关属性
@@ -42,19 +53,26 @@ type (
 		RootName struct{} `apidoc:"api,meta,usage-api"`
 		doc      *APIDoc
 
-		Version     *VersionAttribute `apidoc:"version,attr,usage-api-version,omitempty"`
-		Method      *MethodAttribute  `apidoc:"method,attr,usage-api-method"`
-		ID          *Attribute        `apidoc:"id,attr,usage-api-id,omitempty"`
-		Path        *Path             `apidoc:"path,elem,usage-api-path"`
-		Summary     *Attribute        `apidoc:"summary,attr,usage-api-summary,omitempty"`
-		Description *Richtext         `apidoc:"description,elem,usage-api-description,omitempty"`
-		Requests    []*Request        `apidoc:"request,elem,usage-api-requests,omitempty"` // 不同的 mimetype 可能会定义不同
-		Responses   []*Request        `apidoc:"response,elem,usage-api-responses,omitempty"`
-		Callback    *Callback         `apidoc:"callback,elem,usage-api-callback,omitempty"`
-		Deprecated  *VersionAttribute `apidoc:"deprecated,attr,usage-api-deprecated,omitempty"`
-		Headers     []*Param          `apidoc:"header,elem,usage-api-headers,omitempty"`
-		Tags        []*TagValue       `apidoc:"tag,elem,usage-api-tags,omitempty"`
-		Servers     []*ServerValue    `apidoc:"server,elem,usage-api-servers,omitempty"`
+		Version      *VersionAttribute `apidoc:"version,attr,usage-api-version,omitempty"`
+		Method       *MethodAttribute  `apidoc:"method,attr,usage-api-method"`
+		ID           *Attribute        `apidoc:"id,attr,usage-api-id,omitempty"`
+		Document     *Attribute        `apidoc:"doc,attr,usage-api-doc,omitempty"` // 所属文档的 ID
+		Path         *Path             `apidoc:"path,elem,usage-api-path"`
+		Summary      *Attribute        `apidoc:"summary,attr,usage-api-summary,omitempty"`
+		Description  *Richtext         `apidoc:"description,elem,usage-api-description,omitempty"`
+		Translations []*Translation    `apidoc:"translation,elem,usage-api-translations,omitempty"`
+		Requests     []*Request        `apidoc:"request,elem,usage-api-requests,omitempty"` // 不同的 mimetype 可能会定义不同
+		Responses    []*Request        `apidoc:"response,elem,usage-api-responses,omitempty"`
+		Callbacks    []*Callback       `apidoc:"callback,elem,usage-api-callbacks,omitempty"`
+		Deprecated   *VersionAttribute `apidoc:"deprecated,attr,usage-api-deprecated,omitempty"`
+		Since        *VersionAttribute `apidoc:"since,attr,usage-api-since,omitempty"`
+		Headers      []*Param          `apidoc:"header,elem,usage-api-headers,omitempty"`
+		Cookies      []*Param          `apidoc:"cookie,elem,usage-api-cookies,omitempty"`
+		Tags         []*TagValue       `apidoc:"tag,elem,usage-api-tags,omitempty"`
+		Servers      []*ServerValue    `apidoc:"server,elem,usage-api-servers,omitempty"`
+		Securities   []*SecurityValue  `apidoc:"security,elem,usage-api-securities,omitempty"` // 多个值之间为或的关系
+		XAttributes  []*Attribute      `apidoc:"x-,attrs,usage-api-x-attributes,omitempty"`
+		Extensions   []*Extension      `apidoc:"extension,elem,usage-api-extensions,omitempty"`
 	}
 
 	// Link 表示一个链接
@@ -77,18 +95,23 @@ type (
 	}
 
 	// Callback 描述回调信息
+	//
+	// 同时用于 API 的 callback 元素和 APIDoc 的 webhook 元素。
 	Callback struct {
 		xmlenc.BaseTag
 		RootName struct{} `apidoc:"callback,meta,usage-callback"`
 
-		Method      *MethodAttribute  `apidoc:"method,attr,usage-callback-method"`
-		Path        *Path             `apidoc:"path,elem,usage-callback-path,omitempty"`
-		Summary     *Attribute        `apidoc:"summary,attr,usage-callback-summary,omitempty"`
-		Description *Richtext         `apidoc:"description,elem,usage-callback-description,omitempty"`
-		Deprecated  *VersionAttribute `apidoc:"deprecated,attr,usage-callback-deprecated,omitempty"`
-		Responses   []*Request        `apidoc:"response,elem,usage-callback-responses,omitempty"`
-		Requests    []*Request        `apidoc:"request,elem,usage-callback-requests"` // 至少一个
-		Headers     []*Param          `apidoc:"header,elem,usage-callback-headers,omitempty"`
+		Name         *Attribute        `apidoc:"name,attr,usage-callback-name,omitempty"` // webhook 中不能为空
+		Expression   *Attribute        `apidoc:"expression,attr,usage-callback-expression,omitempty"`
+		Method       *MethodAttribute  `apidoc:"method,attr,usage-callback-method"`
+		Path         *Path             `apidoc:"path,elem,usage-callback-path,omitempty"`
+		Summary      *Attribute        `apidoc:"summary,attr,usage-callback-summary,omitempty"`
+		Description  *Richtext         `apidoc:"description,elem,usage-callback-description,omitempty"`
+		Translations []*Translation    `apidoc:"translation,elem,usage-callback-translations,omitempty"`
+		Deprecated   *VersionAttribute `apidoc:"deprecated,attr,usage-callback-deprecated,omitempty"`
+		Responses    []*Request        `apidoc:"response,elem,usage-callback-responses,omitempty"`
+		Requests     []*Request        `apidoc:"request,elem,usage-callback-requests"` // 至少一个
+		Headers      []*Param          `apidoc:"header,elem,usage-callback-headers,omitempty"`
 	}
 
 	// Enum 表示枚举值
@@ -96,10 +119,12 @@ type (
 		xmlenc.BaseTag
 		RootName struct{} `apidoc:"enum,meta,usage-enum"`
 
-		Deprecated  *VersionAttribute `apidoc:"deprecated,attr,usage-enum-deprecated,omitempty"`
-		Value       *Attribute        `apidoc:"value,attr,usage-enum-value"`
-		Summary     *Attribute        `apidoc:"summary,attr,usage-enum-summary,omitempty"`
-		Description *Richtext         `apidoc:"description,elem,usage-enum-description,omitempty"`
+		Deprecated   *VersionAttribute `apidoc:"deprecated,attr,usage-enum-deprecated,omitempty"`
+		Since        *VersionAttribute `apidoc:"since,attr,usage-enum-since,omitempty"`
+		Value        *Attribute        `apidoc:"value,attr,usage-enum-value"`
+		Summary      *Attribute        `apidoc:"summary,attr,usage-enum-summary,omitempty"`
+		Description  *Richtext         `apidoc:"description,elem,usage-enum-description,omitempty"`
+		Translations []*Translation    `apidoc:"translation,elem,usage-enum-translations,omitempty"`
 	}
 
 	// Example 示例代码
@@ -108,8 +133,9 @@ type (
 		RootName struct{} `apidoc:"example,meta,usage-example"`
 
 		Mimetype *Attribute    `apidoc:"mimetype,attr,usage-example-mimetype"`
-		Content  *ExampleValue `apidoc:",cdata,usage-example-content"`
+		Content  *ExampleValue `apidoc:",cdata,usage-example-content,omitempty"`
 		Summary  *Attribute    `apidoc:"summary,attr,usage-example-summary,omitempty"`
+		Src      *SrcAttribute `apidoc:"src,attr,usage-example-src,omitempty"` // 从外部文件加载 Content 的内容
 	}
 
 	// Param 表示参数类型
@@ -118,16 +144,40 @@ type (
 		RootName struct{} `apidoc:"param,meta,usage-param"`
 
 		XML
-		Name        *Attribute        `apidoc:"name,attr,usage-param-name"`
-		Type        *TypeAttribute    `apidoc:"type,attr,usage-param-type"`
-		Deprecated  *VersionAttribute `apidoc:"deprecated,attr,usage-param-deprecated,omitempty"`
-		Default     *Attribute        `apidoc:"default,attr,usage-param-default,omitempty"`
-		Optional    *BoolAttribute    `apidoc:"optional,attr,usage-param-optional,omitempty"`
-		Array       *BoolAttribute    `apidoc:"array,attr,usage-param-array,omitempty"`
-		Items       []*Param          `apidoc:"param,elem,usage-param-items,omitempty"`
-		Summary     *Attribute        `apidoc:"summary,attr,usage-param-summary,omitempty"`
-		Enums       []*Enum           `apidoc:"enum,elem,usage-param-enums,omitempty"`
-		Description *Richtext         `apidoc:"description,elem,usage-param-description,omitempty"`
+		Name         *Attribute        `apidoc:"name,attr,usage-param-name"`
+		Type         *TypeAttribute    `apidoc:"type,attr,usage-param-type,omitempty"` // 指定了 Ref 时可以为空
+		Deprecated   *VersionAttribute `apidoc:"deprecated,attr,usage-param-deprecated,omitempty"`
+		Since        *VersionAttribute `apidoc:"since,attr,usage-param-since,omitempty"`
+		Default      *Attribute        `apidoc:"default,attr,usage-param-default,omitempty"`
+		Optional     *BoolAttribute    `apidoc:"optional,attr,usage-param-optional,omitempty"`
+		Array        *BoolAttribute    `apidoc:"array,attr,usage-param-array,omitempty"`
+		Items        []*Param          `apidoc:"param,elem,usage-param-items,omitempty"`
+		Summary      *Attribute        `apidoc:"summary,attr,usage-param-summary,omitempty"`
+		Enums        []*Enum           `apidoc:"enum,elem,usage-param-enums,omitempty"`
+		Description  *Richtext         `apidoc:"description,elem,usage-param-description,omitempty"`
+		Translations []*Translation    `apidoc:"translation,elem,usage-param-translations,omitempty"`
+		Ref          *RefAttribute     `apidoc:"ref,attr,usage-param-ref,omitempty"` // 引用 APIDoc.Types 中的类型
+
+		// 组合类型，其中的 param 表示各个分支。
+		OneOf *Composition `apidoc:"one-of,elem,usage-param-one-of,omitempty"` // 仅匹配其中一个分支
+		AnyOf *Composition `apidoc:"any-of,elem,usage-param-any-of,omitempty"` // 匹配任意一个分支
+		AllOf *Composition `apidoc:"all-of,elem,usage-param-all-of,omitempty"` // 同时匹配所有分支
+
+		// 数值的取值范围，仅对数值类型有效。
+		Min          *NumberAttribute `apidoc:"min,attr,usage-param-min,omitempty"`
+		Max          *NumberAttribute `apidoc:"max,attr,usage-param-max,omitempty"`
+		ExclusiveMin *BoolAttribute   `apidoc:"exclusive-min,attr,usage-param-exclusive-min,omitempty"`
+		ExclusiveMax *BoolAttribute   `apidoc:"exclusive-max,attr,usage-param-exclusive-max,omitempty"`
+
+		// 字符串的长度以及格式，仅对字符串类型有效。
+		MinLength *NumberAttribute `apidoc:"min-length,attr,usage-param-min-length,omitempty"`
+		MaxLength *NumberAttribute `apidoc:"max-length,attr,usage-param-max-length,omitempty"`
+		Pattern   *Attribute       `apidoc:"pattern,attr,usage-param-pattern,omitempty"`
+
+		// 数组元素的数量以及是否可重复，仅在 Array 为 true 时有效。
+		MinItems    *NumberAttribute `apidoc:"min-items,attr,usage-param-min-items,omitempty"`
+		MaxItems    *NumberAttribute `apidoc:"max-items,attr,usage-param-max-items,omitempty"`
+		UniqueItems *BoolAttribute   `apidoc:"unique-items,attr,usage-param-unique-items,omitempty"`
 
 		// 数组参数是否展开
 		//
@@ -137,6 +187,9 @@ type (
 		// 1 为默认方式，ArrayStyle 为 true，则展示为第二种方式
 		// 该参数目前仅在查询参数中启作用
 		ArrayStyle *BoolAttribute `apidoc:"array-style,attr,usage-param-array-style,omitempty"`
+
+		XAttributes []*Attribute `apidoc:"x-,attrs,usage-param-x-attributes,omitempty"`
+		Extensions  []*Extension `apidoc:"extension,elem,usage-param-extensions,omitempty"`
 	}
 
 	// Path 路径信息
@@ -158,17 +211,59 @@ type (
 		// 一般无用，但是用于描述 XML 对象时，可以用来表示顶层元素的名称
 		Name *Attribute `apidoc:"name,attr,usage-request-name,omitempty"`
 
-		Type        *TypeAttribute    `apidoc:"type,attr,usage-request-type,omitempty"`
-		Deprecated  *VersionAttribute `apidoc:"deprecated,attr,usage-request-deprecated,omitempty"`
-		Enums       []*Enum           `apidoc:"enum,elem,usage-request-enums,omitempty"`
-		Array       *BoolAttribute    `apidoc:"array,attr,usage-request-array,omitempty"`
-		Items       []*Param          `apidoc:"param,elem,usage-request-items,omitempty"`
-		Summary     *Attribute        `apidoc:"summary,attr,usage-request-summary,omitempty"`
-		Status      *StatusAttribute  `apidoc:"status,attr,usage-request-status,omitempty"`
-		Mimetype    *Attribute        `apidoc:"mimetype,attr,usage-request-mimetype,omitempty"`
-		Examples    []*Example        `apidoc:"example,elem,usage-request-examples,omitempty"`
-		Headers     []*Param          `apidoc:"header,elem,usage-request-headers,omitempty"` // 当前独有的报头，公用的可以放在 API 中
-		Description *Richtext         `apidoc:"description,elem,usage-request-description,omitempty"`
+		Type         *TypeAttribute    `apidoc:"type,attr,usage-request-type,omitempty"`
+		Deprecated   *VersionAttribute `apidoc:"deprecated,attr,usage-request-deprecated,omitempty"`
+		Since        *VersionAttribute `apidoc:"since,attr,usage-request-since,omitempty"`
+		Enums        []*Enum           `apidoc:"enum,elem,usage-request-enums,omitempty"`
+		Array        *BoolAttribute    `apidoc:"array,attr,usage-request-array,omitempty"`
+		Items        []*Param          `apidoc:"param,elem,usage-request-items,omitempty"`
+		Summary      *Attribute        `apidoc:"summary,attr,usage-request-summary,omitempty"`
+		Status       *StatusAttribute  `apidoc:"status,attr,usage-request-status,omitempty"`
+		Mimetype     *Attribute        `apidoc:"mimetype,attr,usage-request-mimetype,omitempty"`
+		Examples     []*Example        `apidoc:"example,elem,usage-request-examples,omitempty"`
+		Headers      []*Param          `apidoc:"header,elem,usage-request-headers,omitempty"` // 当前独有的报头，公用的可以放在 API 中
+		Cookies      []*Param          `apidoc:"cookie,elem,usage-request-cookies,omitempty"` // 作为返回内容时，表示由 Set-Cookie 设置的值
+		Description  *Richtext         `apidoc:"description,elem,usage-request-description,omitempty"`
+		Translations []*Translation    `apidoc:"translation,elem,usage-request-translations,omitempty"`
+		Ref          *RefAttribute     `apidoc:"ref,attr,usage-request-ref,omitempty"` // 引用 APIDoc.Types 中的类型
+		OneOf        *Composition      `apidoc:"one-of,elem,usage-request-one-of,omitempty"`
+		AnyOf        *Composition      `apidoc:"any-of,elem,usage-request-any-of,omitempty"`
+		AllOf        *Composition      `apidoc:"all-of,elem,usage-request-all-of,omitempty"`
+		Links        []*ResponseLink   `apidoc:"link,elem,usage-request-links,omitempty"` // 仅对返回内容有效
+		XAttributes  []*Attribute      `apidoc:"x-,attrs,usage-request-x-attributes,omitempty"`
+		Extensions   []*Extension      `apidoc:"extension,elem,usage-request-extensions,omitempty"`
+	}
+
+	// Composition 组合类型
+	//
+	// 由 Param 和 Request 的 one-of、any-of 和 all-of 元素使用，
+	// Items 中的每一个元素表示一个分支。
+	Composition struct {
+		xmlenc.BaseTag
+		RootName struct{} `apidoc:"composition,meta,usage-composition"`
+
+		// 用于区分分支的属性名称，该属性的值即为分支的 name 属性。
+		Discriminator *Attribute `apidoc:"discriminator,attr,usage-composition-discriminator,omitempty"`
+		Items         []*Param   `apidoc:"param,elem,usage-composition-items"`
+	}
+
+	// TypeDef 可复用的类型定义
+	//
+	// 由 Param.Ref 和 Request.Ref 通过 Name 引用。
+	TypeDef struct {
+		xmlenc.BaseTag
+		RootName struct{} `apidoc:"typedef,meta,usage-typedef"`
+
+		Name         *Attribute        `apidoc:"name,attr,usage-typedef-name"` // 类型的唯一名称
+		Type         *TypeAttribute    `apidoc:"type,attr,usage-typedef-type"`
+		Deprecated   *VersionAttribute `apidoc:"deprecated,attr,usage-typedef-deprecated,omitempty"`
+		Items        []*Param          `apidoc:"param,elem,usage-typedef-items,omitempty"`
+		Enums        []*Enum           `apidoc:"enum,elem,usage-typedef-enums,omitempty"`
+		Summary      *Attribute        `apidoc:"summary,attr,usage-typedef-summary,omitempty"`
+		Description  *Richtext         `apidoc:"description,elem,usage-typedef-description,omitempty"`
+		Translations []*Translation    `apidoc:"translation,elem,usage-typedef-translations,omitempty"`
+
+		references []*Reference
 	}
 
 	// Richtext 富文本内容
@@ -176,8 +271,58 @@ type (
 		xmlenc.BaseTag
 		RootName struct{} `apidoc:"richtext,meta,usage-richtext"`
 
-		Type *Attribute `apidoc:"type,attr,usage-richtext-type"` // 文档类型，可以是 html 或是 markdown
-		Text *CData     `apidoc:",cdata,usage-richtext-text"`
+		Type *Attribute    `apidoc:"type,attr,usage-richtext-type"` // 文档类型，可以是 html 或是 markdown
+		Text *CData        `apidoc:",cdata,usage-richtext-text,omitempty"`
+		Src  *SrcAttribute `apidoc:"src,attr,usage-richtext-src,omitempty"` // 从外部文件加载 Text 的内容
+	}
+
+	// Translation 其它语言的翻译内容
+	//
+	// 可以出现在包含 title、summary 和 description 的元素之中，
+	// 在输出指定语言的文档时，用于替换其所在元素中对应的内容。
+	Translation struct {
+		xmlenc.BaseTag
+		RootName struct{} `apidoc:"translation,meta,usage-translation"`
+
+		Lang        *Attribute `apidoc:"lang,attr,usage-translation-lang"` // 语言，应该使用 BCP47 指定的格式
+		Title       *Attribute `apidoc:"title,attr,usage-translation-title,omitempty"`
+		Summary     *Attribute `apidoc:"summary,attr,usage-translation-summary,omitempty"`
+		Description *Richtext  `apidoc:"description,elem,usage-translation-description,omitempty"`
+	}
+
+	// Extension 扩展内容
+	//
+	// 与 x- 开头的属性相同，但是可以表示复杂类型的值，
+	// 内容为 JSON 格式，输出 openapi 时会作为同名的扩展字段。
+	Extension struct {
+		xmlenc.BaseTag
+		RootName struct{} `apidoc:"extension,meta,usage-extension"`
+
+		Name  *Attribute `apidoc:"name,attr,usage-extension-name"` // 必须以 x- 开头
+		Value *CData     `apidoc:",cdata,usage-extension-value"`
+	}
+
+	// ResponseLink 返回内容与其它 API 之间的关联
+	//
+	// 比如创建之后返回的 id 可以作为获取详情的 API 的参数。
+	ResponseLink struct {
+		xmlenc.BaseTag
+		RootName struct{} `apidoc:"response-link,meta,usage-response-link"`
+
+		Name        *Attribute           `apidoc:"name,attr,usage-response-link-name"`
+		API         *Attribute           `apidoc:"api,attr,usage-response-link-api"` // 目标 API 的 ID
+		Summary     *Attribute           `apidoc:"summary,attr,usage-response-link-summary,omitempty"`
+		Description *Richtext            `apidoc:"description,elem,usage-response-link-description,omitempty"`
+		Params      []*ResponseLinkParam `apidoc:"param,elem,usage-response-link-params,omitempty"`
+	}
+
+	// ResponseLinkParam 目标 API 中参数的取值
+	ResponseLinkParam struct {
+		xmlenc.BaseTag
+		RootName struct{} `apidoc:"response-link-param,meta,usage-response-link-param"`
+
+		Name  *Attribute `apidoc:"name,attr,usage-response-link-param-name"`
+		Field *Attribute `apidoc:"field,attr,usage-response-link-param-field"` // 以 . 分隔的返回字段
 	}
 
 	// Tag 标签内容
@@ -185,9 +330,13 @@ type (
 		xmlenc.BaseTag
 		RootName struct{} `apidoc:"tag,meta,usage-tag"`
 
-		Name       *Attribute        `apidoc:"name,attr,usage-tag-name"`   // 标签的唯一 ID
-		Title      *Attribute        `apidoc:"title,attr,usage-tag-title"` // 显示的名称
-		Deprecated *VersionAttribute `apidoc:"deprecated,attr,usage-tag-deprecated,omitempty"`
+		Name         *Attribute        `apidoc:"name,attr,usage-tag-name"`   // 标签的唯一 ID
+		Title        *Attribute        `apidoc:"title,attr,usage-tag-title"` // 显示的名称
+		Deprecated   *VersionAttribute `apidoc:"deprecated,attr,usage-tag-deprecated,omitempty"`
+		Since        *VersionAttribute `apidoc:"since,attr,usage-tag-since,omitempty"`
+		Translations []*Translation    `apidoc:"translation,elem,usage-tag-translations,omitempty"`
+		XAttributes  []*Attribute      `apidoc:"x-,attrs,usage-tag-x-attributes,omitempty"`
+		Extensions   []*Extension      `apidoc:"extension,elem,usage-tag-extensions,omitempty"`
 
 		references []*Reference
 	}
@@ -197,15 +346,71 @@ type (
 		xmlenc.BaseTag
 		RootName struct{} `apidoc:"server,meta,usage-server"`
 
-		Name        *Attribute        `apidoc:"name,attr,usage-server-name"` // 字面名称，需要唯一
-		URL         *Attribute        `apidoc:"url,attr,usage-server-url"`
-		Deprecated  *VersionAttribute `apidoc:"deprecated,attr,usage-server-deprecated,omitempty"`
-		Summary     *Attribute        `apidoc:"summary,attr,usage-server-summary,omitempty"`
-		Description *Richtext         `apidoc:"description,elem,usage-server-description,omitempty"`
+		Name         *Attribute        `apidoc:"name,attr,usage-server-name"` // 字面名称，需要唯一
+		URL          *Attribute        `apidoc:"url,attr,usage-server-url"`
+		Deprecated   *VersionAttribute `apidoc:"deprecated,attr,usage-server-deprecated,omitempty"`
+		Since        *VersionAttribute `apidoc:"since,attr,usage-server-since,omitempty"`
+		Summary      *Attribute        `apidoc:"summary,attr,usage-server-summary,omitempty"`
+		Description  *Richtext         `apidoc:"description,elem,usage-server-description,omitempty"`
+		Translations []*Translation    `apidoc:"translation,elem,usage-server-translations,omitempty"`
+		Variables    []*ServerVariable `apidoc:"variable,elem,usage-server-variables,omitempty"` // URL 中 {name} 形式的变量
+		XAttributes  []*Attribute      `apidoc:"x-,attrs,usage-server-x-attributes,omitempty"`
+		Extensions   []*Extension      `apidoc:"extension,elem,usage-server-extensions,omitempty"`
+
+		references []*Reference
+	}
+
+	// ServerVariable 服务地址中的变量
+	ServerVariable struct {
+		xmlenc.BaseTag
+		RootName struct{} `apidoc:"variable,meta,usage-server-variable"`
+
+		Name    *Attribute `apidoc:"name,attr,usage-server-variable-name"`
+		Default *Attribute `apidoc:"default,attr,usage-server-variable-default"`
+		Summary *Attribute `apidoc:"summary,attr,usage-server-variable-summary,omitempty"`
+		Enums   []*Enum    `apidoc:"enum,elem,usage-server-variable-enums,omitempty"`
+	}
+
+	// Security 身份验证方案
+	Security struct {
+		xmlenc.BaseTag
+		RootName struct{} `apidoc:"security,meta,usage-security"`
+
+		Name         *Attribute     `apidoc:"name,attr,usage-security-name"`                             // 唯一 ID
+		Type         *Attribute     `apidoc:"type,attr,usage-security-type"`                             // 可以是 http、apikey 和 oauth2
+		Scheme       *Attribute     `apidoc:"scheme,attr,usage-security-scheme,omitempty"`               // type 为 http 时的验证方案
+		BearerFormat *Attribute     `apidoc:"bearer-format,attr,usage-security-bearer-format,omitempty"` // scheme 为 bearer 时令牌的格式
+		IN           *Attribute     `apidoc:"in,attr,usage-security-in,omitempty"`                       // type 为 apikey 时，可以是 header、query 和 cookie
+		Key          *Attribute     `apidoc:"key,attr,usage-security-key,omitempty"`                     // type 为 apikey 时，表示报头、查询参数或是 cookie 的名称
+		Summary      *Attribute     `apidoc:"summary,attr,usage-security-summary,omitempty"`
+		Description  *Richtext      `apidoc:"description,elem,usage-security-description,omitempty"`
+		Translations []*Translation `apidoc:"translation,elem,usage-security-translations,omitempty"`
+		Flows        []*OAuthFlow   `apidoc:"flow,elem,usage-security-flows,omitempty"` // type 为 oauth2 时的授权流程
 
 		references []*Reference
 	}
 
+	// OAuthFlow OAuth2 的授权流程
+	OAuthFlow struct {
+		xmlenc.BaseTag
+		RootName struct{} `apidoc:"oauth-flow,meta,usage-oauth-flow"`
+
+		Type             *Attribute    `apidoc:"type,attr,usage-oauth-flow-type"`
+		AuthorizationURL *Attribute    `apidoc:"authorization-url,attr,usage-oauth-flow-authorization-url,omitempty"`
+		TokenURL         *Attribute    `apidoc:"token-url,attr,usage-oauth-flow-token-url,omitempty"`
+		RefreshURL       *Attribute    `apidoc:"refresh-url,attr,usage-oauth-flow-refresh-url,omitempty"`
+		Scopes           []*OAuthScope `apidoc:"scope,elem,usage-oauth-flow-scopes,omitempty"`
+	}
+
+	// OAuthScope OAuth2 授权流程中可用的权限
+	OAuthScope struct {
+		xmlenc.BaseTag
+		RootName struct{} `apidoc:"oauth-scope,meta,usage-oauth-scope"`
+
+		Name    *Attribute `apidoc:"name,attr,usage-oauth-scope-name"`
+		Summary *Attribute `apidoc:"summary,attr,usage-oauth-scope-summary"`
+	}
+
 	// XML 仅作用于 XML 的几个属性
 	XML struct {
 		XMLAttr     *BoolAttribute `apidoc:"xml-attr,attr,usage-xml-attr,omitempty"`        // 作为父元素的 XML 属性存在
@@ -240,6 +445,16 @@ type (
 		definition *Definition
 	}
 
+	// SecurityValue api.security 的类型
+	SecurityValue struct {
+		xmlenc.BaseTag
+		Content  Content    `apidoc:",content"`
+		Scope    *Attribute `apidoc:"scope,attr,usage-security-value-scope,omitempty"` // 以空格分隔的权限列表，仅对 oauth2 有效。
+		RootName struct{}   `apidoc:"security-value,meta,usage-security-value"`
+
+		definition *Definition
+	}
+
 	// CData 表示 XML 的 CDATA 数据
 	CData struct {
 		xmlenc.BaseTag
@@ -266,6 +481,14 @@ func (r *Richtext) V() string {
 	return r.Text.Value.Value
 }
 
+// V 返回扩展内容的 JSON 格式值
+func (ext *Extension) V() string {
+	if ext == nil || ext.Value == nil {
+		return ""
+	}
+	return ext.Value.Value.Value
+}
+
 // V 返回当前属性实际表示的值
 func (s *Element) V() string {
 	if s == nil {
@@ -300,6 +523,24 @@ func (s *ServerValue) Definition() *Definition {
 	return s.definition
 }
 
+// V 返回当前属性实际表示的值
+func (s *SecurityValue) V() string {
+	if s == nil {
+		return ""
+	}
+	return s.Content.Value
+}
+
+// Scopes 返回 Scope 中的权限列表
+func (s *SecurityValue) Scopes() []string {
+	return strings.Fields(s.Scope.V())
+}
+
+// Definition Definitioner.Definition
+func (s *SecurityValue) Definition() *Definition {
+	return s.definition
+}
+
 // EncodeXML Encoder.EncodeXML
 func (cdata *CData) EncodeXML() (string, error) {
 	return cdata.Value.Value, nil
@@ -336,7 +577,45 @@ func (r *Request) Param() *Param {
 		Summary:     r.Summary,
 		Enums:       r.Enums,
 		Description: r.Description,
+		Ref:         r.Ref,
+		OneOf:       r.OneOf,
+		AnyOf:       r.AnyOf,
+		AllOf:       r.AllOf,
+	}
+}
+
+// Composed 是否为组合类型
+func (p *Param) Composed() bool {
+	return p.OneOf != nil || p.AnyOf != nil || p.AllOf != nil
+}
+
+// Branch 查找名称为 name 的分支
+func (c *Composition) Branch(name string) *Param {
+	for _, item := range c.Items {
+		if item.Name.V() == name {
+			return item
+		}
+	}
+	return nil
+}
+
+// Pattern 返回路径参数 name 需要匹配的正则表达式
+//
+// 路径参数可以采用 {name:pattern} 的形式指定其格式，未指定时返回空值。
+func (p *Path) Pattern(name string) string {
+	_, params, _ := parsePath(p.Path.V())
+	return params[name]
+}
+
+// Template 返回去掉了正则表达式之后的路径
+//
+// 比如 /users/{id:\d+} 返回 /users/{id}，路径格式不正确时原样返回。
+func (p *Path) Template() string {
+	template, _, err := parsePath(p.Path.V())
+	if err != nil {
+		return p.Path.V()
 	}
+	return template
 }
 
 // XMLNamespace 获取指定前缀名称的命名空间
@@ -349,6 +628,111 @@ func (doc *APIDoc) XMLNamespace(prefix string) *XMLNamespace {
 	return nil
 }
 
+// TypeDef 获取指定名称的类型定义
+func (doc *APIDoc) TypeDef(name string) *TypeDef {
+	for _, t := range doc.Types {
+		if t.Name.V() == name {
+			return t
+		}
+	}
+	return nil
+}
+
+// API 获取指定 ID 的 API
+//
+// id 为空时返回 nil。
+func (doc *APIDoc) API(id string) *API {
+	if id == "" {
+		return nil
+	}
+
+	for _, api := range doc.APIs {
+		if api.ID.V() == id {
+			return api
+		}
+	}
+	return nil
+}
+
+// PruneLinks 删除 link 元素中指向不存在的 API 的链接
+//
+// 在 API 被过滤之后调用，以免输出的文档中包含无效的 operationId。
+func (doc *APIDoc) PruneLinks() {
+	for _, resp := range doc.linkResponses() {
+		resp.Links = sliceutil.Delete(resp.Links, func(link *ResponseLink) bool { return doc.API(link.API.V()) == nil })
+	}
+}
+
+// 返回所有可以包含 link 元素的返回内容
+func (doc *APIDoc) linkResponses() []*Request {
+	responses := make([]*Request, 0, len(doc.Responses)+len(doc.APIs))
+	responses = append(responses, doc.Responses...)
+	for _, api := range doc.APIs {
+		responses = append(responses, api.Responses...)
+		for _, cb := range api.Callbacks {
+			responses = append(responses, cb.Responses...)
+		}
+	}
+	for _, cb := range doc.Webhooks {
+		responses = append(responses, cb.Responses...)
+	}
+	return responses
+}
+
+// Param 获取指定名称的参数
+//
+// 查找范围包括路径参数、查询参数、报头和 cookie，以及各个请求中的报头和 cookie。
+func (api *API) Param(name string) *Param {
+	params := make([]*Param, 0, 10)
+	if api.Path != nil {
+		params = append(params, api.Path.Params...)
+		params = append(params, api.Path.Queries...)
+	}
+	params = append(params, api.Headers...)
+	params = append(params, api.Cookies...)
+	for _, r := range api.Requests {
+		params = append(params, r.Headers...)
+		params = append(params, r.Cookies...)
+	}
+
+	for _, p := range params {
+		if p.Name.V() == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// Security 获取指定名称的身份验证方案
+func (doc *APIDoc) Security(name string) *Security {
+	for _, s := range doc.Securities {
+		if s.Name.V() == name {
+			return s
+		}
+	}
+	return nil
+}
+
+// Variable 获取指定名称的变量
+func (srv *Server) Variable(name string) *ServerVariable {
+	for _, v := range srv.Variables {
+		if v.Name.V() == name {
+			return v
+		}
+	}
+	return nil
+}
+
+// References impl Referencer
+func (s *Security) References() []*Reference {
+	return s.references
+}
+
+// References impl Referencer
+func (t *TypeDef) References() []*Reference {
+	return t.references
+}
+
 // References impl Referencer
 func (tag *Tag) References() []*Reference {
 	return tag.references
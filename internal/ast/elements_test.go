@@ -385,7 +385,7 @@ func TestAPI(t *testing.T) {
 	a.Equal(len(api.Responses), 2)
 	resp := api.Responses[0]
 	a.Equal(resp.Mimetype.V(), "json").
-		Equal(resp.Status.V(), 200).
+		Equal(resp.Status.Code(), 200).
 		Equal(resp.Type.V(), TypeObject).
 		Equal(len(resp.Items), 3)
 	sex := resp.Items[1]
@@ -402,11 +402,12 @@ func TestAPI(t *testing.T) {
 		Equal(req.Headers[0].Name.V(), "authorization")
 
 	// callback
-	cb := api.Callback
+	a.Length(api.Callbacks, 1)
+	cb := api.Callbacks[0]
 	a.Equal(cb.Method.V(), "POST").
 		Equal(cb.Requests[0].Type.V(), TypeObject).
 		Equal(cb.Requests[0].Mimetype.V(), "json").
-		Equal(cb.Responses[0].Status.V(), 200)
+		Equal(cb.Responses[0].Status.Code(), 200)
 }
 
 func TestRequest_Param(t *testing.T) {
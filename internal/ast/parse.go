@@ -16,6 +16,7 @@ import (
 // ParseBlocks 从多个 core.Block 实例中解析文档内容
 //
 // g 必须是一个阻塞函数，直到所有代码块都写入参数之后，才能返回。
+// 所有代码块解析完成之后，会关联各个 ref 属性引用的类型定义，并检测 link 指向的 API。
 func (doc *APIDoc) ParseBlocks(h *core.MessageHandler, g func(chan core.Block)) {
 	done := make(chan struct{})
 	blocks := make(chan core.Block, 50)
@@ -30,6 +31,9 @@ func (doc *APIDoc) ParseBlocks(h *core.MessageHandler, g func(chan core.Block))
 	g(blocks)
 	close(blocks)
 	<-done
+
+	doc.resolveTypes(h)
+	doc.resolveLinks(h)
 }
 
 // Parse 将注释块的内容添加到当前文档
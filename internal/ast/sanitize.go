@@ -3,10 +3,15 @@
 package ast
 
 import (
-	"strconv"
+	"encoding/json"
+	"math"
+	"regexp"
+	"regexp/syntax"
+	"strings"
 
 	"github.com/issue9/sliceutil"
-	"github.com/issue9/validation/is"
+	"github.com/issue9/version"
+	"golang.org/x/text/language"
 
 	"github.com/caixw/apidoc/v7/core"
 	"github.com/caixw/apidoc/v7/internal/locale"
@@ -15,11 +20,23 @@ import (
 
 // Sanitize token.Sanitizer
 func (api *API) Sanitize(p *xmlenc.Parser) {
+	checkSince(p, api.Since, api.Deprecated)
+	checkTranslations(p, api.Translations)
+	checkExtensions(p, api.XAttributes, api.Extensions)
+	checkCallbacks(p, api.Callbacks, false)
+
+	checkRequestLinks(p, api.Requests)
+
 	for _, header := range api.Headers { // 报头不能为 object
 		if header.Type.V() == TypeObject {
 			p.Error(header.Type.Location.NewError(locale.ErrInvalidValue).WithField("header"))
 		}
 	}
+	for _, cookie := range api.Cookies { // cookie 不能为 object
+		if cookie.Type.V() == TypeObject {
+			p.Error(cookie.Type.Location.NewError(locale.ErrInvalidValue).WithField("cookie"))
+		}
+	}
 
 	// 对 Servers 和 Tags 查重
 	indexes := sliceutil.Dup(api.Servers, func(i, j *ServerValue) bool { return i.V() == j.V() })
@@ -38,10 +55,231 @@ func (api *API) Sanitize(p *xmlenc.Parser) {
 		}
 		p.Error(err)
 	}
+	indexes = sliceutil.Dup(api.Securities, func(i, j *SecurityValue) bool { return i.V() == j.V() })
+	if len(indexes) > 0 {
+		err := api.Securities[indexes[0]].Location.NewError(locale.ErrDuplicateValue).WithField("security")
+		for _, srv := range indexes[1:] {
+			err.Relate(api.Securities[srv].Location, locale.Sprintf(locale.ErrDuplicateValue))
+		}
+		p.Error(err)
+	}
+}
+
+// Sanitize token.Sanitizer
+func (s *Security) Sanitize(p *xmlenc.Parser) {
+	checkTranslations(p, s.Translations)
+
+	switch s.Type.V() {
+	case SecurityTypeHTTP:
+		if s.Scheme.V() == "" {
+			p.Error(s.Location.NewError(locale.ErrIsEmpty, "scheme").WithField("scheme"))
+		}
+	case SecurityTypeAPIKey:
+		switch s.IN.V() {
+		case SecurityInHeader, SecurityInQuery, SecurityInCookie:
+		case "":
+			p.Error(s.Location.NewError(locale.ErrIsEmpty, "in").WithField("in"))
+		default:
+			p.Error(s.IN.Location.NewError(locale.ErrInvalidValue).WithField("in"))
+		}
+
+		if s.Key.V() == "" {
+			p.Error(s.Location.NewError(locale.ErrIsEmpty, "key").WithField("key"))
+		}
+	case SecurityTypeOAuth2:
+		if len(s.Flows) == 0 {
+			p.Error(s.Location.NewError(locale.ErrIsEmpty, "flow").WithField("flow"))
+		}
+
+		indexes := sliceutil.Dup(s.Flows, func(i, j *OAuthFlow) bool { return i.Type.V() == j.Type.V() })
+		if len(indexes) > 0 {
+			err := s.Flows[indexes[0]].Location.NewError(locale.ErrDuplicateValue).WithField("flow")
+			for _, i := range indexes[1:] {
+				err.Relate(s.Flows[i].Location, locale.Sprintf(locale.ErrDuplicateValue))
+			}
+			p.Error(err)
+		}
+	case "": // 不能为空的属性，在解码时已经处理。
+	default:
+		p.Error(s.Type.Location.NewError(locale.ErrInvalidValue).WithField("type"))
+	}
+
+	if s.Type.V() != SecurityTypeOAuth2 && len(s.Flows) > 0 {
+		p.Error(s.Flows[0].Location.NewError(locale.ErrInvalidValue).WithField("flow"))
+	}
+}
+
+// Sanitize token.Sanitizer
+func (f *OAuthFlow) Sanitize(p *xmlenc.Parser) {
+	var authURL, tokenURL bool
+	switch f.Type.V() {
+	case OAuthFlowImplicit:
+		authURL = true
+	case OAuthFlowPassword, OAuthFlowClientCredentials:
+		tokenURL = true
+	case OAuthFlowAuthorizationCode:
+		authURL = true
+		tokenURL = true
+	case "": // 不能为空的属性，在解码时已经处理。
+	default:
+		p.Error(f.Type.Location.NewError(locale.ErrInvalidValue).WithField("type"))
+	}
+
+	if authURL && f.AuthorizationURL.V() == "" {
+		p.Error(f.Location.NewError(locale.ErrIsEmpty, "authorization-url").WithField("authorization-url"))
+	}
+	if tokenURL && f.TokenURL.V() == "" {
+		p.Error(f.Location.NewError(locale.ErrIsEmpty, "token-url").WithField("token-url"))
+	}
+
+	indexes := sliceutil.Dup(f.Scopes, func(i, j *OAuthScope) bool { return i.Name.V() == j.Name.V() })
+	if len(indexes) > 0 {
+		err := f.Scopes[indexes[0]].Location.NewError(locale.ErrDuplicateValue).WithField("scope")
+		for _, i := range indexes[1:] {
+			err.Relate(f.Scopes[i].Location, locale.Sprintf(locale.ErrDuplicateValue))
+		}
+		p.Error(err)
+	}
+}
+
+// Sanitize token.Sanitizer
+func (ex *Example) Sanitize(p *xmlenc.Parser) {
+	if ex.Content == nil || ex.Content.Value.Value == "" {
+		if cdata := includeCData(p, ex.BaseTag, ex.Src); cdata != nil {
+			ex.Content = (*ExampleValue)(cdata)
+		}
+	}
+}
+
+// Sanitize token.Sanitizer
+func (r *Richtext) Sanitize(p *xmlenc.Parser) {
+	if r.Text == nil || r.Text.Value.Value == "" {
+		if cdata := includeCData(p, r.BaseTag, r.Src); cdata != nil {
+			r.Text = cdata
+		}
+	}
+}
+
+// 加载 src 指向的文件内容作为 tag 的 CDATA
+//
+// 仅在 CDATA 为空时才会调用，已有内容时忽略 src，这样构建后的文档可以被再次解析。
+// src 为空时表示 CDATA 是必须的，会报告错误。
+func includeCData(p *xmlenc.Parser, tag xmlenc.BaseTag, src *SrcAttribute) *CData {
+	if src == nil {
+		p.Error(tag.Location.NewError(locale.ErrIsEmpty, tag.StartTag.String()).WithField("cdata"))
+		return nil
+	}
+
+	b, err := p.Include(src.V())
+	if err != nil {
+		p.Error(src.Location.WithError(err).WithField("@src"))
+		return nil
+	}
+
+	cdata := &CData{
+		BaseTag: xmlenc.BaseTag{Base: xmlenc.Base{Location: b.Location}},
+		Value:   xmlenc.String{Location: b.Location, Value: string(b.Data)},
+	}
+	src.definition = &Definition{Location: b.Location, Target: cdata}
+	return cdata
+}
+
+// Sanitize token.Sanitizer
+func (c *Callback) Sanitize(p *xmlenc.Parser) {
+	checkTranslations(p, c.Translations)
+	checkRequestLinks(p, c.Requests)
+}
+
+// link 仅对返回内容有效
+func checkRequestLinks(p *xmlenc.Parser, requests []*Request) {
+	for _, r := range requests {
+		if len(r.Links) > 0 {
+			p.Error(r.Links[0].Location.NewError(locale.ErrInvalidTag).WithField("link"))
+		}
+	}
+}
+
+// Sanitize token.Sanitizer
+func (t *Translation) Sanitize(p *xmlenc.Parser) {
+	if _, err := language.Parse(t.Lang.V()); err != nil {
+		p.Error(t.Lang.Location.NewError(locale.ErrInvalidFormat).WithField(t.Lang.AttributeName.String()))
+	}
+
+	if t.Title == nil && t.Summary == nil && t.Description == nil {
+		p.Error(t.Location.NewError(locale.ErrIsEmpty, "summary").WithField("summary"))
+	}
+}
+
+// Sanitize token.Sanitizer
+func (ext *Extension) Sanitize(p *xmlenc.Parser) {
+	if !isExtensionName(ext.Name.V()) {
+		p.Error(ext.Name.Location.NewError(locale.ErrInvalidFormat).WithField(ext.Name.AttributeName.String()))
+	}
+
+	if !json.Valid([]byte(ext.V())) {
+		p.Error(ext.Location.NewError(locale.ErrInvalidFormat).WithField(ext.StartTag.String()))
+	}
+}
+
+// Sanitize token.Sanitizer
+func (t *Tag) Sanitize(p *xmlenc.Parser) {
+	checkSince(p, t.Since, t.Deprecated)
+	checkTranslations(p, t.Translations)
+	checkExtensions(p, t.XAttributes, t.Extensions)
+}
+
+// Sanitize token.Sanitizer
+func (srv *Server) Sanitize(p *xmlenc.Parser) {
+	checkSince(p, srv.Since, srv.Deprecated)
+	checkTranslations(p, srv.Translations)
+	checkExtensions(p, srv.XAttributes, srv.Extensions)
+
+	_, vars, err := parsePath(srv.URL.V())
+	if err != nil {
+		p.Error(srv.URL.Location.NewError(locale.ErrInvalidFormat).WithField("url"))
+		return
+	}
+
+	indexes := sliceutil.Dup(srv.Variables, func(i, j *ServerVariable) bool { return i.Name.V() == j.Name.V() })
+	if len(indexes) > 0 {
+		err := srv.Variables[indexes[0]].Location.NewError(locale.ErrDuplicateValue).WithField("variable")
+		for _, i := range indexes[1:] {
+			err.Relate(srv.Variables[i].Location, locale.Sprintf(locale.ErrDuplicateValue))
+		}
+		p.Error(err)
+	}
+
+	// URL 中的变量与 variable 元素必须一一对应
+	for name := range vars {
+		if srv.Variable(name) == nil {
+			p.Error(srv.URL.Location.NewError(locale.ErrPathNotMatchParams).WithField("url"))
+		}
+	}
+	for _, v := range srv.Variables {
+		if _, found := vars[v.Name.V()]; !found {
+			p.Error(v.Location.NewError(locale.ErrPathNotMatchParams).WithField("variable"))
+		}
+	}
+}
+
+// Sanitize token.Sanitizer
+func (v *ServerVariable) Sanitize(p *xmlenc.Parser) {
+	checkDuplicateEnum(v.Enums, p)
+
+	if len(v.Enums) == 0 || v.Default == nil { // default 为空的情况由解码器负责报错
+		return
+	}
+
+	if sliceutil.Count(v.Enums, func(e *Enum) bool { return e.Value.V() == v.Default.V() }) == 0 {
+		p.Error(v.Default.Location.NewError(locale.ErrInvalidValue).WithField("default"))
+	}
 }
 
 // Sanitize token.Sanitizer
 func (e *Enum) Sanitize(p *xmlenc.Parser) {
+	checkSince(p, e.Since, e.Deprecated)
+	checkTranslations(p, e.Translations)
+
 	if e.Description.V() == "" && e.Summary.V() == "" {
 		p.Error(e.Location.NewError(locale.ErrIsEmpty, "summary").WithField("summary"))
 	}
@@ -53,7 +291,7 @@ func (p *Path) Sanitize(pp *xmlenc.Parser) {
 		pp.Error(p.Location.NewError(locale.ErrIsEmpty, "path").WithField("path"))
 	}
 
-	params, err := parsePath(p.Path.V())
+	_, params, err := parsePath(p.Path.V())
 	if err != nil {
 		pp.Error(p.Path.Location.NewError(locale.ErrInvalidFormat).WithField("path"))
 	}
@@ -61,8 +299,14 @@ func (p *Path) Sanitize(pp *xmlenc.Parser) {
 		pp.Error(p.Location.NewError(locale.ErrPathNotMatchParams).WithField("path"))
 	}
 	for _, param := range p.Params {
-		if _, found := params[param.Name.V()]; !found {
+		pattern, found := params[param.Name.V()]
+		if !found {
 			pp.Error(param.Location.NewError(locale.ErrPathNotMatchParams).WithField("path"))
+			continue
+		}
+
+		if pattern != "" {
+			p.checkPattern(pp, param, pattern)
 		}
 	}
 
@@ -79,40 +323,186 @@ func (p *Path) Sanitize(pp *xmlenc.Parser) {
 	}
 }
 
-func parsePath(path string) (params map[string]struct{}, err error) {
-	start := -1
-	for i, b := range path {
-		switch b {
-		case '{':
-			if start != -1 {
-				return nil, locale.NewError(locale.ErrInvalidFormat)
+// 检测路径参数中的正则表达式是否正确，且与参数的定义相符
+//
+// 参数的枚举值和默认值都必须符合该正则表达式，bool 类型的参数至少要能匹配一种写法，
+// 数值类型的参数则至少要能匹配一个由数字组成的字符串。
+func (p *Path) checkPattern(pp *xmlenc.Parser, param *Param, pattern string) {
+	expr, err := regexp.Compile(AnchorPattern(pattern))
+	if err != nil {
+		pp.Error(p.Path.Location.WithError(err).WithField("path"))
+		return
+	}
+
+	newError := func() *core.Error {
+		return p.Path.Location.NewError(locale.ErrInvalidFormat).WithField("path").
+			Relate(param.Location, locale.Sprintf(locale.ErrInvalidFormat))
+	}
+
+	switch param.Type.V() {
+	case TypeBool:
+		values := []string{"true", "false", "1", "0", "t", "f", "TRUE", "FALSE", "True", "False", "T", "F"}
+		if sliceutil.Count(values, func(v string) bool { return expr.MatchString(v) }) == 0 {
+			pp.Error(newError())
+			return
+		}
+	case TypeInt, TypeInt32, TypeInt64:
+		if !matchDigits(AnchorPattern(pattern), "+-") {
+			pp.Error(newError())
+			return
+		}
+	case TypeNumber, TypeFloat, TypeDouble:
+		if !matchDigits(AnchorPattern(pattern), "+-.eE") {
+			pp.Error(newError())
+			return
+		}
+	}
+
+	for _, e := range param.Enums {
+		if !expr.MatchString(e.Value.V()) {
+			pp.Error(newError())
+			return
+		}
+	}
+
+	if param.Default != nil && !param.Array.V() && !expr.MatchString(param.Default.V()) {
+		pp.Error(newError())
+	}
+}
+
+// AnchorPattern 返回匹配整个字符串的正则表达式
+//
+// 路径参数中的 pattern 需要匹配参数的完整值，比如 \d+ 会被转换成 ^(?:\d+)$。
+func AnchorPattern(pattern string) string {
+	return "^(?:" + pattern + ")$"
+}
+
+// 正则表达式 pattern 能否匹配至少包含一个数字，且仅由数字和 chars 中的字符组成的字符串
+//
+// 通过遍历 pattern 编译后的指令实现，仅消耗数字和 chars 中的字符，
+// 零宽度的断言一律视为可以通过。
+func matchDigits(pattern, chars string) bool {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return false
+	}
+	prog, err := syntax.Compile(re.Simplify())
+	if err != nil {
+		return false
+	}
+
+	type state struct {
+		pc    uint32
+		digit bool // 是否已经匹配过数字
+	}
+	visited := make(map[state]struct{}, len(prog.Inst))
+	stack := []state{{pc: uint32(prog.Start)}}
+	runes := []rune("0123456789" + chars)
+
+	for len(stack) > 0 {
+		s := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if _, found := visited[s]; found {
+			continue
+		}
+		visited[s] = struct{}{}
+
+		inst := prog.Inst[s.pc]
+		switch inst.Op {
+		case syntax.InstMatch:
+			if s.digit {
+				return true
+			}
+		case syntax.InstFail:
+		case syntax.InstAlt, syntax.InstAltMatch:
+			stack = append(stack, state{pc: inst.Out, digit: s.digit}, state{pc: inst.Arg, digit: s.digit})
+		case syntax.InstCapture, syntax.InstEmptyWidth, syntax.InstNop:
+			stack = append(stack, state{pc: inst.Out, digit: s.digit})
+		default: // 各类匹配字符的指令
+			for _, r := range runes {
+				if inst.MatchRune(r) {
+					stack = append(stack, state{pc: inst.Out, digit: s.digit || (r >= '0' && r <= '9')})
+				}
 			}
+		}
+	}
+	return false
+}
 
-			start = i + 1
-		case '}':
-			if start == -1 {
-				return nil, locale.NewError(locale.ErrInvalidFormat)
+// 解析路径中的参数
+//
+// 参数可以是 {name} 或是 {name:pattern} 的形式，pattern 为该参数需要匹配的正则表达式，
+// 其中可以包含成对的 { 和 }，或是以 \ 转义的 { 和 }。
+// template 为去掉 pattern 之后的路径，params 的键名为参数名称，键值为对应的 pattern。
+func parsePath(path string) (template string, params map[string]string, err error) {
+	var buf strings.Builder
+	start, colon, depth := -1, -1, 0
+	escaped := false
+
+	for i, b := range path {
+		switch {
+		case start == -1: // 参数之外
+			if b == '}' {
+				return "", nil, locale.NewError(locale.ErrInvalidFormat)
+			}
+			if b == '{' {
+				start = i + 1
+			}
+			buf.WriteRune(b)
+		case colon == -1: // 参数名称
+			switch b {
+			case '{':
+				return "", nil, locale.NewError(locale.ErrInvalidFormat)
+			case ':':
+				colon = i
+			case '}':
+				if params == nil {
+					params = make(map[string]string, 3)
+				}
+				params[path[start:i]] = ""
+				buf.WriteString(path[start : i+1])
+				start = -1
 			}
+		default: // pattern
+			switch {
+			case escaped:
+				escaped = false
+			case b == '\\':
+				escaped = true
+			case b == '{':
+				depth++
+			case b == '}' && depth > 0:
+				depth--
+			case b == '}':
+				if colon+1 == i { // 空的 pattern
+					return "", nil, locale.NewError(locale.ErrInvalidFormat)
+				}
 
-			if params == nil {
-				params = make(map[string]struct{}, 3)
+				if params == nil {
+					params = make(map[string]string, 3)
+				}
+				params[path[start:colon]] = path[colon+1 : i]
+				buf.WriteString(path[start:colon])
+				buf.WriteByte('}')
+				start, colon = -1, -1
 			}
-			params[path[start:i]] = struct{}{}
-			start = -1
-		default:
 		}
 	}
 
 	if start != -1 { // 没有结束符号
-		return nil, locale.NewError(locale.ErrInvalidFormat)
+		return "", nil, locale.NewError(locale.ErrInvalidFormat)
 	}
 
-	return params, nil
+	return buf.String(), params, nil
 }
 
 // Sanitize token.Sanitizer
 func (r *Request) Sanitize(p *xmlenc.Parser) {
-	if r.Type.V() == TypeObject && len(r.Items) == 0 {
+	checkSince(p, r.Since, r.Deprecated)
+	checkTranslations(p, r.Translations)
+	checkExtensions(p, r.XAttributes, r.Extensions)
+
+	if r.Type.V() == TypeObject && len(r.Items) == 0 && r.OneOf == nil && r.AnyOf == nil && r.AllOf == nil {
 		p.Error(r.Location.NewError(locale.ErrIsEmpty, "param").WithField("param"))
 	}
 	if r.Type.V() == TypeNone && len(r.Items) > 0 {
@@ -121,8 +511,10 @@ func (r *Request) Sanitize(p *xmlenc.Parser) {
 
 	checkDuplicateEnum(r.Enums, p)
 
-	if err := chkEnumsType(r.Type, r.Enums, p); err != nil {
-		p.Error(err)
+	if r.Type != nil || r.Ref.V() == "" { // 未指定类型时，由 ref 引用的类型定义决定枚举值的类型。
+		if err := chkEnumsType(r.Type, r.Enums, p); err != nil {
+			p.Error(err)
+		}
 	}
 
 	if err := checkXML(r.Array.V(), len(r.Items) > 0, &r.XML, p); err != nil {
@@ -137,33 +529,65 @@ func (r *Request) Sanitize(p *xmlenc.Parser) {
 		}
 	}
 
-	// 报头不能为 object
+	// 报头和 cookie 不能为 object
 	for _, header := range r.Headers {
 		if header.Type.V() == TypeObject {
 			p.Error(header.Type.Location.NewError(locale.ErrInvalidValue).WithField("type"))
 		}
 	}
+	for _, cookie := range r.Cookies {
+		if cookie.Type.V() == TypeObject {
+			p.Error(cookie.Type.Location.NewError(locale.ErrInvalidValue).WithField("type"))
+		}
+	}
 
 	checkDuplicateItems(r.Items, p)
+
+	indexes := sliceutil.Dup(r.Links, func(i, j *ResponseLink) bool { return i.Name.V() == j.Name.V() })
+	if len(indexes) > 0 {
+		err := r.Links[indexes[0]].Location.NewError(locale.ErrDuplicateValue).WithField("link")
+		for _, i := range indexes[1:] {
+			err.Relate(r.Links[i].Location, locale.Sprintf(locale.ErrDuplicateValue))
+		}
+		p.Error(err)
+	}
 }
 
 // Sanitize token.Sanitizer
 func (p *Param) Sanitize(pp *xmlenc.Parser) {
-	if p.Type.V() == TypeNone {
+	checkSince(pp, p.Since, p.Deprecated)
+	checkTranslations(pp, p.Translations)
+	checkExtensions(pp, p.XAttributes, p.Extensions)
+
+	// 引用了类型定义的参数，其类型等信息可以从类型定义中获取。
+	ref := p.Ref.V() != ""
+
+	// 组合类型的内容由各个分支决定
+	composed := p.Composed()
+
+	if p.Type.V() == TypeNone && !ref && !composed {
 		pp.Error(p.Location.NewError(locale.ErrIsEmpty, "type").WithField("type"))
 	}
-	if p.Type.V() == TypeObject && len(p.Items) == 0 {
+	if p.Type.V() == TypeObject && len(p.Items) == 0 && !composed {
 		pp.Error(p.Location.NewError(locale.ErrIsEmpty, "param").WithField("param"))
 	}
 
-	if p.Type.V() != TypeObject && len(p.Items) > 0 {
+	if p.Type != nil && p.Type.V() != TypeObject && len(p.Items) > 0 {
 		pp.Error(p.Type.Value.Location.NewError(locale.ErrInvalidValue).WithField("type"))
 	}
 
 	checkDuplicateEnum(p.Enums, pp)
 
-	if err := chkEnumsType(p.Type, p.Enums, pp); err != nil {
-		pp.Error(err)
+	if p.Type != nil || !ref { // 未指定类型时，由 ref 引用的类型定义决定枚举值的类型。
+		if err := chkEnumsType(p.Type, p.Enums, pp); err != nil {
+			pp.Error(err)
+		}
+	}
+
+	if p.Default != nil && !ref && !composed {
+		if _, ok := p.DefaultValue(); !ok {
+			pp.Error(p.Default.Location.NewError(locale.ErrInvalidFormat).WithField(p.Default.AttributeName.String()))
+		}
 	}
 
 	checkDuplicateItems(p.Items, pp)
@@ -172,34 +596,281 @@ func (p *Param) Sanitize(pp *xmlenc.Parser) {
 		pp.Error(err)
 	}
 
-	if p.Summary.V() == "" && p.Description.V() == "" {
+	checkConstraints(p, pp)
+
+	if p.Summary.V() == "" && p.Description.V() == "" && !ref {
 		pp.Error(p.Location.NewError(locale.ErrIsEmpty, "summary").WithField("summary"))
 	}
 }
 
+// Sanitize token.Sanitizer
+func (c *Composition) Sanitize(p *xmlenc.Parser) {
+	if len(c.Items) == 0 {
+		p.Error(c.Location.NewError(locale.ErrIsEmpty, "param").WithField("param"))
+		return
+	}
+
+	checkDuplicateItems(c.Items, p)
+
+	name := c.Discriminator.V()
+	if name == "" {
+		return
+	}
+
+	// 引用类型定义的分支，其子元素在解析完所有文档之后才填充，此处不作检测。
+	for _, item := range c.Items {
+		if item.Ref.V() != "" {
+			continue
+		}
+
+		found := false
+		for _, prop := range item.Items {
+			if prop.Name.V() == name {
+				found = !prop.Optional.V()
+				break
+			}
+		}
+		if !found {
+			p.Error(item.Location.NewError(locale.ErrNotFound).WithField("discriminator"))
+		}
+	}
+}
+
+// 返回 p 中所有的组合类型
+func (p *Param) compositions() []*Composition {
+	cs := make([]*Composition, 0, 3)
+	for _, c := range []*Composition{p.OneOf, p.AnyOf, p.AllOf} {
+		if c != nil {
+			cs = append(cs, c)
+		}
+	}
+	return cs
+}
+
+// Sanitize token.Sanitizer
+func (t *TypeDef) Sanitize(p *xmlenc.Parser) {
+	checkTranslations(p, t.Translations)
+
+	if t.Name.V() == "" {
+		p.Error(t.Location.NewError(locale.ErrIsEmpty, "name").WithField("name"))
+	}
+
+	switch {
+	case t.Type.V() == TypeNone:
+		p.Error(t.Location.NewError(locale.ErrIsEmpty, "type").WithField("type"))
+	case t.Type.V() == TypeObject && len(t.Items) == 0:
+		p.Error(t.Location.NewError(locale.ErrIsEmpty, "param").WithField("param"))
+	case t.Type.V() != TypeObject && len(t.Items) > 0:
+		p.Error(t.Type.Value.Location.NewError(locale.ErrInvalidValue).WithField("type"))
+	}
+
+	checkDuplicateEnum(t.Enums, p)
+
+	if err := chkEnumsType(t.Type, t.Enums, p); err != nil {
+		p.Error(err)
+	}
+
+	checkDuplicateItems(t.Items, p)
+}
+
+// 检测 min、max、pattern 等约束条件是否与类型相符以及相互之间是否矛盾
+func checkConstraints(p *Param, pp *xmlenc.Parser) {
+	// 引用类型定义且未指定 type 时，无法判断约束条件是否与类型相符。
+	typed := p.Type.V() != TypeNone
+	primitive, _ := ParseType(p.Type.V())
+
+	// 数值
+	if typed && primitive != TypeNumber {
+		for _, attr := range []*NumberAttribute{p.Min, p.Max} {
+			if attr != nil {
+				pp.Error(invalidAttributeError(&attr.BaseAttribute))
+			}
+		}
+		for _, attr := range []*BoolAttribute{p.ExclusiveMin, p.ExclusiveMax} {
+			if attr != nil {
+				pp.Error(invalidAttributeError(&attr.BaseAttribute))
+			}
+		}
+	}
+	if p.ExclusiveMin.V() && p.Min == nil {
+		pp.Error(p.ExclusiveMin.Location.NewError(locale.ErrIsEmpty, "min").WithField("min"))
+	}
+	if p.ExclusiveMax.V() && p.Max == nil {
+		pp.Error(p.ExclusiveMax.Location.NewError(locale.ErrIsEmpty, "max").WithField("max"))
+	}
+	if p.Min != nil && p.Max != nil {
+		min, max := p.Min.Float64(), p.Max.Float64()
+		if min > max || (min == max && (p.ExclusiveMin.V() || p.ExclusiveMax.V())) {
+			pp.Error(invalidAttributeError(&p.Max.BaseAttribute))
+		} else if t := p.Type.V(); (t == TypeInt || t == TypeInt32 || t == TypeInt64) && !hasInteger(p) {
+			pp.Error(invalidAttributeError(&p.Max.BaseAttribute))
+		}
+	}
+
+	// 字符串
+	if typed && primitive != TypeString {
+		for _, attr := range []*NumberAttribute{p.MinLength, p.MaxLength} {
+			if attr != nil {
+				pp.Error(invalidAttributeError(&attr.BaseAttribute))
+			}
+		}
+		if p.Pattern != nil {
+			pp.Error(invalidAttributeError(&p.Pattern.BaseAttribute))
+		}
+	}
+	checkSizeRange(p.MinLength, p.MaxLength, pp)
+	if p.Pattern != nil {
+		if _, err := regexp.Compile(p.Pattern.V()); err != nil {
+			pp.Error(p.Pattern.Location.WithError(err).WithField(p.Pattern.AttributeName.String()))
+		}
+	}
+
+	// 数组
+	if !p.Array.V() {
+		for _, attr := range []*NumberAttribute{p.MinItems, p.MaxItems} {
+			if attr != nil {
+				pp.Error(invalidAttributeError(&attr.BaseAttribute))
+			}
+		}
+		if p.UniqueItems != nil {
+			pp.Error(invalidAttributeError(&p.UniqueItems.BaseAttribute))
+		}
+	}
+	checkSizeRange(p.MinItems, p.MaxItems, pp)
+}
+
+// min 和 max 之间是否存在整数
+func hasInteger(p *Param) bool {
+	min, max := p.Min.Float64(), p.Max.Float64()
+	lo, hi := math.Ceil(min), math.Floor(max)
+	if p.ExclusiveMin.V() && lo == min {
+		lo++
+	}
+	if p.ExclusiveMax.V() && hi == max {
+		hi--
+	}
+	return lo <= hi
+}
+
+// 检测表示长度范围的 min 和 max，两者都必须是非负整数且 min 不能大于 max。
+func checkSizeRange(min, max *NumberAttribute, pp *xmlenc.Parser) {
+	for _, attr := range []*NumberAttribute{min, max} {
+		if attr != nil && (attr.IsFloat() || attr.IntValue() < 0) {
+			pp.Error(invalidAttributeError(&attr.BaseAttribute))
+		}
+	}
+
+	if min != nil && max != nil && min.Float64() > max.Float64() {
+		pp.Error(invalidAttributeError(&max.BaseAttribute))
+	}
+}
+
+// 检测 since 是否早于 deprecated
+func checkSince(p *xmlenc.Parser, since, deprecated *VersionAttribute) {
+	if since == nil || deprecated == nil {
+		return
+	}
+
+	if ret, err := version.SemVerCompare(since.V(), deprecated.V()); err == nil && ret >= 0 {
+		p.Error(invalidAttributeError(&since.BaseAttribute))
+	}
+}
+
+// 检测 translations 中是否存在相同的语言
+func checkTranslations(p *xmlenc.Parser, translations []*Translation) {
+	indexes := sliceutil.Dup(translations, func(i, j *Translation) bool {
+		return strings.EqualFold(i.Lang.V(), j.Lang.V())
+	})
+	if len(indexes) > 0 {
+		err := translations[indexes[0]].Lang.Location.NewError(locale.ErrDuplicateValue).WithField("@lang")
+		for _, i := range indexes[1:] {
+			err.Relate(translations[i].Lang.Location, locale.Sprintf(locale.ErrDuplicateValue))
+		}
+		p.Error(err)
+	}
+}
+
+// 检测扩展属性和 extension 元素的名称是否合法且唯一
+func checkExtensions(p *xmlenc.Parser, attrs []*Attribute, exts []*Extension) {
+	names := make([]*Attribute, 0, len(attrs)+len(exts))
+	for _, attr := range attrs {
+		if !isExtensionName(attr.AttributeName.Local.Value) {
+			p.Error(invalidAttributeError(&attr.BaseAttribute))
+		}
+		names = append(names, &Attribute{BaseAttribute: attr.BaseAttribute, Value: attr.AttributeName.Local})
+	}
+	for _, ext := range exts {
+		names = append(names, ext.Name)
+	}
+
+	indexes := sliceutil.Dup(names, func(i, j *Attribute) bool { return i.V() == j.V() })
+	if len(indexes) > 0 {
+		err := names[indexes[0]].Location.NewError(locale.ErrDuplicateValue).WithField(names[indexes[0]].V())
+		for _, i := range indexes[1:] {
+			err.Relate(names[i].Location, locale.Sprintf(locale.ErrDuplicateValue))
+		}
+		p.Error(err)
+	}
+}
+
+// 检测回调的名称是否重复
+//
+// webhook 表示 callbacks 是否为文档中的 webhook 元素，webhook 必须指定 name 属性；
+// 而 API 中的回调需要通过 expression 或是 path 确定回调地址，两者都为空时给出警告。
+func checkCallbacks(p *xmlenc.Parser, callbacks []*Callback, webhook bool) {
+	field := "callback"
+	if webhook {
+		field = "webhook"
+	}
+
+	for _, cb := range callbacks {
+		switch {
+		case webhook && cb.Name.V() == "":
+			p.Error(cb.Location.NewError(locale.ErrIsEmpty, "@name").WithField(field))
+		case !webhook && cb.Expression.V() == "" && cb.Path == nil:
+			p.Warning(cb.Location.NewError(locale.ErrIsEmpty, "@expression").WithField(field))
+		}
+	}
+
+	indexes := sliceutil.Dup(callbacks, func(i, j *Callback) bool { return i.Name.V() == j.Name.V() })
+	if len(indexes) > 0 {
+		err := callbacks[indexes[0]].Location.NewError(locale.ErrDuplicateValue).WithField(field)
+		for _, i := range indexes[1:] {
+			err.Relate(callbacks[i].Location, locale.Sprintf(locale.ErrDuplicateValue))
+		}
+		p.Error(err)
+	}
+}
+
+// 扩展内容的名称必须以 x- 开头且不能只有 x-
+func isExtensionName(name string) bool {
+	return len(name) > len(ExtensionPrefix) && strings.HasPrefix(name, ExtensionPrefix)
+}
+
+func invalidAttributeError(attr *xmlenc.BaseAttribute) *core.Error {
+	return attr.Location.NewError(locale.ErrInvalidValue).WithField(attr.AttributeName.String())
+}
+
 // 检测 enums 中的类型是否符合 t 的标准，比如 Number 要求枚举值也都是数值
 func chkEnumsType(t *TypeAttribute, enums []*Enum, p *xmlenc.Parser) error {
 	if len(enums) == 0 {
 		return nil
 	}
 
+	if t == nil {
+		return enums[0].Location.NewError(locale.ErrIsEmpty, "type").WithField("type")
+	}
+
 	switch t.V() {
-	case TypeNumber:
-		for _, enum := range enums {
-			if !is.Number(enum.Value.V()) {
-				return enum.Location.NewError(locale.ErrInvalidFormat).WithField(enum.StartTag.String())
-			}
-		}
-	case TypeBool:
-		for _, enum := range enums {
-			if _, err := strconv.ParseBool(enum.Value.V()); err != nil {
-				return enum.Location.NewError(locale.ErrInvalidFormat).WithField(enum.StartTag.String())
-			}
-		}
 	case TypeObject, TypeNone:
 		return t.Location.NewError(locale.ErrInvalidValue).WithField(t.AttributeName.String())
 	}
 
+	for _, enum := range enums {
+		if _, ok := ParseValue(t.V(), enum.Value.V()); !ok {
+			return enum.Location.NewError(locale.ErrInvalidFormat).WithField(enum.StartTag.String())
+		}
+	}
 	return nil
 }
 
@@ -264,6 +935,27 @@ func (doc *APIDoc) Sanitize(p *xmlenc.Parser) {
 	}
 	doc.URI = p.Location.URI
 
+	checkTranslations(p, doc.Translations)
+	checkCallbacks(p, doc.Webhooks, true)
+
+	indexes := sliceutil.Dup(doc.Types, func(i, j *TypeDef) bool { return i.Name.V() == j.Name.V() })
+	if len(indexes) > 0 {
+		err := doc.Types[indexes[0]].Location.NewError(locale.ErrDuplicateValue).WithField("type")
+		for _, i := range indexes[1:] {
+			err.Relate(doc.Types[i].Location, locale.Sprintf(locale.ErrDuplicateValue))
+		}
+		p.Error(err)
+	}
+
+	indexes = sliceutil.Dup(doc.Securities, func(i, j *Security) bool { return i.Name.V() == j.Name.V() })
+	if len(indexes) > 0 {
+		err := doc.Securities[indexes[0]].Location.NewError(locale.ErrDuplicateValue).WithField("security")
+		for _, i := range indexes[1:] {
+			err.Relate(doc.Securities[i].Location, locale.Sprintf(locale.ErrDuplicateValue))
+		}
+		p.Error(err)
+	}
+
 	for _, api := range doc.APIs {
 		if api.doc == nil {
 			api.doc = doc // 保证单文件的文档能正常解析
@@ -374,10 +1066,53 @@ func (api *API) sanitizeTags(p *xmlenc.Parser) {
 			Target:   srv,
 		})
 	}
+
+	for _, sec := range api.Securities {
+		s := api.doc.Security(sec.Content.Value)
+		if s == nil {
+			p.Warning(sec.Content.Location.NewError(locale.ErrInvalidValue).AddTypes(core.ErrorTypeUnused))
+			continue
+		}
+
+		sec.definition = &Definition{
+			Location: s.Location,
+			Target:   s,
+		}
+		s.references = append(s.references, &Reference{
+			Location: sec.Location,
+			Target:   sec,
+		})
+
+		// 仅 oauth2 可以指定权限，且权限必须是在授权流程中定义过的。
+		for _, scope := range sec.Scopes() {
+			if s.Type.V() != SecurityTypeOAuth2 || !s.hasScope(scope) {
+				p.Warning(sec.Scope.Location.NewError(locale.ErrInvalidValue).WithField("scope"))
+				break
+			}
+		}
+	}
+}
+
+func (s *Security) hasScope(scope string) bool {
+	for _, f := range s.Flows {
+		for _, item := range f.Scopes {
+			if item.Name.V() == scope {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // 检测当前 api 是否与 apidoc.APIs 中存在相同的值
+//
+// 同时也会检测 api 及其回调中的返回内容是否存在重复或是重叠的状态码。
 func (api *API) checkDup(p *xmlenc.Parser) {
+	checkDupResponses(p, api.Responses)
+	for _, cb := range api.Callbacks {
+		checkDupResponses(p, cb.Responses)
+	}
+
 	err := api.Location.NewError(locale.ErrDuplicateValue)
 
 	for _, item := range api.doc.APIs {
@@ -421,3 +1156,324 @@ func (api *API) checkDup(p *xmlenc.Parser) {
 		p.Error(err)
 	}
 }
+
+// 检测返回内容中的状态码
+//
+// 状态码和 mimetype 都相同的返回内容被视为重复；
+// 具体的状态码处于同一 mimetype 的 1XX 至 5XX 范围之内时，以警告的形式指出两者重叠，
+// 此时具体的状态码优先，default 则不作检测。
+func checkDupResponses(p *xmlenc.Parser, responses []*Request) {
+	indexes := sliceutil.Dup(responses, func(i, j *Request) bool {
+		return i.Status.V() == j.Status.V() && i.Mimetype.V() == j.Mimetype.V()
+	})
+	if len(indexes) > 0 {
+		err := responses[indexes[0]].Location.NewError(locale.ErrDuplicateValue).WithField("status")
+		for _, i := range indexes[1:] {
+			err.Relate(responses[i].Location, locale.Sprintf(locale.ErrDuplicateValue))
+		}
+		p.Error(err)
+	}
+
+	for _, resp := range responses {
+		if resp.Status == nil || resp.Status.IsRange() {
+			continue
+		}
+
+		for _, r := range responses {
+			if r.Status == nil || r.Status.V() == StatusDefault || !r.Status.IsRange() ||
+				r.Mimetype.V() != resp.Mimetype.V() || !r.Status.Match(resp.Status.Code()) {
+				continue
+			}
+
+			msg := locale.Sprintf(locale.ErrStatusOverlap, resp.Status.V(), r.Status.V())
+			err := resp.Status.Location.NewError(locale.ErrStatusOverlap, resp.Status.V(), r.Status.V()).WithField("status")
+			p.Warning(err.Relate(r.Status.Location, msg))
+		}
+	}
+}
+
+// 将所有的 ref 属性与 doc.Types 中的类型定义进行关联
+//
+// 需要在所有代码块都解析完成之后调用。引用的类型会被填充到对应的参数中，
+// 参数自身已经定义的值优先于类型定义中的值。
+func (doc *APIDoc) resolveTypes(h *core.MessageHandler) {
+	if doc.Title.V() == "" { // apidoc 未初始化
+		return
+	}
+
+	for _, t := range doc.Types {
+		t.references = nil
+	}
+
+	// 按依赖关系排序，被引用的类型定义先于引用它的类型定义进行处理，
+	// 保证从类型定义中复制的子元素都是已经关联过的内容。
+	cycles := make(map[*RefAttribute]struct{})
+	done := make(map[*TypeDef]struct{}, len(doc.Types))
+	order := make([]*TypeDef, 0, len(doc.Types))
+	for _, t := range doc.Types {
+		order = doc.checkTypeCycle(h, t, nil, done, cycles, order)
+	}
+
+	for _, t := range order {
+		doc.resolveParams(h, t.Items, cycles)
+	}
+	doc.resolveParams(h, doc.Headers, cycles)
+	doc.resolveRequests(h, doc.Responses, cycles)
+
+	for _, api := range doc.APIs {
+		doc.resolveParams(h, api.Headers, cycles)
+		doc.resolveParams(h, api.Cookies, cycles)
+		if api.Path != nil {
+			doc.resolveParams(h, api.Path.Params, cycles)
+			doc.resolveParams(h, api.Path.Queries, cycles)
+		}
+		doc.resolveRequests(h, api.Requests, cycles)
+		doc.resolveRequests(h, api.Responses, cycles)
+
+		doc.resolveCallbacks(h, api.Callbacks, cycles)
+	}
+	doc.resolveCallbacks(h, doc.Webhooks, cycles)
+}
+
+// 检测返回内容中的 link 所指向的 API 及其参数是否存在
+func (doc *APIDoc) resolveLinks(h *core.MessageHandler) {
+	for _, resp := range doc.linkResponses() {
+		for _, link := range resp.Links {
+			target := doc.API(link.API.V())
+			if target == nil {
+				err := link.API.Location.NewError(locale.ErrNotFound).WithField(link.API.AttributeName.String())
+				h.Error(err.Relate(doc.Location, locale.Sprintf(locale.ErrAPINotFoundInDoc, link.API.V())))
+				continue
+			}
+
+			for _, param := range link.Params {
+				if target.Param(param.Name.V()) == nil {
+					err := param.Name.Location.NewError(locale.ErrNotFound).WithField(param.Name.AttributeName.String())
+					h.Error(err.Relate(target.Location, locale.Sprintf(locale.ErrParamNotFoundInAPI, param.Name.V())))
+				}
+			}
+		}
+	}
+}
+
+func (doc *APIDoc) resolveCallbacks(h *core.MessageHandler, callbacks []*Callback, cycles map[*RefAttribute]struct{}) {
+	for _, cb := range callbacks {
+		doc.resolveParams(h, cb.Headers, cycles)
+		if cb.Path != nil {
+			doc.resolveParams(h, cb.Path.Params, cycles)
+			doc.resolveParams(h, cb.Path.Queries, cycles)
+		}
+		doc.resolveRequests(h, cb.Requests, cycles)
+		doc.resolveRequests(h, cb.Responses, cycles)
+	}
+}
+
+// 检测类型定义 t 是否存在循环引用
+//
+// path 为当前的引用路径，done 为已经检测完成的类型定义，
+// 造成循环引用的 ref 属性会被写入 cycles。
+// 检测完成的类型定义按其依赖关系追加到 order 并返回，被依赖的类型定义位于前面。
+func (doc *APIDoc) checkTypeCycle(h *core.MessageHandler, t *TypeDef, path []*TypeDef, done map[*TypeDef]struct{}, cycles map[*RefAttribute]struct{}, order []*TypeDef) []*TypeDef {
+	if _, found := done[t]; found {
+		return order
+	}
+
+	path = append(path, t)
+	walkRefs(t.Items, func(ref *RefAttribute) {
+		target := doc.TypeDef(ref.V())
+		if target == nil {
+			return
+		}
+
+		if sliceutil.Count(path, func(i *TypeDef) bool { return i == target }) > 0 {
+			cycles[ref] = struct{}{}
+			err := ref.Location.NewError(locale.ErrCircularReference).WithField(ref.AttributeName.String())
+			h.Error(err.Relate(target.Location, locale.Sprintf(locale.ErrCircularReference)))
+			return
+		}
+
+		order = doc.checkTypeCycle(h, target, path, done, cycles, order)
+	})
+	done[t] = struct{}{}
+	return append(order, t)
+}
+
+// 遍历 params 中所有的 ref 属性
+//
+// 带 ref 属性的参数，如果其子元素是从类型定义中填充而来，则不再向下查找。
+func walkRefs(params []*Param, f func(*RefAttribute)) {
+	for _, p := range params {
+		for _, c := range p.compositions() {
+			walkRefs(c.Items, f)
+		}
+
+		if p.Ref.V() != "" {
+			f(p.Ref)
+			if p.Ref.inherited.Items {
+				continue
+			}
+		}
+		walkRefs(p.Items, f)
+	}
+}
+
+func (doc *APIDoc) resolveParams(h *core.MessageHandler, params []*Param, cycles map[*RefAttribute]struct{}) {
+	for _, p := range params {
+		for _, c := range p.compositions() {
+			doc.resolveParams(h, c.Items, cycles)
+		}
+
+		fields := &inheritFields{
+			ref: p.Ref, typ: &p.Type, deprecated: &p.Deprecated, items: &p.Items, enums: &p.Enums,
+			summary: &p.Summary, desc: &p.Description, translations: &p.Translations,
+		}
+		doc.resolveInherit(h, fields, cycles)
+	}
+}
+
+func (doc *APIDoc) resolveRequests(h *core.MessageHandler, requests []*Request, cycles map[*RefAttribute]struct{}) {
+	for _, r := range requests {
+		doc.resolveParams(h, r.Headers, cycles)
+		doc.resolveParams(h, r.Cookies, cycles)
+
+		for _, c := range r.Param().compositions() {
+			doc.resolveParams(h, c.Items, cycles)
+		}
+
+		fields := &inheritFields{
+			ref: r.Ref, typ: &r.Type, deprecated: &r.Deprecated, items: &r.Items, enums: &r.Enums,
+			summary: &r.Summary, desc: &r.Description, translations: &r.Translations,
+		}
+		doc.resolveInherit(h, fields, cycles)
+	}
+}
+
+// 可以从类型定义中继承的字段
+//
+// 分别指向 Param 或 Request 中的同名字段。
+type inheritFields struct {
+	ref          *RefAttribute
+	typ          **TypeAttribute
+	deprecated   **VersionAttribute
+	items        *[]*Param
+	enums        *[]*Enum
+	summary      **Attribute
+	desc         **Richtext
+	translations *[]*Translation
+}
+
+// 关联 fields 中 ref 引用的类型定义，并填充未指定的内容
+//
+// 之前从类型定义中继承的内容会先被清除，保证类型定义修改之后重新关联时，不会保留旧的内容。
+func (doc *APIDoc) resolveInherit(h *core.MessageHandler, fields *inheritFields, cycles map[*RefAttribute]struct{}) {
+	if fields.ref.V() == "" {
+		doc.resolveParams(h, *fields.items, cycles)
+		return
+	}
+
+	fields.reset()
+	doc.resolveParams(h, *fields.items, cycles) // 用户自己指定的子元素
+
+	if t := doc.resolveRef(h, fields.ref, cycles); t != nil {
+		fields.inherit(t)
+	}
+}
+
+// 清除之前从类型定义中继承的内容
+func (fields *inheritFields) reset() {
+	in := fields.ref.inherited
+	if in.Type {
+		*fields.typ = nil
+	}
+	if in.Deprecated {
+		*fields.deprecated = nil
+	}
+	if in.Items {
+		*fields.items = nil
+	}
+	if in.Enums {
+		*fields.enums = nil
+	}
+	if in.Summary {
+		*fields.summary = nil
+	}
+	if in.Description {
+		*fields.desc = nil
+	}
+	if in.Translations {
+		*fields.translations = nil
+	}
+	fields.ref.inherited = Inheritance{}
+}
+
+// 将类型定义 t 中的内容填充到引用它的 param 或 request 中
+//
+// 仅填充未指定的内容，所有内容都是复制而来，之后对其进行的修改不会影响到 t 或是其它引用 t 的元素。
+// 填充的属性以 ref 的位置作为其位置，错误信息等会指向引用处而不是类型定义。
+// 填充的内容会记录在 ref 中，以便与用户指定的内容进行区分。
+func (fields *inheritFields) inherit(t *TypeDef) {
+	ref := fields.ref
+	in := &ref.inherited
+
+	if *fields.typ == nil && t.Type != nil {
+		*fields.typ = deepCopy(t.Type)
+		(*fields.typ).Location = ref.Location
+		in.Type = true
+	}
+	if *fields.deprecated == nil && t.Deprecated != nil {
+		*fields.deprecated = deepCopy(t.Deprecated)
+		(*fields.deprecated).Location = ref.Location
+		in.Deprecated = true
+	}
+	if len(*fields.items) == 0 && len(t.Items) > 0 {
+		*fields.items = deepCopy(t.Items)
+		in.Items = true
+	}
+	if len(*fields.enums) == 0 && len(t.Enums) > 0 {
+		*fields.enums = deepCopy(t.Enums)
+		in.Enums = true
+	}
+
+	if (*fields.summary).V() == "" && (*fields.desc).V() == "" {
+		if t.Summary != nil {
+			*fields.summary = deepCopy(t.Summary)
+			(*fields.summary).Location = ref.Location
+			in.Summary = true
+		}
+		if t.Description != nil {
+			*fields.desc = deepCopy(t.Description)
+			in.Description = true
+		}
+		if len(*fields.translations) == 0 && len(t.Translations) > 0 {
+			*fields.translations = deepCopy(t.Translations)
+			in.Translations = true
+		}
+	}
+}
+
+// 查找 ref 引用的类型定义并建立双向的关联
+//
+// 如果类型不存在或是存在循环引用，返回 nil。
+func (doc *APIDoc) resolveRef(h *core.MessageHandler, ref *RefAttribute, cycles map[*RefAttribute]struct{}) *TypeDef {
+	ref.definition = nil
+
+	t := doc.TypeDef(ref.V())
+	if t == nil {
+		h.Error(ref.Location.NewError(locale.ErrNotFound).WithField(ref.AttributeName.String()))
+		return nil
+	}
+
+	ref.definition = &Definition{
+		Location: t.Location,
+		Target:   t,
+	}
+	t.references = append(t.references, &Reference{
+		Location: ref.Location,
+		Target:   ref,
+	})
+
+	if _, found := cycles[ref]; found {
+		return nil
+	}
+	return t
+}
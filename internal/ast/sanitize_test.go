@@ -3,7 +3,11 @@
 package ast
 
 import (
+	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/issue9/assert/v3"
@@ -17,10 +21,17 @@ import (
 var (
 	_ xmlenc.Sanitizer = &Param{}
 	_ xmlenc.Sanitizer = &Request{}
+	_ xmlenc.Sanitizer = &TypeDef{}
+	_ xmlenc.Sanitizer = &Security{}
+	_ xmlenc.Sanitizer = &OAuthFlow{}
 	_ xmlenc.Sanitizer = &APIDoc{}
 	_ xmlenc.Sanitizer = &Path{}
 	_ xmlenc.Sanitizer = &Enum{}
 	_ xmlenc.Sanitizer = &XMLNamespace{}
+	_ xmlenc.Sanitizer = &Example{}
+	_ xmlenc.Sanitizer = &Richtext{}
+
+	_ Definitioner = &SrcAttribute{}
 )
 
 func newEmptyParser(a *assert.Assertion) *xmlenc.Parser {
@@ -157,6 +168,24 @@ func TestAPI_Sanitize(t *testing.T) {
 	rslt.Handler.Stop()
 	a.NotEmpty(rslt.Errors)
 
+	// cookies
+
+	api = &API{
+		Cookies: []*Param{{Type: &TypeAttribute{Value: xmlenc.String{Value: TypeString}}}},
+	}
+	p, rslt = newParser(a, "", "")
+	api.Sanitize(p)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors).Empty(rslt.Warns)
+
+	api.Cookies = append(api.Cookies, &Param{
+		Type: &TypeAttribute{Value: xmlenc.String{Value: TypeObject}},
+	})
+	p, rslt = newParser(a, "", "")
+	api.Sanitize(p)
+	rslt.Handler.Stop()
+	a.NotEmpty(rslt.Errors)
+
 	// servers
 
 	api = &API{
@@ -202,6 +231,56 @@ func TestAPI_Sanitize(t *testing.T) {
 	a.NotEmpty(rslt.Errors)
 }
 
+func TestCheckCallbacks(t *testing.T) {
+	a := assert.New(t, false)
+
+	cb := func(name string) string {
+		return `<callback method="POST" name="` + name + `" expression="{$request.body#/url}"><request type="string" mimetype="json" /></callback>`
+	}
+	webhook := func(name string) string {
+		return `<webhook method="POST" name="` + name + `"><request type="string" mimetype="json" /></webhook>`
+	}
+	api := func(callbacks ...string) string {
+		return `<api method="GET"><path path="/users" /><response status="200" />` + strings.Join(callbacks, "") + `</api>`
+	}
+	doc := func(webhooks ...string) string {
+		return `<apidoc version="1.0.0"><title>t</title><mimetype>json</mimetype>` + strings.Join(webhooks, "") + `</apidoc>`
+	}
+
+	data := []*struct {
+		xml  string
+		v    any
+		err  bool
+		warn bool
+	}{
+		{xml: api(cb("")), v: &API{}},
+		{xml: api(cb("success"), cb("failure")), v: &API{}},
+		{xml: api(cb("success"), cb("success")), v: &API{}, err: true},
+		{xml: api(cb(""), cb("")), v: &API{}, err: true},
+		{xml: api(`<callback method="POST"><path path="/callback" /><request type="string" mimetype="json" /></callback>`), v: &API{}},
+		{xml: api(`<callback method="POST"><request type="string" mimetype="json" /></callback>`), v: &API{}, warn: true},
+		{xml: doc(webhook("created"), webhook("deleted")), v: &APIDoc{}},
+		{xml: doc(webhook("")), v: &APIDoc{}, err: true},
+		{xml: doc(webhook("created"), webhook("created")), v: &APIDoc{}, err: true},
+	}
+
+	for i, item := range data {
+		p, rslt := newParser(a, item.xml, "")
+		xmlenc.Decode(p, item.v, core.XMLNamespace)
+		rslt.Handler.Stop()
+		if item.err {
+			a.NotEmpty(rslt.Errors, "not error at %d", i)
+		} else {
+			a.Empty(rslt.Errors, "error %v at %d", rslt.Errors, i)
+		}
+		if item.warn {
+			a.NotEmpty(rslt.Warns, "not warn at %d", i)
+		} else {
+			a.Empty(rslt.Warns, "warn %v at %d", rslt.Warns, i)
+		}
+	}
+}
+
 func TestXMLnamespace_Sanitize(t *testing.T) {
 	a := assert.New(t, false)
 
@@ -222,24 +301,44 @@ func TestParsePath(t *testing.T) {
 	a := assert.New(t, false)
 
 	data := []*struct {
-		path   string
-		params map[string]struct{}
-		err    bool
+		path     string
+		template string
+		params   map[string]string
+		err      bool
 	}{
 		{},
 		{
-			path: "/path",
+			path:     "/path",
+			template: "/path",
 		},
 
 		{
-			path:   "/{path}",
-			params: map[string]struct{}{"path": {}},
+			path:     "/{path}",
+			template: "/{path}",
+			params:   map[string]string{"path": ""},
 		},
 		{
-			path: "/{path}/{p2}",
-			params: map[string]struct{}{
-				"path": {},
-				"p2":   {},
+			path:     "/{path}/{p2}",
+			template: "/{path}/{p2}",
+			params: map[string]string{
+				"path": "",
+				"p2":   "",
+			},
+		},
+		{
+			path:     `/users/{id:\d+}/{slug:[a-z-]+}`,
+			template: "/users/{id}/{slug}",
+			params: map[string]string{
+				"id":   `\d+`,
+				"slug": "[a-z-]+",
+			},
+		},
+		{
+			path:     `/{id:\d{3}}/{p:[\{\}]+}`,
+			template: "/{id}/{p}",
+			params: map[string]string{
+				"id": `\d{3}`,
+				"p":  `[\{\}]+`,
 			},
 		},
 		{
@@ -258,19 +357,73 @@ func TestParsePath(t *testing.T) {
 			path: "/{path",
 			err:  true,
 		},
+		{
+			path: "/{path:}",
+			err:  true,
+		},
+		{
+			path: `/{id:\d{3}`,
+			err:  true,
+		},
 	}
 
 	for _, item := range data {
-		p, err := parsePath(item.path)
+		template, p, err := parsePath(item.path)
 
 		if item.err {
 			a.Error(err).Nil(p)
 			continue
 		}
-		a.NotError(err).Equal(p, item.params)
+		a.NotError(err).
+			Equal(p, item.params).
+			Equal(template, item.template)
 	}
 }
 
+func TestPath_Sanitize(t *testing.T) {
+	a := assert.New(t, false)
+
+	data := []*struct {
+		xml string
+		err bool
+	}{
+		{xml: `<path path="/users/{id:\d+}"><param name="id" type="number" summary="id" /></path>`},
+		{xml: `<path path="/users/{id:\d+"><param name="id" type="number" summary="id" /></path>`, err: true},
+		{xml: `<path path="/users/{id:[a-z}"><param name="id" type="string" summary="id" /></path>`, err: true},
+		{xml: `<path path="/users/{id:\d+}"><param name="id" type="number" default="abc" summary="id" /></path>`, err: true},
+		{xml: `<path path="/users/{id:\d+}"><param name="id" type="number" default="5" summary="id" /></path>`},
+		{xml: `<path path="/users/{id:\d+}"><param name="id" type="number" summary="id"><enum value="1" summary="1" /><enum value="x" summary="x" /></param></path>`, err: true},
+		{xml: `<path path="/users/{v:[a-z]+}"><param name="v" type="bool" summary="v" /></path>`},
+		{xml: `<path path="/users/{v:\d{3}}"><param name="v" type="bool" summary="v" /></path>`, err: true},
+		{xml: `<path path="/users/{id:[a-z]+}"><param name="id" type="number" summary="id" /></path>`, err: true},
+		{xml: `<path path="/users/{id:[a-z]+}"><param name="id" type="number.int" summary="id" /></path>`, err: true},
+		{xml: `<path path="/users/{id:[a-z]*}"><param name="id" type="number.int" summary="id" /></path>`, err: true},
+		{xml: `<path path="/users/{id:v\d+}"><param name="id" type="number.int" summary="id" /></path>`, err: true},
+		{xml: `<path path="/users/{id:2\d{3}}"><param name="id" type="number.int" summary="id" /></path>`},
+		{xml: `<path path="/users/{id:-?[0-9]+}"><param name="id" type="number.int32" summary="id" /></path>`},
+		{xml: `<path path="/users/{id:\d+\.\d+}"><param name="id" type="number.float" summary="id" /></path>`},
+		{xml: `<path path="/users/{id:\d+\.\d+}"><param name="id" type="number.int" summary="id" /></path>`, err: true},
+		{xml: `<path path="/users/{id:[a-z]+}"><param name="id" type="string" summary="id" /></path>`},
+	}
+
+	for i, item := range data {
+		p, rslt := newParser(a, item.xml, "")
+		xmlenc.Decode(p, &Path{}, core.XMLNamespace)
+		rslt.Handler.Stop()
+		if item.err {
+			a.NotEmpty(rslt.Errors, "not error at %d", i)
+		} else {
+			a.Empty(rslt.Errors, "error %v at %d", rslt.Errors, i)
+		}
+	}
+
+	path := &Path{Path: &Attribute{Value: xmlenc.String{Value: `/users/{id:\d+}/{name}`}}}
+	a.Equal(path.Template(), "/users/{id}/{name}").
+		Equal(path.Pattern("id"), `\d+`).
+		Equal(path.Pattern("name"), "").
+		Equal(path.Pattern("not-exists"), "")
+}
+
 func TestChkEnumsType(t *testing.T) {
 	a := assert.New(t, false)
 
@@ -318,6 +471,20 @@ func TestChkEnumsType(t *testing.T) {
 			},
 			err: true,
 		},
+		{
+			t: &TypeAttribute{Value: xmlenc.String{Value: TypeDate}},
+			enums: []*Enum{
+				{Value: &Attribute{Value: xmlenc.String{Value: "2020-01-02"}}},
+				{Value: &Attribute{Value: xmlenc.String{Value: "2020-01-32"}}},
+			},
+			err: true,
+		},
+		{
+			t: &TypeAttribute{Value: xmlenc.String{Value: TypeEmail}},
+			enums: []*Enum{
+				{Value: &Attribute{Value: xmlenc.String{Value: "user@example.com"}}},
+			},
+		},
 		{ // object 是不允许的
 			t: &TypeAttribute{Value: xmlenc.String{Value: TypeObject}},
 			enums: []*Enum{
@@ -388,6 +555,46 @@ func TestAPI_sanitizeTags(t *testing.T) {
 	a.Empty(rslt.Warns)
 }
 
+func TestCheckDupResponses(t *testing.T) {
+	a := assert.New(t, false)
+
+	parse := func(responses string) *messagetest.Result {
+		return parseDocuments(&Documents{},
+			newDocumentsBlock("doc.go", `<apidoc version="1.0.0"><title>t</title><mimetype>json</mimetype></apidoc>`),
+			newDocumentsBlock("api.go", `<api method="GET"><path path="/users" />`+responses+`</api>`),
+		)
+	}
+
+	rslt := parse(`<response status="200" /><response status="4XX" /><response status="5xx" /><response status="default" />`)
+	a.Empty(rslt.Errors).Empty(rslt.Warns)
+
+	// 不同的 mimetype
+	rslt = parse(`<response status="5XX" mimetype="json" /><response status="5XX" mimetype="xml" />`)
+	a.Empty(rslt.Errors).Empty(rslt.Warns)
+
+	rslt = parse(`<response status="5XX" /><response status="5xx" />`)
+	a.Length(rslt.Errors, 1)
+
+	rslt = parse(`<response status="default" /><response status="DEFAULT" />`)
+	a.Length(rslt.Errors, 1)
+
+	rslt = parse(`<response status="200" /><response status="0200" />`)
+	a.Length(rslt.Errors, 1)
+
+	// 具体状态码与范围重叠
+	rslt = parse(`<response status="500" /><response status="5XX" /><response status="default" />`)
+	a.Empty(rslt.Errors).Length(rslt.Warns, 1)
+	err, ok := rslt.Warns[0].(*core.Error)
+	a.True(ok).Length(err.Related, 1)
+
+	rslt = parse(`<response status="500" mimetype="json" /><response status="5XX" mimetype="xml" />`)
+	a.Empty(rslt.Errors).Empty(rslt.Warns)
+
+	// 回调中的返回内容
+	rslt = parse(`<response status="200" /><callback method="POST"><request type="string" mimetype="json" /><response status="2XX" /><response status="2XX" /></callback>`)
+	a.Length(rslt.Errors, 1)
+}
+
 func TestAPI_checkDup(t *testing.T) {
 	a := assert.New(t, false)
 
@@ -531,3 +738,603 @@ func TestAPI_checkDup(t *testing.T) {
 	err, ok = rslt.Errors[0].(*core.Error)
 	a.True(ok).Equal(1, len(err.Related))
 }
+
+func TestAPIDoc_resolveTypes(t *testing.T) {
+	a := assert.New(t, false)
+
+	const doc = `<apidoc version="1.1.1">
+	<title>title</title>
+	<mimetype>application/json</mimetype>
+	<type name="user" type="object" summary="user">
+		<param name="id" type="number" summary="id" />
+		<param name="group" ref="group" />
+	</type>
+	<type name="group" type="object" summary="group">
+		<param name="name" type="string" summary="name" />
+	</type>
+</apidoc>`
+
+	rslt := messagetest.NewMessageHandler()
+	d := &APIDoc{}
+	d.ParseBlocks(rslt.Handler, func(blocks chan core.Block) {
+		blocks <- core.Block{Data: []byte(`<api method="GET">
+		<path path="/users" />
+		<response status="200" type="object" mimetype="application/json">
+			<param name="user" ref="user" array="true" summary="users" />
+		</response>
+	</api>`)}
+		blocks <- core.Block{Data: []byte(doc)}
+	})
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors)
+
+	user := d.APIs[0].Responses[0].Items[0]
+	a.Equal(user.Type.V(), TypeObject).
+		Equal(2, len(user.Items)).
+		Equal(user.Summary.V(), "users").
+		NotNil(user.Ref.Definition()).
+		Equal(user.Ref.Definition().Target, d.Types[0])
+	group := user.Items[1]
+	a.Equal(group.Type.V(), TypeObject).
+		Equal(group.Summary.V(), "group").
+		Equal(1, len(group.Items))
+	a.Equal(1, len(d.Types[0].References())).
+		Equal(1, len(d.Types[1].References()))
+
+	// 填充的内容是复制而来的，位置指向 ref 属性。
+	a.True(user.Type != d.Types[0].Type).
+		True(user.Items[0] != d.Types[0].Items[0]).
+		Equal(user.Items[0], d.Types[0].Items[0]).
+		Equal(user.Type.Location, user.Ref.Location).
+		Equal(group.Summary.Location, group.Ref.Location)
+	user.Items[0].Summary.Value.Value = "changed"
+	a.Equal(d.Types[0].Items[0].Summary.V(), "id")
+
+	// 组合类型中的引用
+	rslt = messagetest.NewMessageHandler()
+	d = &APIDoc{}
+	d.ParseBlocks(rslt.Handler, func(blocks chan core.Block) {
+		blocks <- core.Block{Data: []byte(`<api method="GET">
+		<path path="/users" />
+		<response status="200" mimetype="application/json">
+			<one-of discriminator="name">
+				<param name="user" ref="user" />
+				<param name="group" ref="group" />
+			</one-of>
+		</response>
+	</api>`)}
+		blocks <- core.Block{Data: []byte(doc)}
+	})
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors)
+	branches := d.APIs[0].Responses[0].OneOf.Items
+	a.Equal(2, len(branches[0].Items)).
+		Equal(1, len(branches[1].Items)).
+		Equal(branches[1].Ref.Definition().Target, d.Types[1])
+
+	// 未定义的类型
+	rslt = messagetest.NewMessageHandler()
+	d = &APIDoc{}
+	d.ParseBlocks(rslt.Handler, func(blocks chan core.Block) {
+		blocks <- core.Block{Data: []byte(doc)}
+		blocks <- core.Block{Data: []byte(`<api method="GET">
+		<path path="/users" />
+		<response status="200" ref="not-exists" mimetype="application/json" />
+	</api>`)}
+	})
+	rslt.Handler.Stop()
+	a.Equal(1, len(rslt.Errors))
+	a.Nil(d.APIs[0].Responses[0].Ref.Definition()).
+		Nil(d.APIs[0].Responses[0].Type)
+
+	// 循环引用
+	rslt = messagetest.NewMessageHandler()
+	d = &APIDoc{}
+	d.ParseBlocks(rslt.Handler, func(blocks chan core.Block) {
+		blocks <- core.Block{Data: []byte(`<apidoc version="1.1.1">
+	<title>title</title>
+	<mimetype>application/json</mimetype>
+	<type name="t1" type="object">
+		<param name="t2" ref="t2" />
+	</type>
+	<type name="t2" type="object">
+		<param name="t1" ref="t1" />
+	</type>
+	<type name="t3" type="object">
+		<param name="t3" ref="t3" />
+	</type>
+</apidoc>`)}
+	})
+	rslt.Handler.Stop()
+	a.Equal(2, len(rslt.Errors))
+	a.Nil(d.Types[1].Items[0].Type).
+		Nil(d.Types[2].Items[0].Type).
+		NotNil(d.Types[1].Items[0].Ref.Definition())
+
+	// 多层引用，被引用的类型定义在后面声明
+	rslt = messagetest.NewMessageHandler()
+	d = &APIDoc{}
+	d.ParseBlocks(rslt.Handler, func(blocks chan core.Block) {
+		blocks <- core.Block{Data: []byte(`<apidoc version="1.1.1">
+	<title>title</title>
+	<mimetype>application/json</mimetype>
+	<type name="a" type="object">
+		<param name="b" ref="b" />
+	</type>
+	<type name="b" type="object">
+		<param name="c" ref="c" />
+	</type>
+	<type name="c" type="object">
+		<param name="name" type="string" summary="name" />
+	</type>
+</apidoc>`)}
+		blocks <- core.Block{Data: []byte(`<api method="GET">
+		<path path="/users" />
+		<response status="200" ref="a" mimetype="application/json" />
+	</api>`)}
+	})
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors)
+	c := d.Types[0].Items[0].Items[0]
+	a.Equal(c.Type.V(), TypeObject).
+		Equal(1, len(c.Items)).
+		Equal(c.Items[0].Name.V(), "name")
+	resp := d.APIs[0].Responses[0]
+	a.Equal(resp.Type.V(), TypeObject).
+		Equal(1, len(resp.Items)).
+		Equal(1, len(resp.Items[0].Items)).
+		Equal(resp.Items[0].Items[0].Type.V(), TypeObject).
+		Equal(1, len(resp.Items[0].Items[0].Items))
+
+	// 多层引用中不存在的类型
+	rslt = messagetest.NewMessageHandler()
+	d = &APIDoc{}
+	d.ParseBlocks(rslt.Handler, func(blocks chan core.Block) {
+		blocks <- core.Block{Data: []byte(`<apidoc version="1.1.1">
+	<title>title</title>
+	<mimetype>application/json</mimetype>
+	<type name="a" type="object">
+		<param name="b" ref="b" />
+	</type>
+	<type name="b" type="object">
+		<param name="c" ref="not-exists" />
+	</type>
+</apidoc>`)}
+	})
+	rslt.Handler.Stop()
+	a.Equal(1, len(rslt.Errors))
+
+	// 重复的类型名称
+	rslt = messagetest.NewMessageHandler()
+	d = &APIDoc{}
+	d.ParseBlocks(rslt.Handler, func(blocks chan core.Block) {
+		blocks <- core.Block{Data: []byte(`<apidoc version="1.1.1">
+	<title>title</title>
+	<mimetype>application/json</mimetype>
+	<type name="t1" type="string" />
+	<type name="t1" type="number" />
+</apidoc>`)}
+	})
+	rslt.Handler.Stop()
+	a.Equal(1, len(rslt.Errors))
+}
+
+func TestAPIDoc_resolveLinks(t *testing.T) {
+	a := assert.New(t, false)
+
+	parse := func(link string) (*APIDoc, *messagetest.Result) {
+		docs := &Documents{}
+		rslt := parseDocuments(docs,
+			newDocumentsBlock("doc.go", `<apidoc version="1.0.0"><title>t</title><mimetype>json</mimetype></apidoc>`),
+			newDocumentsBlock("get.go", `<api method="GET" id="get-order">
+	<path path="/orders/{id}"><param name="id" type="number" summary="id" /></path>
+	<header name="x-version" type="string" summary="version" />
+	<response status="200" />
+</api>`),
+			newDocumentsBlock("post.go", `<api method="POST" id="post-order">
+	<path path="/orders" />
+	<response status="201" type="object" mimetype="json">
+		<param name="id" type="number" summary="id" />
+		`+link+`
+	</response>
+</api>`),
+		)
+		return docs.Docs[0], rslt
+	}
+
+	doc, rslt := parse(`<link name="get" api="get-order"><param name="id" field="id" /><param name="x-version" field="id" /></link>`)
+	a.Empty(rslt.Errors).
+		Length(doc.API("post-order").Responses[0].Links, 1)
+
+	_, rslt = parse(`<link name="get" api="not-exists"><param name="id" field="id" /></link>`)
+	a.Length(rslt.Errors, 1)
+	err, ok := rslt.Errors[0].(*core.Error)
+	a.True(ok).Length(err.Related, 1).Equal(err.Related[0].Location.URI, "doc.go")
+
+	_, rslt = parse(`<link name="get" api="get-order"><param name="not-exists" field="id" /></link>`)
+	a.Length(rslt.Errors, 1)
+	err, ok = rslt.Errors[0].(*core.Error)
+	a.True(ok).Length(err.Related, 1).Equal(err.Related[0].Location.URI, "get.go")
+
+	// 重复的名称
+	_, rslt = parse(`<link name="get" api="get-order" /><link name="get" api="get-order" />`)
+	a.NotEmpty(rslt.Errors)
+
+	// 仅对返回内容有效
+	p, rslt := newParser(a, `<api method="POST"><path path="/orders" /><request type="string" mimetype="json"><link name="get" api="get-order" /></request><response status="200" /></api>`, "")
+	xmlenc.Decode(p, &API{}, core.XMLNamespace)
+	rslt.Handler.Stop()
+	a.NotEmpty(rslt.Errors)
+
+	p, rslt = newParser(a, `<callback method="POST" expression="{$request.body#/url}"><request type="string" mimetype="json"><link name="get" api="get-order" /></request></callback>`, "")
+	xmlenc.Decode(p, &Callback{}, core.XMLNamespace)
+	rslt.Handler.Stop()
+	a.NotEmpty(rslt.Errors)
+
+	// 回调和 webhook 中的返回内容
+	docs := &Documents{}
+	rslt = parseDocuments(docs,
+		newDocumentsBlock("doc.go", `<apidoc version="1.0.0"><title>t</title><mimetype>json</mimetype>
+	<webhook method="POST" name="created">
+		<request type="string" mimetype="json" />
+		<response status="200"><link name="get" api="not-exists" /></response>
+	</webhook>
+</apidoc>`),
+		newDocumentsBlock("get.go", `<api method="GET" id="get-order">
+	<path path="/orders/{id}"><param name="id" type="number" summary="id" /></path>
+	<response status="200" />
+	<callback method="POST" expression="{$request.body#/url}">
+		<request type="string" mimetype="json" />
+		<response status="200"><link name="get" api="get-order"><param name="not-exists" field="id" /></link></response>
+	</callback>
+</api>`),
+	)
+	a.Length(rslt.Errors, 2)
+}
+
+func TestSecurity_Sanitize(t *testing.T) {
+	a := assert.New(t, false)
+
+	data := []*struct {
+		xml string
+		err bool
+	}{
+		{xml: `<security name="s1" type="http" scheme="bearer" bearer-format="JWT" summary="s1" />`},
+		{xml: `<security name="s1" type="http" />`, err: true},
+		{xml: `<security name="s1" type="apikey" in="header" key="token" />`},
+		{xml: `<security name="s1" type="apikey" in="body" key="token" />`, err: true},
+		{xml: `<security name="s1" type="apikey" in="cookie" />`, err: true},
+		{xml: `<security name="s1" type="oauth2" />`, err: true},
+		{xml: `<security name="s1" type="not-exists" />`, err: true},
+		{xml: `<security name="s1" type="oauth2">
+			<flow type="implicit" authorization-url="https://example.com/auth">
+				<scope name="read" summary="read" />
+				<scope name="write" summary="write" />
+			</flow>
+			<flow type="password" token-url="https://example.com/token" />
+		</security>`},
+		{xml: `<security name="s1" type="oauth2">
+			<flow type="implicit" authorization-url="https://example.com/auth" />
+			<flow type="implicit" authorization-url="https://example.com/auth" />
+		</security>`, err: true},
+		{xml: `<security name="s1" type="oauth2">
+			<flow type="authorization-code" authorization-url="https://example.com/auth" />
+		</security>`, err: true},
+		{xml: `<security name="s1" type="oauth2">
+			<flow type="client-credentials" token-url="https://example.com/token">
+				<scope name="read" summary="read" />
+				<scope name="read" summary="read" />
+			</flow>
+		</security>`, err: true},
+		{xml: `<security name="s1" type="http" scheme="basic">
+			<flow type="client-credentials" token-url="https://example.com/token" />
+		</security>`, err: true},
+	}
+
+	for i, item := range data {
+		p, rslt := newParser(a, item.xml, "")
+		s := &Security{}
+		xmlenc.Decode(p, s, core.XMLNamespace)
+		rslt.Handler.Stop()
+		if item.err {
+			a.NotEmpty(rslt.Errors, "not error at %d", i)
+		} else {
+			a.Empty(rslt.Errors, "error %v at %d", rslt.Errors, i)
+		}
+	}
+}
+
+func TestServer_Sanitize(t *testing.T) {
+	a := assert.New(t, false)
+
+	data := []*struct {
+		xml string
+		err bool
+	}{
+		{xml: `<server name="s1" url="https://example.com" summary="s1" />`},
+		{xml: `<server name="s1" url="https://{region}.example.com/{base}" summary="s1">
+			<variable name="region" default="cn" />
+			<variable name="base" default="v1" summary="base">
+				<enum value="v1" summary="v1" />
+				<enum value="v2" summary="v2" />
+			</variable>
+		</server>`},
+		{xml: `<server name="s1" url="https://{region.example.com" summary="s1" />`, err: true},
+		{xml: `<server name="s1" url="https://{region}.example.com" summary="s1" />`, err: true}, // 未声明的变量
+		{xml: `<server name="s1" url="https://example.com" summary="s1">
+			<variable name="region" default="cn" />
+		</server>`, err: true}, // 变量未在地址中使用
+		{xml: `<server name="s1" url="https://{region}.example.com" summary="s1">
+			<variable name="region" default="cn" />
+			<variable name="region" default="us" />
+		</server>`, err: true},
+		{xml: `<server name="s1" url="https://{region}.example.com" summary="s1">
+			<variable name="region" default="cn">
+				<enum value="us" summary="us" />
+			</variable>
+		</server>`, err: true}, // 默认值不在枚举中
+	}
+
+	for i, item := range data {
+		p, rslt := newParser(a, item.xml, "")
+		srv := &Server{}
+		xmlenc.Decode(p, srv, core.XMLNamespace)
+		rslt.Handler.Stop()
+		if item.err {
+			a.NotEmpty(rslt.Errors, "not error at %d", i)
+		} else {
+			a.Empty(rslt.Errors, "error %v at %d", rslt.Errors, i)
+		}
+	}
+}
+
+func TestExtension_Sanitize(t *testing.T) {
+	a := assert.New(t, false)
+
+	data := []*struct {
+		xml string
+		err bool
+	}{
+		{xml: `<tag name="t1" title="t1" x-team="api" x-tier="1" />`},
+		{xml: `<tag name="t1" title="t1" x-team="api"><extension name="x-rate"><![CDATA[{"limit":100}]]></extension></tag>`},
+		{xml: `<tag name="t1" title="t1" x-="api" />`, err: true},
+		{xml: `<tag name="t1" title="t1"><extension name="rate"><![CDATA[1]]></extension></tag>`, err: true},
+		{xml: `<tag name="t1" title="t1"><extension name="x-rate"><![CDATA[{limit:100}]]></extension></tag>`, err: true},
+		{xml: `<tag name="t1" title="t1" x-rate="1"><extension name="x-rate"><![CDATA[1]]></extension></tag>`, err: true},
+		{xml: `<tag name="t1" title="t1"><extension name="x-rate"><![CDATA[1]]></extension><extension name="x-rate"><![CDATA[2]]></extension></tag>`, err: true},
+	}
+
+	for i, item := range data {
+		p, rslt := newParser(a, item.xml, "")
+		tag := &Tag{}
+		xmlenc.Decode(p, tag, core.XMLNamespace)
+		rslt.Handler.Stop()
+		if item.err {
+			a.NotEmpty(rslt.Errors, "not error at %d", i)
+		} else {
+			a.Empty(rslt.Errors, "error %v at %d", rslt.Errors, i)
+		}
+	}
+
+	// 编码之后保留扩展内容
+	p, rslt := newParser(a, `<api method="GET" x-team="api"><path path="/users" /><extension name="x-rate"><![CDATA[{"limit":100}]]></extension></api>`, "")
+	api := &API{}
+	xmlenc.Decode(p, api, core.XMLNamespace)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors).
+		Length(api.XAttributes, 1).
+		Equal(api.XAttributes[0].V(), "api").
+		Length(api.Extensions, 1).
+		Equal(api.Extensions[0].V(), `{"limit":100}`)
+
+	data2, err := xmlenc.Encode("", api, "", "")
+	a.NotError(err).
+		Contains(string(data2), `x-team="api"`).
+		Contains(string(data2), `<extension name="x-rate"><![CDATA[{"limit":100}]]></extension>`)
+}
+
+func TestExample_Sanitize(t *testing.T) {
+	a := assert.New(t, false)
+
+	dir := t.TempDir()
+	a.NotError(os.WriteFile(filepath.Join(dir, "example.json"), []byte(`{"id":1}`), os.ModePerm))
+	a.NotError(os.WriteFile(filepath.Join(dir, "desc.md"), []byte("# title"), os.ModePerm))
+	uri := core.FileURI(filepath.Join(dir, "doc.go"))
+
+	p, rslt := newParser(a, `<example mimetype="json" src="example.json" />`, uri)
+	ex := &Example{}
+	xmlenc.Decode(p, ex, core.XMLNamespace)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors).
+		Equal(ex.Content.Value.Value, `{"id":1}`).
+		Equal(ex.Content.Location.URI, core.FileURI(filepath.Join(dir, "example.json")))
+	a.NotNil(ex.Src.Definition()).
+		Equal(ex.Src.Definition().Location, ex.Content.Location)
+
+	// 已有内容，忽略 src
+	p, rslt = newParser(a, `<example mimetype="json" src="not-exists.json"><![CDATA[{}]]></example>`, uri)
+	ex = &Example{}
+	xmlenc.Decode(p, ex, core.XMLNamespace)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors).Equal(ex.Content.Value.Value, `{}`).Nil(ex.Src.Definition())
+
+	p, rslt = newParser(a, `<example mimetype="json" src="not-exists.json" />`, uri)
+	xmlenc.Decode(p, &Example{}, core.XMLNamespace)
+	rslt.Handler.Stop()
+	a.Length(rslt.Errors, 1)
+
+	p, rslt = newParser(a, `<example mimetype="json" />`, uri)
+	xmlenc.Decode(p, &Example{}, core.XMLNamespace)
+	rslt.Handler.Stop()
+	a.Length(rslt.Errors, 1)
+
+	// Richtext
+	p, rslt = newParser(a, `<description type="markdown" src="desc.md" />`, uri)
+	r := &Richtext{}
+	xmlenc.Decode(p, r, core.XMLNamespace)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors).Equal(r.V(), "# title")
+
+	p, rslt = newParser(a, `<description type="markdown" />`, uri)
+	xmlenc.Decode(p, &Richtext{}, core.XMLNamespace)
+	rslt.Handler.Stop()
+	a.Length(rslt.Errors, 1)
+}
+
+func TestComposition_Sanitize(t *testing.T) {
+	a := assert.New(t, false)
+
+	data := []*struct {
+		xml string
+		err bool
+	}{
+		{xml: `<param name="event" summary="event">
+			<one-of discriminator="kind">
+				<param name="card" type="object" summary="card">
+					<param name="kind" type="string" summary="kind" />
+					<param name="number" type="string" summary="number" />
+				</param>
+				<param name="bank" type="object" summary="bank">
+					<param name="kind" type="string" summary="kind" />
+				</param>
+			</one-of>
+		</param>`},
+		{xml: `<param name="event" type="object" summary="event">
+			<any-of>
+				<param name="s" type="string" summary="s" />
+				<param name="n" type="number" summary="n" />
+			</any-of>
+		</param>`},
+		{xml: `<param name="event" summary="event"><all-of /></param>`, err: true},
+		{xml: `<param name="event" summary="event">
+			<one-of>
+				<param name="s" type="string" summary="s" />
+				<param name="s" type="number" summary="n" />
+			</one-of>
+		</param>`, err: true}, // 分支名称重复
+		{xml: `<param name="event" summary="event">
+			<one-of discriminator="kind">
+				<param name="card" type="object" summary="card">
+					<param name="number" type="string" summary="number" />
+				</param>
+			</one-of>
+		</param>`, err: true}, // 缺少 discriminator 指定的属性
+		{xml: `<param name="event" summary="event">
+			<one-of discriminator="kind">
+				<param name="card" type="object" summary="card">
+					<param name="kind" type="string" optional="true" summary="kind" />
+				</param>
+			</one-of>
+		</param>`, err: true}, // discriminator 指定的属性为可选项
+	}
+
+	for i, item := range data {
+		p, rslt := newParser(a, item.xml, "")
+		param := &Param{}
+		xmlenc.Decode(p, param, core.XMLNamespace)
+		rslt.Handler.Stop()
+		if item.err {
+			a.NotEmpty(rslt.Errors, "not error at %d", i)
+		} else {
+			a.Empty(rslt.Errors, "error %v at %d", rslt.Errors, i)
+		}
+	}
+}
+
+func TestAPI_sanitizeSecurities(t *testing.T) {
+	a := assert.New(t, false)
+
+	const doc = `<apidoc version="1.1.1">
+	<title>title</title>
+	<mimetype>application/json</mimetype>
+	<security name="bearer" type="http" scheme="bearer" />
+	<security name="oauth" type="oauth2">
+		<flow type="password" token-url="https://example.com/token">
+			<scope name="read" summary="read" />
+		</flow>
+	</security>
+	<api method="GET">
+		<path path="/users" />
+		<response status="200" />
+		%s
+	</api>
+</apidoc>`
+
+	data := []*struct {
+		securities string
+		warn       bool
+		err        bool
+	}{
+		{securities: `<security>bearer</security>`},
+		{securities: `<security>bearer</security><security scope="read">oauth</security>`},
+		{securities: `<security>not-exists</security>`, warn: true},
+		{securities: `<security scope="read">bearer</security>`, warn: true},
+		{securities: `<security scope="read write">oauth</security>`, warn: true},
+		{securities: `<security>bearer</security><security>bearer</security>`, err: true},
+	}
+
+	for i, item := range data {
+		p, rslt := newParser(a, fmt.Sprintf(doc, item.securities), "")
+		d := &APIDoc{}
+		xmlenc.Decode(p, d, core.XMLNamespace)
+		rslt.Handler.Stop()
+
+		a.Equal(item.warn, len(rslt.Warns) > 0, "warns %v at %d", rslt.Warns, i)
+		a.Equal(item.err, len(rslt.Errors) > 0, "errors %v at %d", rslt.Errors, i)
+	}
+
+	p, rslt := newParser(a, fmt.Sprintf(doc, `<security>bearer</security>`), "")
+	d := &APIDoc{}
+	xmlenc.Decode(p, d, core.XMLNamespace)
+	rslt.Handler.Stop()
+	sec := d.APIs[0].Securities[0]
+	a.Equal(sec.Definition().Target, d.Securities[0]).
+		Equal(1, len(d.Securities[0].References())).
+		Empty(d.Securities[1].References())
+}
+
+func TestParam_checkConstraints(t *testing.T) {
+	a := assert.New(t, false)
+
+	data := []*struct {
+		xml string
+		err bool
+	}{
+		{xml: `<param name="p1" type="number" min="1" max="10" summary="s" />`},
+		{xml: `<param name="p1" type="number.int" min="1" max="1" summary="s" />`},
+		{xml: `<param name="p1" type="number.float" min="-1.5" exclusive-min="true" summary="s" />`},
+		{xml: `<param name="p1" type="number" min="10" max="1" summary="s" />`, err: true},
+		{xml: `<param name="p1" type="number" min="1" max="1" exclusive-max="true" summary="s" />`, err: true},
+		{xml: `<param name="p1" type="number" exclusive-max="true" summary="s" />`, err: true},
+		{xml: `<param name="p1" type="string" min="1" summary="s" />`, err: true},
+		{xml: `<param name="p1" type="number.int" min="1.2" max="1.8" summary="s" />`, err: true},
+		{xml: `<param name="p1" type="number.int32" min="1" max="2" exclusive-min="true" exclusive-max="true" summary="s" />`, err: true},
+		{xml: `<param name="p1" type="number.int64" min="0.5" max="1.5" summary="s" />`},
+		{xml: `<param name="p1" type="number.float" min="1.2" max="1.8" summary="s" />`},
+
+		{xml: `<param name="p1" type="string" min-length="1" max-length="5" pattern="^[a-z]+$" summary="s" />`},
+		{xml: `<param name="p1" type="string.email" max-length="50" summary="s" />`},
+		{xml: `<param name="p1" type="string" min-length="5" max-length="1" summary="s" />`, err: true},
+		{xml: `<param name="p1" type="string" min-length="-1" summary="s" />`, err: true},
+		{xml: `<param name="p1" type="string" max-length="1.5" summary="s" />`, err: true},
+		{xml: `<param name="p1" type="string" pattern="[a-z" summary="s" />`, err: true},
+		{xml: `<param name="p1" type="number" pattern="[a-z]" summary="s" />`, err: true},
+
+		{xml: `<param name="p1" type="string" array="true" min-items="1" max-items="5" unique-items="true" summary="s" />`},
+		{xml: `<param name="p1" type="string" array="true" min-items="5" max-items="1" summary="s" />`, err: true},
+		{xml: `<param name="p1" type="string" min-items="1" summary="s" />`, err: true},
+		{xml: `<param name="p1" type="string" unique-items="true" summary="s" />`, err: true},
+	}
+
+	for i, item := range data {
+		p, rslt := newParser(a, item.xml, "")
+		param := &Param{}
+		xmlenc.Decode(p, param, core.XMLNamespace)
+		rslt.Handler.Stop()
+		if item.err {
+			a.NotEmpty(rslt.Errors, "not error at %d", i)
+		} else {
+			a.Empty(rslt.Errors, "error %v at %d", rslt.Errors, i)
+		}
+	}
+}
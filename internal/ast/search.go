@@ -52,8 +52,11 @@ func search(v reflect.Value, uri core.URI, pos core.Position, t reflect.Type) (r
 		return nil
 	}
 
+	// 倒序查找子元素：由 ref 从类型定义中填充的内容与 ref 拥有相同的位置，
+	// 且 ref 总是位于这些字段之后；APIDoc.Types 也位于 APIDoc.APIs 之后，
+	// 倒序可以保证优先返回 ref 和类型定义本身，而不是复制而来的内容。
 	if v.Kind() == reflect.Struct {
-		for vt, i := v.Type(), 0; i < vt.NumField(); i++ {
+		for vt, i := v.Type(), v.NumField()-1; i >= 0; i-- {
 			ft := vt.Field(i)
 			if ft.Anonymous || unicode.IsLower(rune(ft.Name[0])) {
 				continue
@@ -112,4 +112,34 @@ func TestSearch(t *testing.T) {
 	a.NotNil(r).Equal(r.Loc(), core.Location{URI: "doc.go", Range: core.Range{
 		End: core.Position{Line: 15, Character: 10},
 	}})
+
+	// 由 ref 填充的内容，优先返回 ref 以及类型定义本身。
+	rslt = messagetest.NewMessageHandler()
+	doc = &APIDoc{}
+	doc.ParseBlocks(rslt.Handler, func(blocks chan core.Block) {
+		blocks <- core.Block{Data: []byte(`<apidoc version="1.0.0">
+		<title>title</title>
+		<mimetype>application/json</mimetype>
+		<type name="user" type="object">
+			<param name="id" type="number" summary="id" />
+		</type>
+		<api method="GET">
+			<path path="/users" />
+			<response status="200" ref="user" mimetype="application/json" />
+		</api>
+	</apidoc>`), Location: core.Location{URI: "doc.go"}}
+	})
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors)
+	resp := doc.APIs[0].Responses[0]
+	a.Equal(resp.Type.V(), TypeObject)
+
+	r = doc.Search("doc.go", resp.Ref.Location.Range.Start, nil)
+	a.NotNil(r).Equal(r.Loc(), resp.Ref.Location)
+	_, ok := r.(RefAttribute)
+	a.True(ok)
+
+	// 类型定义中的 param 与 response 中复制而来的 param 位置相同
+	r = doc.Search("doc.go", doc.Types[0].Items[0].Location.Range.Start, nil)
+	a.NotNil(r).True(r.(Param).Summary == doc.Types[0].Items[0].Summary)
 }
@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: MIT
+
+package ast
+
+import (
+	"reflect"
+
+	"golang.org/x/text/language"
+)
+
+// Translate 将文档转换成仅包含 lang 语言的内容
+//
+// 所有包含 translation 子元素的对象，如果其中有与 lang 相匹配的翻译内容，
+// 则以翻译内容替换 title、summary 和 description 的值，否则保留原来的值。
+// 转换之后所有的 translation 都会被删除。如果至少有一处内容被翻译，
+// APIDoc.Lang 会被设置为 lang，否则内容依然是文档的默认语言，APIDoc.Lang 保持不变。
+func (doc *APIDoc) Translate(lang language.Tag) {
+	def := language.Und
+	if doc.Lang.V() != "" {
+		if tag, err := language.Parse(doc.Lang.V()); err == nil {
+			def = tag
+		}
+	}
+
+	translationsType := reflect.TypeOf([]*Translation{})
+	visited := make(map[uintptr]struct{}, 100)
+	applied := false
+
+	var walk func(reflect.Value)
+	walk = func(v reflect.Value) {
+		switch v.Kind() {
+		case reflect.Pointer:
+			if v.IsNil() {
+				return
+			}
+			if _, found := visited[v.Pointer()]; found {
+				return
+			}
+			visited[v.Pointer()] = struct{}{}
+			walk(v.Elem())
+		case reflect.Slice:
+			for i := 0; i < v.Len(); i++ {
+				walk(v.Index(i))
+			}
+		case reflect.Struct:
+			if f := v.FieldByName("Translations"); f.IsValid() && f.Type() == translationsType {
+				if t := matchTranslation(lang, def, f.Interface().([]*Translation)); t != nil {
+					t.apply(v)
+					applied = true
+				}
+				f.Set(reflect.Zero(translationsType))
+			}
+
+			t := v.Type()
+			for i := 0; i < t.NumField(); i++ {
+				if t.Field(i).IsExported() {
+					walk(v.Field(i))
+				}
+			}
+		}
+	}
+	walk(reflect.ValueOf(doc))
+
+	if !applied {
+		return
+	}
+	if doc.Lang == nil {
+		doc.Lang = &Attribute{}
+	}
+	doc.Lang.Value.Value = lang.String()
+}
+
+// 从 translations 中查找与 lang 匹配的翻译内容
+//
+// 如果 def 比所有的翻译内容都更匹配 lang，或是都无法匹配，则返回 nil。
+func matchTranslation(lang, def language.Tag, translations []*Translation) *Translation {
+	if len(translations) == 0 {
+		return nil
+	}
+
+	tags := make([]language.Tag, 0, len(translations)+1)
+	tags = append(tags, def)
+	for _, t := range translations {
+		tag, err := language.Parse(t.Lang.V())
+		if err != nil {
+			tag = language.Und
+		}
+		tags = append(tags, tag)
+	}
+
+	_, index, confidence := language.NewMatcher(tags).Match(lang)
+	if index == 0 || confidence < language.High {
+		return nil
+	}
+	return translations[index-1]
+}
+
+// 将翻译内容写入 v 对应的字段
+//
+// v 为包含 Translations 字段的元素，比如 API、Param 等。
+func (t *Translation) apply(v reflect.Value) {
+	if title := v.FieldByName("Title"); t.Title != nil && title.IsValid() {
+		switch elem := title.Interface().(type) {
+		case *Attribute:
+			title.Set(reflect.ValueOf(t.Title))
+		case *Element:
+			e := &Element{BaseTag: t.BaseTag, Content: Content{Base: t.Title.Base, Value: t.Title.V()}}
+			if elem != nil {
+				e.BaseTag = elem.BaseTag
+			}
+			title.Set(reflect.ValueOf(e))
+		}
+	}
+
+	if t.Summary != nil {
+		if summary := v.FieldByName("Summary"); summary.IsValid() {
+			summary.Set(reflect.ValueOf(t.Summary))
+		}
+	}
+
+	if t.Description != nil {
+		if desc := v.FieldByName("Description"); desc.IsValid() {
+			desc.Set(reflect.ValueOf(t.Description))
+		}
+	}
+}
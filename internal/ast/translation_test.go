@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: MIT
+
+package ast
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v3"
+	"golang.org/x/text/language"
+
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/internal/xmlenc"
+)
+
+func TestTranslation_Sanitize(t *testing.T) {
+	a := assert.New(t, false)
+
+	data := []*struct {
+		xml string
+		v   any
+		err bool
+	}{
+		{xml: `<tag name="t" title="t"><translation lang="en" title="tag" /></tag>`, v: &Tag{}},
+		{xml: `<tag name="t" title="t"><translation lang="en" title="t1" /><translation lang="EN" title="t2" /></tag>`, v: &Tag{}, err: true},
+		{xml: `<tag name="t" title="t"><translation lang="en" title="t1" /><translation lang="cmn-Hant" title="t2" /></tag>`, v: &Tag{}},
+		{xml: `<tag name="t" title="t"><translation lang="not a lang" title="t1" /></tag>`, v: &Tag{}, err: true},
+		{xml: `<tag name="t" title="t"><translation lang="en" /></tag>`, v: &Tag{}, err: true},
+		{xml: `<enum value="e" summary="s"><translation lang="en"><description type="markdown"><![CDATA[desc]]></description></translation></enum>`, v: &Enum{}},
+	}
+
+	for i, item := range data {
+		p, rslt := newParser(a, item.xml, "")
+		xmlenc.Decode(p, item.v, core.XMLNamespace)
+		rslt.Handler.Stop()
+		if item.err {
+			a.NotEmpty(rslt.Errors, "not error at %d", i)
+		} else {
+			a.Empty(rslt.Errors, "error %v at %d", rslt.Errors, i)
+		}
+	}
+}
+
+func TestAPIDoc_Translate(t *testing.T) {
+	a := assert.New(t, false)
+
+	parse := func() *APIDoc {
+		docs := &Documents{}
+		rslt := parseDocuments(docs,
+			newDocumentsBlock("doc.go", `<apidoc lang="cmn-Hans">
+	<title>标题</title>
+	<description type="markdown"><![CDATA[描述]]></description>
+	<translation lang="en" title="title"><description type="markdown"><![CDATA[desc]]></description></translation>
+	<translation lang="cmn-Hant" title="標題" />
+	<mimetype>json</mimetype>
+	<tag name="t1" title="标签">
+		<translation lang="en-US" title="tag" />
+	</tag>
+</apidoc>`),
+			newDocumentsBlock("api.go", `<api method="GET" summary="用户列表">
+	<translation lang="en" summary="users" />
+	<path path="/users">
+		<query name="page" type="number" summary="页码">
+			<translation lang="fr" summary="page" />
+		</query>
+	</path>
+	<response status="200" />
+</api>`),
+		)
+		a.Empty(rslt.Errors).Length(docs.Docs, 1)
+		return docs.Docs[0]
+	}
+
+	doc := parse()
+	doc.Translate(language.English)
+	a.Equal(doc.Lang.V(), "en").
+		Equal(doc.Title.V(), "title").
+		Equal(doc.Description.V(), "desc").
+		Empty(doc.Translations).
+		Equal(doc.Tags[0].Title.V(), "tag").
+		Empty(doc.Tags[0].Translations).
+		Equal(doc.APIs[0].Summary.V(), "users").
+		Equal(doc.APIs[0].Path.Queries[0].Summary.V(), "页码"). // 不存在匹配项，保留默认值
+		Empty(doc.APIs[0].Path.Queries[0].Translations)
+
+	// 仅替换指定的内容
+	doc = parse()
+	doc.Translate(language.MustParse("cmn-Hant"))
+	a.Equal(doc.Lang.V(), "cmn-Hant").
+		Equal(doc.Title.V(), "標題").
+		Equal(doc.Description.V(), "描述").
+		Equal(doc.Tags[0].Title.V(), "标签")
+
+	// 与默认语言相同
+	doc = parse()
+	doc.Translate(language.MustParse("cmn-Hans"))
+	a.Equal(doc.Lang.V(), "cmn-Hans").
+		Equal(doc.Title.V(), "标题").
+		Equal(doc.Tags[0].Title.V(), "标签").
+		Equal(doc.APIs[0].Summary.V(), "用户列表")
+
+	// 没有任何匹配的翻译内容，保留文档的默认语言
+	doc = parse()
+	doc.Translate(language.Japanese)
+	a.Equal(doc.Lang.V(), "cmn-Hans").
+		Equal(doc.Title.V(), "标题").
+		Empty(doc.Translations).
+		Empty(doc.Tags[0].Translations)
+}
@@ -0,0 +1,160 @@
+// SPDX-License-Identifier: MIT
+
+package ast
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/issue9/validation/is"
+)
+
+var uuidExpr = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// ParseValue 将字符串 v 按类型 t 进行转换
+//
+// bool 返回 bool 类型的值，number 及其子类型返回 int64 或是 float64，
+// 其它类型返回 v 本身。如果 v 的格式不符合 t 的要求，ok 返回 false。
+func ParseValue(t, v string) (val any, ok bool) {
+	switch t {
+	case TypeBool:
+		b, err := strconv.ParseBool(v)
+		return b, err == nil
+	case TypeNumber:
+		if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return i, true
+		}
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	case TypeInt, TypeInt64:
+		i, err := strconv.ParseInt(v, 10, 64)
+		return i, err == nil
+	case TypeInt32:
+		i, err := strconv.ParseInt(v, 10, 32)
+		return i, err == nil
+	case TypeFloat, TypeDouble:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	case TypeEmail:
+		return v, is.Email(v)
+	case TypeURL:
+		return v, is.URL(v)
+	case TypeDate:
+		_, err := time.Parse(DateFormat, v)
+		return v, err == nil
+	case TypeTime:
+		_, err := time.Parse(TimeFormat, v)
+		return v, err == nil
+	case TypeDateTime:
+		_, err := time.Parse(DateTimeFormat, v)
+		return v, err == nil
+	case TypeUUID:
+		return v, uuidExpr.MatchString(v)
+	case TypeIPv4:
+		ip, err := netip.ParseAddr(v)
+		return v, err == nil && ip.Is4()
+	case TypeIPv6:
+		ip, err := netip.ParseAddr(v)
+		return v, err == nil && ip.Is6() && ip.Zone() == ""
+	case TypeHostname:
+		return v, isHostname(v)
+	case TypeByte:
+		_, err := base64.StdEncoding.DecodeString(v)
+		return v, err == nil
+	default:
+		return v, true
+	}
+}
+
+// RFC1123 中定义的主机名
+func isHostname(v string) bool {
+	if v == "" || len(v) > 253 {
+		return false
+	}
+
+	for _, label := range strings.Split(v, ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z') && !(c >= 'A' && c <= 'Z') && !(c >= '0' && c <= '9') && c != '-' {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// DefaultValue 返回 default 属性按类型转换之后的值
+//
+// 数组的默认值以 [ 和 ] 包含，元素之间以逗号分隔，比如 [normal,lock]，
+// 也可以是 JSON 格式的数组，未以 [ 和 ] 包含的值则作为数组的唯一元素。
+// 返回 []any，其中每一个元素都会按类型进行转换。
+// 未指定 default 时返回 nil。如果值与类型不符，ok 返回 false。
+func (p *Param) DefaultValue() (val any, ok bool) {
+	if p.Default == nil {
+		return nil, true
+	}
+
+	if !p.Array.V() {
+		return ParseValue(p.Type.V(), p.Default.V())
+	}
+
+	items, ok := splitArray(p.Default.V())
+	if !ok { // 兼容旧版本，未以 [] 包含的值作为数组的唯一元素。
+		items = []string{p.Default.V()}
+	}
+
+	values := make([]any, 0, len(items))
+	for _, item := range items {
+		val, ok := ParseValue(p.Type.V(), item)
+		if !ok {
+			return nil, false
+		}
+		values = append(values, val)
+	}
+	return values, true
+}
+
+// 将数组格式的字符串拆分成元素列表
+//
+// 优先按 JSON 数组进行解析，失败时按逗号分隔。
+func splitArray(v string) (items []string, ok bool) {
+	v = strings.TrimSpace(v)
+	if !strings.HasPrefix(v, "[") || !strings.HasSuffix(v, "]") {
+		return nil, false
+	}
+
+	var raws []json.RawMessage
+	if err := json.Unmarshal([]byte(v), &raws); err == nil {
+		items = make([]string, 0, len(raws))
+		for _, raw := range raws {
+			item := string(raw)
+			if strings.HasPrefix(item, `"`) {
+				if err := json.Unmarshal(raw, &item); err != nil {
+					return nil, false
+				}
+			}
+			items = append(items, item)
+		}
+		return items, true
+	}
+
+	v = strings.TrimSpace(v[1 : len(v)-1])
+	if v == "" {
+		return []string{}, true
+	}
+
+	items = strings.Split(v, ",")
+	for i, item := range items {
+		items[i] = strings.TrimSpace(item)
+	}
+	return items, true
+}
@@ -0,0 +1,160 @@
+// SPDX-License-Identifier: MIT
+
+package ast
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/issue9/assert/v3"
+
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/internal/xmlenc"
+)
+
+func TestParseValue(t *testing.T) {
+	a := assert.New(t, false)
+
+	data := []*struct {
+		t, v string
+		val  any
+		ok   bool
+	}{
+		{t: TypeBool, v: "true", val: true, ok: true},
+		{t: TypeBool, v: "yes", val: false},
+		{t: TypeNumber, v: "5", val: int64(5), ok: true},
+		{t: TypeNumber, v: "-1.5", val: -1.5, ok: true},
+		{t: TypeNumber, v: "abc", val: 0.0},
+		{t: TypeInt, v: "1.5", val: int64(0)},
+		{t: TypeInt32, v: "2147483648", val: int64(2147483647)},
+		{t: TypeInt64, v: "2147483648", val: int64(2147483648), ok: true},
+		{t: TypeDouble, v: "1.5", val: 1.5, ok: true},
+		{t: TypeString, v: "abc", val: "abc", ok: true},
+		{t: TypeObject, v: "abc", val: "abc", ok: true},
+		{t: TypeEmail, v: "user@example.com", val: "user@example.com", ok: true},
+		{t: TypeEmail, v: "example.com", val: "example.com"},
+		{t: TypeURL, v: "https://example.com", val: "https://example.com", ok: true},
+		{t: TypeURL, v: "example", val: "example"},
+		{t: TypeDate, v: "2010-01-02", val: "2010-01-02", ok: true},
+		{t: TypeDate, v: "2010-01-32", val: "2010-01-32"},
+		{t: TypeTime, v: "17:18:19Z", val: "17:18:19Z", ok: true},
+		{t: TypeTime, v: "17:18:19", val: "17:18:19"},
+		{t: TypeDateTime, v: "2020-01-02T17:18:19+08:00", val: "2020-01-02T17:18:19+08:00", ok: true},
+		{t: TypeDateTime, v: "2020-01-02T17:18:19", val: "2020-01-02T17:18:19"},
+		{t: TypeUUID, v: "123E4567-E89B-12D3-A456-426614174000", val: "123E4567-E89B-12D3-A456-426614174000", ok: true},
+		{t: TypeUUID, v: "123e4567e89b12d3a456426614174000", val: "123e4567e89b12d3a456426614174000"},
+		{t: TypeIPv4, v: "127.0.0.1", val: "127.0.0.1", ok: true},
+		{t: TypeIPv4, v: "256.0.0.1", val: "256.0.0.1"},
+		{t: TypeIPv6, v: "2001:db8::ff00:42:8329", val: "2001:db8::ff00:42:8329", ok: true},
+		{t: TypeIPv6, v: "fe80::1%eth0", val: "fe80::1%eth0"},
+		{t: TypeByte, v: "YXBpZG9j", val: "YXBpZG9j", ok: true},
+		{t: TypeByte, v: "apidoc!", val: "apidoc!"},
+	}
+
+	for i, item := range data {
+		val, ok := ParseValue(item.t, item.v)
+		a.Equal(ok, item.ok, "ok not equal at %d", i)
+		if item.ok {
+			a.Equal(val, item.val, "val not equal at %d", i)
+		}
+	}
+}
+
+func TestIsHostname(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.True(isHostname("localhost"))
+	a.True(isHostname("api.example.com"))
+	a.True(isHostname("1-api.example.com"))
+
+	a.False(isHostname(""))
+	a.False(isHostname("-api.example.com"))
+	a.False(isHostname("api..example.com"))
+	a.False(isHostname("api_1.example.com"))
+	a.False(isHostname(strings.Repeat("a", 64) + ".com"))
+}
+
+func TestParam_DefaultValue(t *testing.T) {
+	a := assert.New(t, false)
+
+	newParam := func(t, def string, array bool) *Param {
+		p := &Param{
+			Type:  &TypeAttribute{Value: xmlenc.String{Value: t}},
+			Array: &BoolAttribute{Value: Bool{Value: array}},
+		}
+		if def != "" {
+			p.Default = &Attribute{Value: xmlenc.String{Value: def}}
+		}
+		return p
+	}
+
+	val, ok := newParam(TypeNumber, "", false).DefaultValue()
+	a.True(ok).Nil(val)
+
+	val, ok = newParam(TypeNumber, "5", false).DefaultValue()
+	a.True(ok).Equal(val, int64(5))
+
+	_, ok = newParam(TypeNumber, "abc", false).DefaultValue()
+	a.False(ok)
+
+	_, ok = newParam(TypeBool, "yes", false).DefaultValue()
+	a.False(ok)
+
+	val, ok = newParam(TypeString, "[normal, lock]", true).DefaultValue()
+	a.True(ok).Equal(val, []any{"normal", "lock"})
+
+	val, ok = newParam(TypeString, `["a,b", "c"]`, true).DefaultValue()
+	a.True(ok).Equal(val, []any{"a,b", "c"})
+
+	val, ok = newParam(TypeNumber, "[1,2.5]", true).DefaultValue()
+	a.True(ok).Equal(val, []any{int64(1), 2.5})
+
+	val, ok = newParam(TypeNumber, "[]", true).DefaultValue()
+	a.True(ok).Empty(val)
+
+	_, ok = newParam(TypeNumber, "[1,a]", true).DefaultValue()
+	a.False(ok)
+
+	val, ok = newParam(TypeNumber, "1", true).DefaultValue()
+	a.True(ok).Equal(val, []any{int64(1)})
+
+	val, ok = newParam(TypeString, "normal", true).DefaultValue()
+	a.True(ok).Equal(val, []any{"normal"})
+
+	_, ok = newParam(TypeNumber, "a", true).DefaultValue()
+	a.False(ok)
+
+	_, ok = newParam(TypeDate, "[2020-01-02,2020-13-01]", true).DefaultValue()
+	a.False(ok)
+}
+
+func TestParam_Sanitize_default(t *testing.T) {
+	a := assert.New(t, false)
+
+	data := []*struct {
+		xml string
+		err bool
+	}{
+		{xml: `<param name="p" type="number" default="5" summary="s" />`},
+		{xml: `<param name="p" type="number" default="abc" summary="s" />`, err: true},
+		{xml: `<param name="p" type="bool" default="yes" summary="s" />`, err: true},
+		{xml: `<param name="p" type="string.date-time" default="2020-01-02" summary="s" />`, err: true},
+		{xml: `<param name="p" type="string.email" default="user@example.com" summary="s" />`},
+		{xml: `<param name="p" type="string.url" default="url" summary="s" />`, err: true},
+		{xml: `<param name="p" type="number" array="true" default="[1,2]" summary="s" />`},
+		{xml: `<param name="p" type="number" array="true" default="[1,x]" summary="s" />`, err: true},
+		{xml: `<param name="p" type="number" array="true" default="1" summary="s" />`},
+		{xml: `<param name="p" type="number" array="true" default="x" summary="s" />`, err: true},
+	}
+
+	for i, item := range data {
+		p, rslt := newParser(a, item.xml, "")
+		xmlenc.Decode(p, &Param{}, core.XMLNamespace)
+		rslt.Handler.Stop()
+		if item.err {
+			a.NotEmpty(rslt.Errors, "not error at %d", i)
+		} else {
+			a.Empty(rslt.Errors, "error %v at %d", rslt.Errors, i)
+		}
+	}
+}
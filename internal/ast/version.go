@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: MIT
+
+package ast
+
+import (
+	"github.com/issue9/sliceutil"
+	"github.com/issue9/version"
+)
+
+// FilterVersion 仅保留版本号为 target 时可用的内容
+//
+// since 晚于 target 的内容都会被删除，如果 deprecated 为 true，
+// 那么在 target 之前的版本就已经被弃用的内容也会被删除。
+// 被删除的 tag 和 server 也会从 api 的引用中删除，
+// link 中指向已被删除的 API 的链接也一并删除。
+// 版本号无法比较的内容会被保留。
+func (doc *APIDoc) FilterVersion(target string, deprecated bool) {
+	f := &versionFilter{target: target, deprecated: deprecated}
+
+	doc.Tags = sliceutil.Delete(doc.Tags, func(t *Tag) bool { return !f.available(t.Since, t.Deprecated) })
+	doc.Servers = sliceutil.Delete(doc.Servers, func(srv *Server) bool { return !f.available(srv.Since, srv.Deprecated) })
+	for _, srv := range doc.Servers {
+		for _, v := range srv.Variables {
+			v.Enums = f.enums(v.Enums)
+		}
+	}
+
+	for _, t := range doc.Types {
+		t.Items = f.params(t.Items)
+		t.Enums = f.enums(t.Enums)
+	}
+	doc.Headers = f.params(doc.Headers)
+	doc.Responses = f.requests(doc.Responses)
+
+	doc.APIs = sliceutil.Delete(doc.APIs, func(api *API) bool { return !f.available(api.Since, api.Deprecated) })
+	for _, api := range doc.APIs {
+		f.api(api)
+		api.Tags = sliceutil.Delete(api.Tags, func(t *TagValue) bool { return doc.findTag(t.V()) == nil })
+		api.Servers = sliceutil.Delete(api.Servers, func(srv *ServerValue) bool { return doc.findServer(srv.V()) == nil })
+	}
+	doc.Webhooks = f.callbacks(doc.Webhooks)
+	doc.PruneLinks()
+}
+
+type versionFilter struct {
+	target     string
+	deprecated bool // 是否删除已经弃用的内容
+}
+
+func (f *versionFilter) available(since, deprecated *VersionAttribute) bool {
+	if since != nil {
+		if ret, err := version.SemVerCompare(since.V(), f.target); err == nil && ret > 0 {
+			return false
+		}
+	}
+
+	if f.deprecated && deprecated != nil {
+		if ret, err := version.SemVerCompare(deprecated.V(), f.target); err == nil && ret < 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (f *versionFilter) api(api *API) {
+	api.Headers = f.params(api.Headers)
+	api.Cookies = f.params(api.Cookies)
+	f.path(api.Path)
+	api.Requests = f.requests(api.Requests)
+	api.Responses = f.requests(api.Responses)
+
+	api.Callbacks = f.callbacks(api.Callbacks)
+}
+
+func (f *versionFilter) callbacks(callbacks []*Callback) []*Callback {
+	callbacks = sliceutil.Delete(callbacks, func(cb *Callback) bool { return !f.available(nil, cb.Deprecated) })
+	for _, cb := range callbacks {
+		cb.Headers = f.params(cb.Headers)
+		f.path(cb.Path)
+		cb.Requests = f.requests(cb.Requests)
+		cb.Responses = f.requests(cb.Responses)
+	}
+	return callbacks
+}
+
+func (f *versionFilter) path(p *Path) {
+	if p != nil {
+		p.Params = f.params(p.Params)
+		p.Queries = f.params(p.Queries)
+	}
+}
+
+func (f *versionFilter) requests(requests []*Request) []*Request {
+	requests = sliceutil.Delete(requests, func(r *Request) bool { return !f.available(r.Since, r.Deprecated) })
+	for _, r := range requests {
+		r.Items = f.params(r.Items)
+		r.Enums = f.enums(r.Enums)
+		r.Headers = f.params(r.Headers)
+		r.Cookies = f.params(r.Cookies)
+		f.compositions(r.OneOf, r.AnyOf, r.AllOf)
+	}
+	return requests
+}
+
+func (f *versionFilter) params(params []*Param) []*Param {
+	params = sliceutil.Delete(params, func(p *Param) bool { return !f.available(p.Since, p.Deprecated) })
+	for _, p := range params {
+		p.Items = f.params(p.Items)
+		p.Enums = f.enums(p.Enums)
+		f.compositions(p.OneOf, p.AnyOf, p.AllOf)
+	}
+	return params
+}
+
+func (f *versionFilter) compositions(comps ...*Composition) {
+	for _, c := range comps {
+		if c != nil {
+			c.Items = f.params(c.Items)
+		}
+	}
+}
+
+func (f *versionFilter) enums(enums []*Enum) []*Enum {
+	return sliceutil.Delete(enums, func(e *Enum) bool { return !f.available(e.Since, e.Deprecated) })
+}
@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: MIT
+
+package ast
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v3"
+
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/internal/xmlenc"
+)
+
+func TestCheckSince(t *testing.T) {
+	a := assert.New(t, false)
+
+	data := []*struct {
+		xml string
+		v   any
+		err bool
+	}{
+		{xml: `<param name="p" type="number" since="1.0.0" deprecated="1.1.0" summary="s" />`, v: &Param{}},
+		{xml: `<param name="p" type="number" since="1.1.0" deprecated="1.1.0" summary="s" />`, v: &Param{}, err: true},
+		{xml: `<param name="p" type="number" since="1.2.0" deprecated="1.1.0" summary="s" />`, v: &Param{}, err: true},
+		{xml: `<tag name="t" title="t" since="1.2.0" deprecated="1.1.0" />`, v: &Tag{}, err: true},
+		{xml: `<enum value="e" summary="s" since="2.0.0" deprecated="1.1.0" />`, v: &Enum{}, err: true},
+		{xml: `<server name="s" url="https://example.com" since="2.0.0" />`, v: &Server{}},
+	}
+
+	for i, item := range data {
+		p, rslt := newParser(a, item.xml, "")
+		xmlenc.Decode(p, item.v, core.XMLNamespace)
+		rslt.Handler.Stop()
+		if item.err {
+			a.NotEmpty(rslt.Errors, "not error at %d", i)
+		} else {
+			a.Empty(rslt.Errors, "error %v at %d", rslt.Errors, i)
+		}
+	}
+}
+
+func TestAPIDoc_FilterVersion(t *testing.T) {
+	a := assert.New(t, false)
+
+	parse := func() *APIDoc {
+		docs := &Documents{}
+		rslt := parseDocuments(docs,
+			newDocumentsBlock("doc.go", `<apidoc version="2.0.0">
+	<title>t</title>
+	<mimetype>json</mimetype>
+	<tag name="t1" title="t1" />
+	<tag name="t2" title="t2" since="2.0.0" />
+	<tag name="t3" title="t3" deprecated="1.1.0" />
+	<server name="s1" url="https://example.com/s1" since="1.1.0" />
+	<webhook method="POST" name="created" deprecated="1.0.1"><request type="string" mimetype="json" /></webhook>
+</apidoc>`),
+			newDocumentsBlock("api1.go", `<api method="GET">
+	<path path="/users">
+		<query name="page" type="number" summary="page" />
+		<query name="size" type="number" summary="size" since="1.1.0" />
+	</path>
+	<tag>t1</tag>
+	<tag>t2</tag>
+	<tag>t3</tag>
+	<server>s1</server>
+	<response status="200" type="object">
+		<param name="id" type="number" summary="id" />
+		<param name="status" type="string" summary="status" deprecated="1.0.1">
+			<enum value="normal" summary="normal" />
+			<enum value="lock" summary="lock" since="2.0.0" />
+		</param>
+	</response>
+	<response status="404" type="string" since="1.1.0">
+		<link name="post" api="post-users" />
+		<link name="delete" api="delete-users" />
+	</response>
+	<callback method="POST" name="success"><request type="string" mimetype="json" /></callback>
+	<callback method="POST" name="failure" deprecated="1.0.1"><request type="string" mimetype="json" /></callback>
+</api>`),
+			newDocumentsBlock("api2.go", `<api method="POST" id="post-users" since="1.1.0"><path path="/users" /><response status="200" /></api>`),
+			newDocumentsBlock("api3.go", `<api method="DELETE" id="delete-users" deprecated="1.0.1"><path path="/users" /><response status="200" /></api>`),
+		)
+		a.Empty(rslt.Errors, "%v", rslt.Errors).Length(docs.Docs, 1)
+		return docs.Docs[0]
+	}
+
+	get := func(doc *APIDoc, method string) *API {
+		for _, api := range doc.APIs {
+			if api.Method.V() == method {
+				return api
+			}
+		}
+		return nil
+	}
+
+	doc := parse()
+	doc.FilterVersion("1.0.0", false)
+	a.Length(doc.Tags, 2).Empty(doc.Servers).Length(doc.APIs, 2).Length(doc.Webhooks, 1)
+	api := get(doc, "GET")
+	a.NotNil(api).Nil(get(doc, "POST")).
+		Length(api.Callbacks, 2).
+		Length(api.Path.Queries, 1).
+		Length(api.Tags, 2).
+		Empty(api.Servers).
+		Length(api.Responses, 1).
+		Length(api.Responses[0].Items, 2).
+		Length(api.Responses[0].Items[1].Enums, 1)
+
+	// 在 target 版本才弃用的内容会被保留
+	doc = parse()
+	doc.FilterVersion("1.0.1", true)
+	a.Length(doc.Tags, 2).Length(doc.APIs, 2).Length(doc.Webhooks, 1)
+	api = get(doc, "GET")
+	a.NotNil(api).NotNil(get(doc, "DELETE")).
+		Length(api.Callbacks, 2).
+		Length(api.Responses[0].Items, 2)
+
+	doc = parse()
+	doc.FilterVersion("1.1.0", true)
+	a.Length(doc.Tags, 2).Length(doc.Servers, 1).Length(doc.APIs, 2).Empty(doc.Webhooks)
+	api = get(doc, "GET")
+	a.NotNil(api).Nil(get(doc, "DELETE")).
+		Length(api.Callbacks, 1).
+		Length(api.Path.Queries, 2).
+		Length(api.Tags, 2).
+		Length(api.Servers, 1).
+		Length(api.Responses, 2).
+		Length(api.Responses[0].Items, 1).
+		Length(api.Responses[1].Links, 1).
+		Equal(api.Responses[1].Links[0].API.V(), "post-users")
+
+	doc = parse()
+	doc.FilterVersion("2.0.0", false)
+	a.Length(doc.Tags, 3).Length(doc.APIs, 3)
+}
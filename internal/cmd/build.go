@@ -13,14 +13,22 @@ import (
 	"github.com/caixw/apidoc/v7/internal/locale"
 )
 
-var buildDir = uri("./")
+var (
+	buildDir   = uri("./")
+	buildWatch bool
+)
 
 func initBuild(command *cmdopt.CmdOpt) {
 	fs := command.New("build", locale.Sprintf(locale.CmdBuildUsage), doBuild)
 	fs.Var(&buildDir, "d", locale.Sprintf(locale.FlagBuildDirUsage))
+	fs.BoolVar(&buildWatch, "w", false, locale.Sprintf(locale.FlagBuildWatchUsage))
 }
 
 func doBuild(io.Writer) error {
+	if buildWatch {
+		return watch(core.URI(buildDir), true)
+	}
+
 	start := time.Now()
 
 	cfg, err := build.LoadConfig(core.URI(buildDir))
@@ -32,6 +40,6 @@ func doBuild(io.Writer) error {
 	defer h.Stop()
 
 	cfg.Build(h)
-	h.Locale(core.Info, locale.Complete, cfg.Output.Path, time.Since(start))
+	h.Locale(core.Info, locale.Complete, time.Since(start))
 	return nil
 }
@@ -12,14 +12,22 @@ import (
 	"github.com/caixw/apidoc/v7/internal/locale"
 )
 
-var syntaxDir uri = uri(core.FileURI("./"))
+var (
+	syntaxDir   = uri(core.FileURI("./"))
+	syntaxWatch bool
+)
 
 func initSyntax(command *cmdopt.CmdOpt) {
 	fs := command.New("syntax", locale.Sprintf(locale.CmdSyntaxUsage), syntax)
 	fs.Var(&syntaxDir, "d", locale.Sprintf(locale.FlagSyntaxDirUsage))
+	fs.BoolVar(&syntaxWatch, "w", false, locale.Sprintf(locale.FlagSyntaxWatchUsage))
 }
 
 func syntax(w io.Writer) error {
+	if syntaxWatch {
+		return watch(syntaxDir.URI(), false)
+	}
+
 	cfg, err := build.LoadConfig(syntaxDir.URI())
 	if err != nil {
 		return err
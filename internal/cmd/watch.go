@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/caixw/apidoc/v7/build"
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/internal/locale"
+)
+
+// 监视模式下检测文件变化的时间间隔
+const watchInterval = time.Second
+
+// 监视 wd 目录下配置文件所指定的文件，并在变化之后重新解析
+//
+// output 表示是否需要输出文档，否则仅检测语法。
+func watch(wd core.URI, output bool) error {
+	h := core.NewMessageHandler(messageHandle)
+	defer h.Stop()
+
+	w, err := build.NewWatcher(h, wd, output)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	h.Locale(core.Info, locale.WatchStart, wd)
+	w.Watch(ctx, watchInterval, func(diff *build.Diff) {
+		h.Locale(core.Succ, locale.WatchRebuild)
+		for _, api := range diff.Added {
+			h.Locale(core.Info, locale.WatchAPIAdded, api)
+		}
+		for _, api := range diff.Changed {
+			h.Locale(core.Info, locale.WatchAPIChanged, api)
+		}
+		for _, api := range diff.Removed {
+			h.Locale(core.Info, locale.WatchAPIRemoved, api)
+		}
+	})
+	return nil
+}
@@ -55,6 +55,15 @@ func (d *doc) buildConfigItem(parent string, f reflect.StructField) error {
 		panic(fmt.Sprintf("字段 %s 的类型 %s 无法处理", f.Name, t.Kind()))
 	}
 
+	// 同一类型的字段仅说明一次，比如 outputs 的元素与 output 相同。
+	if d.configTypes == nil {
+		d.configTypes = make(map[reflect.Type]struct{}, 5)
+	}
+	if _, found := d.configTypes[t]; found {
+		return nil
+	}
+	d.configTypes[t] = struct{}{}
+
 	return d.buildConfigObject(name, t)
 }
 
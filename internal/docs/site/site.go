@@ -9,6 +9,7 @@ import (
 	"encoding/xml"
 	"io/ioutil"
 	"os"
+	"reflect"
 
 	"github.com/issue9/errwrap"
 	"golang.org/x/text/language/display"
@@ -53,6 +54,8 @@ type doc struct {
 	Spec     []*spec    `xml:"spec>type"`
 	Commands []*command `xml:"commands>command"`
 	Config   []*item    `xml:"config>item"`
+
+	configTypes map[reflect.Type]struct{} // 已经生成过字段说明的配置项类型
 }
 
 type spec struct {
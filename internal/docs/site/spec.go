@@ -50,6 +50,10 @@ func (d *doc) dumpToTypes(n *node.Node) error {
 		}
 	}
 
+	if n.AnyAttributes != nil {
+		appendItem(t, "@"+n.AnyAttributes.Name+"*", n.AnyAttributes.Value, n.AnyAttributes.Usage, false)
+	}
+
 	for _, elem := range n.Elements {
 		appendItem(t, elem.Name, elem.Value, elem.Usage, !elem.Omitempty)
 
@@ -39,7 +39,9 @@ mock 服务会根据接口定义检测用户提交的数据是否合法，并生
 	CmdNotFound    = "子命令 %s 未找到\n"
 
 	FlagSyntaxDirUsage         = "以 `URI` 形式表示测试项目地址"
+	FlagSyntaxWatchUsage       = "监视文件的变化，并在变化之后重新检测语法"
 	FlagBuildDirUsage          = "以 `URI` 形式表示的项目地址"
+	FlagBuildWatchUsage        = "监视文件的变化，并在变化之后重新生成文档"
 	FlagMockPortUsage          = "指定 mock 服务的端口号"
 	FlagMockServersUsage       = "指定 mock 服务时，文档中 server 变量对应的路由前缀"
 	FlagMockIndentUsage        = "指定缩进内容"
@@ -70,9 +72,15 @@ mock 服务会根据接口定义检测用户提交的数据是否合法，并生
 	FlagVersionKindUsage       = "只显示该类型的版本号，可以是 apidoc、doc、lsp、openapi 和 all"
 
 	VersionInCompatible = "当前程序与配置文件中指定的版本号不兼容"
-	Complete            = "完成！文档保存在：%s，总用时：%v"
+	Complete            = "完成！总用时：%v"
+	OutputSuccess       = "文档已保存至 %s"
 	ConfigWriteSuccess  = "配置内容成功写入 %s"
 	TestSuccess         = "语法没有问题！"
+	WatchStart          = "正在监视 %s 中的文件变化，按 Ctrl+C 退出"
+	WatchRebuild        = "检测到文件变化，已重新解析"
+	WatchAPIAdded       = "新增 API：%s"
+	WatchAPIChanged     = "修改 API：%s"
+	WatchAPIRemoved     = "删除 API：%s"
 	LangID              = "ID"
 	LangName            = "名称"
 	LangExts            = "扩展名"
@@ -87,40 +95,52 @@ mock 服务会根据接口定义检测用户提交的数据是否合法，并生
 	// 文档树中各个字段的介绍
 	UsageAPIDoc              = "usage-apidoc"
 	UsageAPIDocAPIDoc        = "usage-apidoc-apidoc"
+	UsageAPIDocID            = "usage-apidoc-id"
 	UsageAPIDocLang          = "usage-apidoc-lang"
 	UsageAPIDocLogo          = "usage-apidoc-logo"
 	UsageAPIDocCreated       = "usage-apidoc-created"
 	UsageAPIDocVersion       = "usage-apidoc-version"
 	UsageAPIDocTitle         = "usage-apidoc-title"
 	UsageAPIDocDescription   = "usage-apidoc-description"
+	UsageAPIDocTranslations  = "usage-apidoc-translations"
 	UsageAPIDocContact       = "usage-apidoc-contact"
 	UsageAPIDocLicense       = "usage-apidoc-license"
 	UsageAPIDocTags          = "usage-apidoc-tags"
 	UsageAPIDocServers       = "usage-apidoc-servers"
+	UsageAPIDocSecurities    = "usage-apidoc-securities"
 	UsageAPIDocAPIs          = "usage-apidoc-apis"
+	UsageAPIDocWebhooks      = "usage-apidoc-webhooks"
 	UsageAPIDocHeaders       = "usage-apidoc-headers"
 	UsageAPIDocResponses     = "usage-apidoc-responses"
 	UsageAPIDocMimetypes     = "usage-apidoc-mimetypes"
 	UsageAPIDocXMLNamespaces = "usage-apidoc-xml-namespaces"
+	UsageAPIDocTypes         = "usage-apidoc-types"
 
 	UsageXMLNamespace       = "usage-xml-namespace"
 	UsageXMLNamespacePrefix = "usage-xml-namespace-prefix"
 	UsageXMLNamespaceURN    = "usage-xml-namespace-urn"
 
-	UsageAPI            = "usage-api"
-	UsageAPIVersion     = "usage-api-version"
-	UsageAPIMethod      = "usage-api-method"
-	UsageAPIID          = "usage-api-id"
-	UsageAPIPath        = "usage-api-path"
-	UsageAPISummary     = "usage-api-summary"
-	UsageAPIDescription = "usage-api-description"
-	UsageAPIRequests    = "usage-api-requests"
-	UsageAPIResponses   = "usage-api-responses"
-	UsageAPICallback    = "usage-api-callback"
-	UsageAPIDeprecated  = "usage-api-deprecated"
-	UsageAPIHeaders     = "usage-api-headers"
-	UsageAPITags        = "usage-api-tags"
-	UsageAPIServers     = "usage-api-servers"
+	UsageAPI             = "usage-api"
+	UsageAPIVersion      = "usage-api-version"
+	UsageAPIMethod       = "usage-api-method"
+	UsageAPIID           = "usage-api-id"
+	UsageAPIDocument     = "usage-api-doc"
+	UsageAPIPath         = "usage-api-path"
+	UsageAPISummary      = "usage-api-summary"
+	UsageAPIDescription  = "usage-api-description"
+	UsageAPITranslations = "usage-api-translations"
+	UsageAPIRequests     = "usage-api-requests"
+	UsageAPIResponses    = "usage-api-responses"
+	UsageAPICallbacks    = "usage-api-callbacks"
+	UsageAPIDeprecated   = "usage-api-deprecated"
+	UsageAPISince        = "usage-api-since"
+	UsageAPIHeaders      = "usage-api-headers"
+	UsageAPICookies      = "usage-api-cookies"
+	UsageAPITags         = "usage-api-tags"
+	UsageAPIServers      = "usage-api-servers"
+	UsageAPISecurities   = "usage-api-securities"
+	UsageAPIXAttributes  = "usage-api-x-attributes"
+	UsageAPIExtensions   = "usage-api-extensions"
 
 	UsageLink     = "usage-link"
 	UsageLinkText = "usage-link-text"
@@ -131,75 +151,186 @@ mock 服务会根据接口定义检测用户提交的数据是否合法，并生
 	UsageContactURL   = "usage-contact-url"
 	UsageContactEmail = "usage-contact-email"
 
-	UsageCallback            = "usage-callback"
-	UsageCallbackMethod      = "usage-callback-method"
-	UsageCallbackPath        = "usage-callback-path"
-	UsageCallbackSummary     = "usage-callback-summary"
-	UsageCallbackDeprecated  = "usage-callback-deprecated"
-	UsageCallbackDescription = "usage-callback-description"
-	UsageCallbackResponses   = "usage-callback-responses"
-	UsageCallbackRequests    = "usage-callback-requests"
-	UsageCallbackHeaders     = "usage-callback-headers"
-
-	UsageEnum            = "usage-enum"
-	UsageEnumDeprecated  = "usage-enum-deprecated"
-	UsageEnumValue       = "usage-enum-value"
-	UsageEnumSummary     = "usage-enum-summary"
-	UsageEnumDescription = "usage-enum-description"
+	UsageCallback             = "usage-callback"
+	UsageCallbackMethod       = "usage-callback-method"
+	UsageCallbackName         = "usage-callback-name"
+	UsageCallbackExpression   = "usage-callback-expression"
+	UsageCallbackPath         = "usage-callback-path"
+	UsageCallbackSummary      = "usage-callback-summary"
+	UsageCallbackDeprecated   = "usage-callback-deprecated"
+	UsageCallbackDescription  = "usage-callback-description"
+	UsageCallbackTranslations = "usage-callback-translations"
+	UsageCallbackResponses    = "usage-callback-responses"
+	UsageCallbackRequests     = "usage-callback-requests"
+	UsageCallbackHeaders      = "usage-callback-headers"
+
+	UsageEnum             = "usage-enum"
+	UsageEnumDeprecated   = "usage-enum-deprecated"
+	UsageEnumSince        = "usage-enum-since"
+	UsageEnumValue        = "usage-enum-value"
+	UsageEnumSummary      = "usage-enum-summary"
+	UsageEnumDescription  = "usage-enum-description"
+	UsageEnumTranslations = "usage-enum-translations"
 
 	UsageExample         = "usage-example"
 	UsageExampleMimetype = "usage-example-mimetype"
 	UsageExampleSummary  = "usage-example-summary"
+	UsageExampleSrc      = "usage-example-src"
 	UsageExampleContent  = "usage-example-content"
 
-	UsageParam            = "usage-param"
-	UsageParamName        = "usage-param-name"
-	UsageParamType        = "usage-param-type"
-	UsageParamDeprecated  = "usage-param-deprecated"
-	UsageParamDefault     = "usage-param-default"
-	UsageParamOptional    = "usage-param-optional"
-	UsageParamArray       = "usage-param-array"
-	UsageParamItems       = "usage-param-items"
-	UsageParamSummary     = "usage-param-summary"
-	UsageParamEnums       = "usage-param-enums"
-	UsageParamDescription = "usage-param-description"
-	UsageParamArrayStyle  = "usage-param-array-style"
+	UsageParam             = "usage-param"
+	UsageParamName         = "usage-param-name"
+	UsageParamType         = "usage-param-type"
+	UsageParamDeprecated   = "usage-param-deprecated"
+	UsageParamSince        = "usage-param-since"
+	UsageParamDefault      = "usage-param-default"
+	UsageParamOptional     = "usage-param-optional"
+	UsageParamArray        = "usage-param-array"
+	UsageParamItems        = "usage-param-items"
+	UsageParamSummary      = "usage-param-summary"
+	UsageParamEnums        = "usage-param-enums"
+	UsageParamDescription  = "usage-param-description"
+	UsageParamTranslations = "usage-param-translations"
+	UsageParamArrayStyle   = "usage-param-array-style"
+	UsageParamXAttributes  = "usage-param-x-attributes"
+	UsageParamExtensions   = "usage-param-extensions"
+	UsageParamRef          = "usage-param-ref"
+	UsageParamOneOf        = "usage-param-one-of"
+	UsageParamAnyOf        = "usage-param-any-of"
+	UsageParamAllOf        = "usage-param-all-of"
+	UsageParamMin          = "usage-param-min"
+	UsageParamMax          = "usage-param-max"
+	UsageParamExclusiveMin = "usage-param-exclusive-min"
+	UsageParamExclusiveMax = "usage-param-exclusive-max"
+	UsageParamMinLength    = "usage-param-min-length"
+	UsageParamMaxLength    = "usage-param-max-length"
+	UsageParamPattern      = "usage-param-pattern"
+	UsageParamMinItems     = "usage-param-min-items"
+	UsageParamMaxItems     = "usage-param-max-items"
+	UsageParamUniqueItems  = "usage-param-unique-items"
 
 	UsagePath        = "usage-path"
 	UsagePathPath    = "usage-path-path"
 	UsagePathParams  = "usage-path-params"
 	UsagePathQueries = "usage-path-queries"
 
-	UsageRequest            = "usage-request"
-	UsageRequestName        = "usage-request-name"
-	UsageRequestType        = "usage-request-type"
-	UsageRequestDeprecated  = "usage-request-deprecated"
-	UsageRequestArray       = "usage-request-array"
-	UsageRequestItems       = "usage-request-items"
-	UsageRequestSummary     = "usage-request-summary"
-	UsageRequestStatus      = "usage-request-status"
-	UsageRequestEnums       = "usage-request-enums"
-	UsageRequestDescription = "usage-request-description"
-	UsageRequestMimetype    = "usage-request-mimetype"
-	UsageRequestExamples    = "usage-request-examples"
-	UsageRequestHeaders     = "usage-request-headers"
+	UsageRequest             = "usage-request"
+	UsageRequestName         = "usage-request-name"
+	UsageRequestType         = "usage-request-type"
+	UsageRequestDeprecated   = "usage-request-deprecated"
+	UsageRequestSince        = "usage-request-since"
+	UsageRequestArray        = "usage-request-array"
+	UsageRequestItems        = "usage-request-items"
+	UsageRequestSummary      = "usage-request-summary"
+	UsageRequestStatus       = "usage-request-status"
+	UsageRequestEnums        = "usage-request-enums"
+	UsageRequestDescription  = "usage-request-description"
+	UsageRequestTranslations = "usage-request-translations"
+	UsageRequestMimetype     = "usage-request-mimetype"
+	UsageRequestExamples     = "usage-request-examples"
+	UsageRequestHeaders      = "usage-request-headers"
+	UsageRequestCookies      = "usage-request-cookies"
+	UsageRequestRef          = "usage-request-ref"
+	UsageRequestOneOf        = "usage-request-one-of"
+	UsageRequestAnyOf        = "usage-request-any-of"
+	UsageRequestAllOf        = "usage-request-all-of"
+	UsageRequestXAttributes  = "usage-request-x-attributes"
+	UsageRequestExtensions   = "usage-request-extensions"
+	UsageRequestLinks        = "usage-request-links"
 
 	UsageRichtext     = "usage-richtext"
 	UsageRichtextType = "usage-richtext-type"
 	UsageRichtextText = "usage-richtext-text"
+	UsageRichtextSrc  = "usage-richtext-src"
+
+	UsageTranslation            = "usage-translation"
+	UsageTranslationLang        = "usage-translation-lang"
+	UsageTranslationTitle       = "usage-translation-title"
+	UsageTranslationSummary     = "usage-translation-summary"
+	UsageTranslationDescription = "usage-translation-description"
+
+	UsageExtension      = "usage-extension"
+	UsageExtensionName  = "usage-extension-name"
+	UsageExtensionValue = "usage-extension-value"
+
+	UsageResponseLink            = "usage-response-link"
+	UsageResponseLinkName        = "usage-response-link-name"
+	UsageResponseLinkAPI         = "usage-response-link-api"
+	UsageResponseLinkSummary     = "usage-response-link-summary"
+	UsageResponseLinkDescription = "usage-response-link-description"
+	UsageResponseLinkParams      = "usage-response-link-params"
+
+	UsageResponseLinkParam      = "usage-response-link-param"
+	UsageResponseLinkParamName  = "usage-response-link-param-name"
+	UsageResponseLinkParamField = "usage-response-link-param-field"
+
+	UsageTag             = "usage-tag"
+	UsageTagName         = "usage-tag-name"
+	UsageTagTitle        = "usage-tag-title"
+	UsageTagDeprecated   = "usage-tag-deprecated"
+	UsageTagSince        = "usage-tag-since"
+	UsageTagTranslations = "usage-tag-translations"
+	UsageTagXAttributes  = "usage-tag-x-attributes"
+	UsageTagExtensions   = "usage-tag-extensions"
+
+	UsageTypeDef             = "usage-typedef"
+	UsageTypeDefName         = "usage-typedef-name"
+	UsageTypeDefType         = "usage-typedef-type"
+	UsageTypeDefDeprecated   = "usage-typedef-deprecated"
+	UsageTypeDefItems        = "usage-typedef-items"
+	UsageTypeDefEnums        = "usage-typedef-enums"
+	UsageTypeDefSummary      = "usage-typedef-summary"
+	UsageTypeDefDescription  = "usage-typedef-description"
+	UsageTypeDefTranslations = "usage-typedef-translations"
+
+	UsageComposition              = "usage-composition"
+	UsageCompositionDiscriminator = "usage-composition-discriminator"
+	UsageCompositionItems         = "usage-composition-items"
+
+	UsageServer             = "usage-server"
+	UsageServerName         = "usage-server-name"
+	UsageServerTitle        = "usage-server-title"
+	UsageServerURL          = "usage-server-url"
+	UsageServerDeprecated   = "usage-server-deprecated"
+	UsageServerSince        = "usage-server-since"
+	UsageServerSummary      = "usage-server-summary"
+	UsageServerDescription  = "usage-server-description"
+	UsageServerTranslations = "usage-server-translations"
+	UsageServerVariables    = "usage-server-variables"
+	UsageServerXAttributes  = "usage-server-x-attributes"
+	UsageServerExtensions   = "usage-server-extensions"
+
+	UsageServerVariable        = "usage-server-variable"
+	UsageServerVariableName    = "usage-server-variable-name"
+	UsageServerVariableDefault = "usage-server-variable-default"
+	UsageServerVariableSummary = "usage-server-variable-summary"
+	UsageServerVariableEnums   = "usage-server-variable-enums"
+
+	UsageSecurity             = "usage-security"
+	UsageSecurityName         = "usage-security-name"
+	UsageSecurityType         = "usage-security-type"
+	UsageSecurityScheme       = "usage-security-scheme"
+	UsageSecurityBearerFormat = "usage-security-bearer-format"
+	UsageSecurityIN           = "usage-security-in"
+	UsageSecurityKey          = "usage-security-key"
+	UsageSecuritySummary      = "usage-security-summary"
+	UsageSecurityDescription  = "usage-security-description"
+	UsageSecurityTranslations = "usage-security-translations"
+	UsageSecurityFlows        = "usage-security-flows"
+
+	UsageOAuthFlow                 = "usage-oauth-flow"
+	UsageOAuthFlowType             = "usage-oauth-flow-type"
+	UsageOAuthFlowAuthorizationURL = "usage-oauth-flow-authorization-url"
+	UsageOAuthFlowTokenURL         = "usage-oauth-flow-token-url"
+	UsageOAuthFlowRefreshURL       = "usage-oauth-flow-refresh-url"
+	UsageOAuthFlowScopes           = "usage-oauth-flow-scopes"
 
-	UsageTag           = "usage-tag"
-	UsageTagName       = "usage-tag-name"
-	UsageTagTitle      = "usage-tag-title"
-	UsageTagDeprecated = "usage-tag-deprecated"
+	UsageOAuthScope        = "usage-oauth-scope"
+	UsageOAuthScopeName    = "usage-oauth-scope-name"
+	UsageOAuthScopeSummary = "usage-oauth-scope-summary"
 
-	UsageServer            = "usage-server"
-	UsageServerName        = "usage-server-name"
-	UsageServerTitle       = "usage-server-title"
-	UsageServerURL         = "usage-server-url"
-	UsageServerDeprecated  = "usage-server-deprecated"
-	UsageServerSummary     = "usage-server-summary"
-	UsageServerDescription = "usage-server-description"
+	UsageSecurityValue      = "usage-security-value"
+	UsageSecurityValueScope = "usage-security-value-scope"
 
 	UsageXMLAttr    = "usage-xml-attr"
 	UsageXMLExtract = "usage-xml-extract"
@@ -210,27 +341,46 @@ mock 服务会根据接口定义检测用户提交的数据是否合法，并生
 	// 基本类型
 	UsageString  = "usage-string"
 	UsageNumber  = "usage-number"
+	UsageStatus  = "usage-status"
 	UsageBool    = "usage-bool"
 	UsageVersion = "usage-version"
 	UsageDate    = "usage-date"
 	UsageType    = "usage-type"
 
 	// 以下是有关 build.Config 的字段说明
-	UsageConfigVersion               = "usage-config-version"
-	UsageConfigInputs                = "usage-config-inputs"
-	UsageConfigInputsLang            = "usage-config-inputs.lang"
-	UsageConfigInputsDir             = "usage-config-inputs.dir"
-	UsageConfigInputsExts            = "usage-config-inputs.exts"
-	UsageConfigInputsRecursive       = "usage-config-inputs.recursive"
-	UsageConfigInputsEncoding        = "usage-config-inputs.encoding"
-	UsageConfigInputsIgnores         = "usage-config-inputs.ignores"
-	UsageConfigOutput                = "usage-config-output"
-	UsageConfigOutputType            = "usage-config-output.type"
-	UsageConfigOutputPath            = "usage-config-output.path"
-	UsageConfigOutputTags            = "usage-config-output.tags"
-	UsageConfigOutputStyle           = "usage-config-output.style"
-	UsageConfigOutputNamespace       = "usage-config-output.namespace"
-	UsageConfigOutputNamespacePrefix = "usage-config-output.namespace-prefix"
+	UsageConfigVersion                 = "usage-config-version"
+	UsageConfigInputs                  = "usage-config-inputs"
+	UsageConfigInputsLang              = "usage-config-inputs.lang"
+	UsageConfigInputsDir               = "usage-config-inputs.dir"
+	UsageConfigInputsExts              = "usage-config-inputs.exts"
+	UsageConfigInputsRecursive         = "usage-config-inputs.recursive"
+	UsageConfigInputsEncoding          = "usage-config-inputs.encoding"
+	UsageConfigInputsIgnores           = "usage-config-inputs.ignores"
+	UsageConfigInputsDoc               = "usage-config-inputs.doc"
+	UsageConfigInputsCache             = "usage-config-inputs.cache"
+	UsageConfigInputsGitignore         = "usage-config-inputs.gitignore"
+	UsageConfigInputsFiles             = "usage-config-inputs.files"
+	UsageConfigOutput                  = "usage-config-output"
+	UsageConfigOutputType              = "usage-config-output.type"
+	UsageConfigOutputPath              = "usage-config-output.path"
+	UsageConfigOutputTags              = "usage-config-output.tags"
+	UsageConfigOutputInclude           = "usage-config-output.include"
+	UsageConfigOutputIncludeServers    = "usage-config-output.include.servers"
+	UsageConfigOutputIncludeMethods    = "usage-config-output.include.methods"
+	UsageConfigOutputIncludePaths      = "usage-config-output.include.paths"
+	UsageConfigOutputIncludeIDs        = "usage-config-output.include.ids"
+	UsageConfigOutputIncludeDeprecated = "usage-config-output.include.deprecated"
+	UsageConfigOutputExclude           = "usage-config-output.exclude"
+	UsageConfigOutputDoc               = "usage-config-output.doc"
+	UsageConfigOutputTarget            = "usage-config-output.target"
+	UsageConfigOutputRemoveDeprecated  = "usage-config-output.remove-deprecated"
+	UsageConfigOutputStyle             = "usage-config-output.style"
+	UsageConfigOutputNamespace         = "usage-config-output.namespace"
+	UsageConfigOutputNamespacePrefix   = "usage-config-output.namespace-prefix"
+	UsageConfigOutputMarkdown          = "usage-config-output.markdown"
+	UsageConfigOutputBaseURL           = "usage-config-output.base-url"
+	UsageConfigOutputLocale            = "usage-config-output.locale"
+	UsageConfigOutputs                 = "usage-config-outputs"
 
 	// 错误信息，可能在地方用到
 	ErrInvalidUTF8Character      = "无效的 UTF8 字符"
@@ -254,12 +404,17 @@ mock 服务会根据接口定义检测用户提交的数据是否合法，并生
 	ErrDuplicateValue            = "重复的值"
 	ErrMessage                   = "%s 位于 %s"
 	ErrNotFound                  = "未找到该值"
+	ErrAPINotFoundInDoc          = "文档中不存在 id 为 %s 的 API"
+	ErrParamNotFoundInAPI        = "API 中不存在名为 %s 的参数"
+	ErrStatusOverlap             = "状态码 %s 与 %s 所表示的范围重叠"
 	ErrReadRemoteFile            = "读取远程文件 %s 时返回状态码 %d"
 	ErrServerNotInitialized      = "服务未初始化"
 	ErrInvalidLSPState           = "无效的 LSP 状态"
 	ErrInvalidURIScheme          = "无效的 URI 协议：%s"
 	ErrInvalidURI                = "无效的 URI：%s"
 	ErrFileNotFound              = "未找到文件 %s"
+	ErrCircularReference         = "存在循环引用"
+	ErrUnauthorized              = "缺少有效的身份验证信息"
 
 	// logs
 	InfoPrefix    = "[INFO] "
@@ -29,9 +29,11 @@ mock 服务会根据接口定义检测用户提交的数据是否合法，并生
 	CmdNotFound:    "子命令 %s 未找到\n",
 
 	FlagSyntaxDirUsage:         "以 `URI` 形式表示测试项目地址",
+	FlagSyntaxWatchUsage:       "监视文件的变化，并在变化之后重新检测语法",
 	FlagBuildDirUsage:          "以 `URI` 形式表示的项目地址",
+	FlagBuildWatchUsage:        "监视文件的变化，并在变化之后重新生成文档",
 	FlagMockPortUsage:          "指定 mock 服务的端口号",
-	FlagMockServersUsage:       "指定 mock 服务时，文档中 server 名对应的路由前缀。",
+	FlagMockServersUsage:       "指定 mock 服务时，文档中 server 名对应的路由前缀，“server 名.变量名”可用于指定前缀中服务变量的值。",
 	FlagMockIndentUsage:        "指定缩进内容",
 	FlagMockSliceSizeUsage:     "生成数组大小的范围，格式为 [min,max]。",
 	FlagMockNumSliceUsage:      "生成数值类型的数据时的数值范围，格式为 [min,max]。",
@@ -60,9 +62,15 @@ mock 服务会根据接口定义检测用户提交的数据是否合法，并生
 	FlagVersionKindUsage:       "只显示该类型的版本号，可以是 apidoc、doc、lsp、openapi 和 all",
 
 	VersionInCompatible: "当前程序与配置文件中指定的版本号不兼容",
-	Complete:            "完成！文档保存在：%s，总用时：%v",
+	Complete:            "完成！总用时：%v",
+	OutputSuccess:       "文档已保存至 %s",
 	ConfigWriteSuccess:  "配置内容成功写入 %s",
 	TestSuccess:         "语法没有问题！",
+	WatchStart:          "正在监视 %s 中的文件变化，按 Ctrl+C 退出",
+	WatchRebuild:        "检测到文件变化，已重新解析",
+	WatchAPIAdded:       "新增 API：%s",
+	WatchAPIChanged:     "修改 API：%s",
+	WatchAPIRemoved:     "删除 API：%s",
 	LangID:              "ID",
 	LangName:            "名称",
 	LangExts:            "扩展名",
@@ -75,42 +83,54 @@ mock 服务会根据接口定义检测用户提交的数据是否合法，并生
 	PackFileHeader:      "文档由 %s 自动生成，请勿手动修改！",
 
 	// 文档树中各个字段的介绍
-	UsageAPIDoc:              "用于描述整个文档的相关内容，只能出现一次。",
+	UsageAPIDoc:              "用于描述整个文档的相关内容，同一项目中可以存在多个，通过 id 属性进行区分。",
 	UsageAPIDocAPIDoc:        "文档的版本要号",
+	UsageAPIDocID:            "文档的唯一 ID，同一项目中存在多份文档时，用于区分各个文档。",
 	UsageAPIDocLang:          "文档内容的本地化 ID，比如 <var>zh-Hans</var>、<var>en-US</var> 等。",
 	UsageAPIDocLogo:          "文档的图标，仅可使用 SVG 格式图标。",
 	UsageAPIDocCreated:       "文档的创建时间",
 	UsageAPIDocVersion:       "文档的版本号",
 	UsageAPIDocTitle:         "文档的标题",
 	UsageAPIDocDescription:   "文档的整体描述内容",
+	UsageAPIDocTranslations:  "文档标题及描述内容的其它语言版本",
 	UsageAPIDocContact:       "文档作者的联系方式",
 	UsageAPIDocLicense:       "文档的版权信息",
 	UsageAPIDocTags:          "文档中定义的所有标签",
 	UsageAPIDocServers:       "API 基地址列表，每个 API 最少应该有一个 server。",
+	UsageAPIDocSecurities:    "文档中定义的所有身份验证方案",
 	UsageAPIDocAPIs:          "文档中的 API 文档",
+	UsageAPIDocWebhooks:      "文档中的 webhook，表示不由某一请求触发的回调，需要指定唯一的 name",
 	UsageAPIDocHeaders:       "文档中所有 API 都包含的公共报头",
 	UsageAPIDocResponses:     "文档中所有 API 文档都需要支持的返回内容",
 	UsageAPIDocMimetypes:     "文档所支持的 mimetype",
 	UsageAPIDocXMLNamespaces: "针对 <var>application/xml</var> 类型的内容的命名空间设置",
+	UsageAPIDocTypes:         "可复用的类型定义，可以由 <code>param</code>、<code>request</code> 和 <code>response</code> 的 <code>@ref</code> 引用。",
 
 	UsageXMLNamespace:       "为 <var>application/xml</var> 定义命名空间的相关属性",
 	UsageXMLNamespacePrefix: "命名空间的前缀，如果为空，则表示作为默认命名空间，命局只能有一个默认命名空间。",
 	UsageXMLNamespaceURN:    "命名空间的唯一标识，需要全局唯一，且区分大小写。",
 
-	UsageAPI:            "用于定义单个 API 接口的具体内容",
-	UsageAPIVersion:     "表示此接口在该版本中添加",
-	UsageAPIMethod:      "当前接口所支持的请求方法",
-	UsageAPIID:          "接口的唯一 ID",
-	UsageAPIPath:        "定义路径信息",
-	UsageAPISummary:     "简要介绍",
-	UsageAPIDescription: "该接口的详细介绍，为 HTML 内容。",
-	UsageAPIRequests:    "定义可用的请求信息",
-	UsageAPIResponses:   "定义可能的返回信息",
-	UsageAPICallback:    "定义回调接口内容",
-	UsageAPIDeprecated:  "在此版本之后将会被弃用",
-	UsageAPIHeaders:     "传递的报头内容，如果是某个 mimetype 专用的，可以放在 request 元素中。",
-	UsageAPITags:        "关联的标签",
-	UsageAPIServers:     "关联的服务",
+	UsageAPI:             "用于定义单个 API 接口的具体内容",
+	UsageAPIVersion:      "表示此接口在该版本中添加",
+	UsageAPIMethod:       "当前接口所支持的请求方法",
+	UsageAPIID:           "接口的唯一 ID",
+	UsageAPIDocument:     "接口所属文档的 ID，为空表示由输入项决定，或是在仅有一份文档时归属于该文档。",
+	UsageAPIPath:         "定义路径信息",
+	UsageAPISummary:      "简要介绍",
+	UsageAPIDescription:  "该接口的详细介绍，为 HTML 内容。",
+	UsageAPITranslations: "摘要及详细介绍的其它语言版本",
+	UsageAPIRequests:     "定义可用的请求信息",
+	UsageAPIResponses:    "定义可能的返回信息",
+	UsageAPICallbacks:    "定义回调接口内容，可以有多个，通过 name 区分",
+	UsageAPIDeprecated:   "在此版本之后将会被弃用",
+	UsageAPISince:        "表示从该版本号开始提供此接口",
+	UsageAPIHeaders:      "传递的报头内容，如果是某个 mimetype 专用的，可以放在 request 元素中。",
+	UsageAPICookies:      "传递的 cookie 内容，如果是某个 mimetype 专用的，可以放在 request 元素中。",
+	UsageAPITags:         "关联的标签",
+	UsageAPIServers:      "关联的服务",
+	UsageAPISecurities:   "访问该接口需要的身份验证方案，多个值之间为或的关系。",
+	UsageAPIXAttributes:  "以 <code>x-</code> 开头的扩展属性，会作为 openapi 中 operation 的扩展字段输出。",
+	UsageAPIExtensions:   "复杂类型的扩展内容，会作为 openapi 中 operation 的扩展字段输出。",
 
 	UsageLink:     "用于描述链接信息，一般转换为 HTML 的 <code>a</code> 标签。",
 	UsageLinkText: "链接的字面文字",
@@ -121,75 +141,186 @@ mock 服务会根据接口定义检测用户提交的数据是否合法，并生
 	UsageContactURL:   "联系人的 URL",
 	UsageContactEmail: "联系人的电子邮件",
 
-	UsageCallback:            "定义接口的回调内容",
-	UsageCallbackMethod:      "回调的请求方法",
-	UsageCallbackPath:        "回调的请求地址",
-	UsageCallbackSummary:     "简要介绍",
-	UsageCallbackDeprecated:  "在此版本之后将会被弃用",
-	UsageCallbackDescription: "对于回调的详细介绍",
-	UsageCallbackResponses:   "定义可能的返回信息",
-	UsageCallbackRequests:    "定义可用的请求信息",
-	UsageCallbackHeaders:     "传递的报头内容",
-
-	UsageEnum:            "定义枚举类型的数所的枚举值",
-	UsageEnumDeprecated:  "该属性弃用的版本号",
-	UsageEnumValue:       "枚举值",
-	UsageEnumSummary:     "枚举值的说明",
-	UsageEnumDescription: "枚举值的详细说明",
+	UsageCallback:             "定义接口的回调内容",
+	UsageCallbackMethod:       "回调的请求方法",
+	UsageCallbackName:         "回调的名称，在同一 API 或是文档的 webhook 中需要唯一",
+	UsageCallbackExpression:   "回调的目标地址，可以是 {$request.body#/url} 形式的表达式，为空时采用 path 的值，两者都为空时无法导出到 openapi",
+	UsageCallbackPath:         "回调的请求地址",
+	UsageCallbackSummary:      "简要介绍",
+	UsageCallbackDeprecated:   "在此版本之后将会被弃用",
+	UsageCallbackDescription:  "对于回调的详细介绍",
+	UsageCallbackTranslations: "摘要及详细介绍的其它语言版本",
+	UsageCallbackResponses:    "定义可能的返回信息",
+	UsageCallbackRequests:     "定义可用的请求信息",
+	UsageCallbackHeaders:      "传递的报头内容",
+
+	UsageEnum:             "定义枚举类型的数所的枚举值",
+	UsageEnumDeprecated:   "该属性弃用的版本号",
+	UsageEnumSince:        "该枚举值开始启用的版本号",
+	UsageEnumValue:        "枚举值",
+	UsageEnumSummary:      "枚举值的说明",
+	UsageEnumDescription:  "枚举值的详细说明",
+	UsageEnumTranslations: "摘要及详细说明的其它语言版本",
 
 	UsageExample:         "示例代码",
 	UsageExampleMimetype: "特定于类型的示例代码",
 	UsageExampleSummary:  "示例代码的概要信息",
+	UsageExampleSrc:      "从外部文件加载示例代码的内容，路径相对于当前文件，找不到时再相对于 <code>inputs.dir</code>，不能是绝对路径或是指向这两个目录之外的文件。已有内容时忽略该属性。",
 	UsageExampleContent:  "示例代码的内容，需要使用 CDATA 包含代码。",
 
-	UsageParam:            "参数类型，基本上可以作为 request 的子集使用。",
-	UsageParamName:        "值的名称",
-	UsageParamType:        "值的类型",
-	UsageParamDeprecated:  "表示在大于等于该版本号时不再启作用",
-	UsageParamDefault:     "默认值",
-	UsageParamOptional:    "是否为可选的参数",
-	UsageParamArray:       "是否为数组",
-	UsageParamItems:       "子类型，比如对象的子元素。",
-	UsageParamSummary:     "简要介绍",
-	UsageParamEnums:       "当前参数可用的枚举值",
-	UsageParamDescription: "详细介绍，为 HTML 内容。",
-	UsageParamArrayStyle:  "以数组的方式展示数据",
+	UsageParam:             "参数类型，基本上可以作为 request 的子集使用。",
+	UsageParamName:         "值的名称",
+	UsageParamType:         "值的类型",
+	UsageParamDeprecated:   "表示在大于等于该版本号时不再启作用",
+	UsageParamSince:        "表示在大于等于该版本号时才启作用",
+	UsageParamDefault:      "默认值，必须与 type 指定的类型相符。数组以 <code>[</code> 和 <code>]</code> 包含，元素之间以逗号分隔，比如 <code>[normal,lock]</code>，也可以是 JSON 格式的数组，未以 <code>[]</code> 包含的值则作为数组的唯一元素。",
+	UsageParamOptional:     "是否为可选的参数",
+	UsageParamArray:        "是否为数组",
+	UsageParamItems:        "子类型，比如对象的子元素。",
+	UsageParamSummary:      "简要介绍",
+	UsageParamEnums:        "当前参数可用的枚举值",
+	UsageParamDescription:  "详细介绍，为 HTML 内容。",
+	UsageParamTranslations: "摘要及详细介绍的其它语言版本",
+	UsageParamArrayStyle:   "以数组的方式展示数据",
+	UsageParamXAttributes:  "以 <code>x-</code> 开头的扩展属性，会作为 openapi 中参数或是 schema 的扩展字段输出。",
+	UsageParamExtensions:   "复杂类型的扩展内容，会作为 openapi 中参数或是 schema 的扩展字段输出。",
+	UsageParamRef:          "引用 <code>apidoc/type</code> 中定义的类型，当前元素未指定的值会从该类型中获取。",
+	UsageParamOneOf:        "只能匹配其中一个分支的组合类型",
+	UsageParamAnyOf:        "至少匹配其中一个分支的组合类型",
+	UsageParamAllOf:        "需要同时匹配所有分支的组合类型",
+	UsageParamMin:          "数值的最小值，仅对数值类型有效。",
+	UsageParamMax:          "数值的最大值，仅对数值类型有效。",
+	UsageParamExclusiveMin: "是否不包含 <code>min</code> 指定的值",
+	UsageParamExclusiveMax: "是否不包含 <code>max</code> 指定的值",
+	UsageParamMinLength:    "字符串的最小长度，仅对字符串类型有效。",
+	UsageParamMaxLength:    "字符串的最大长度，仅对字符串类型有效。",
+	UsageParamPattern:      "字符串需要匹配的正则表达式，仅对字符串类型有效。",
+	UsageParamMinItems:     "数组的最小长度，仅在 <code>array</code> 为 true 时有效。",
+	UsageParamMaxItems:     "数组的最大长度，仅在 <code>array</code> 为 true 时有效。",
+	UsageParamUniqueItems:  "数组元素是否不能重复，仅在 <code>array</code> 为 true 时有效。",
 
 	UsagePath:        "用于定义请求时与路径相关的内容",
-	UsagePathPath:    "接口地址",
+	UsagePathPath:    "接口地址，路径参数以 <code>{name}</code> 表示，也可以采用 <code>{name:pattern}</code> 的形式指定参数需要匹配的正则表达式。",
 	UsagePathParams:  "地址中的参数",
 	UsagePathQueries: "地址中的查询参数",
 
-	UsageRequest:            "定义了请求和返回的相关内容",
-	UsageRequestName:        "当 mimetype 为 <var>application/xml</var> 时，此值表示 XML 的顶层元素名称，否则无用。",
-	UsageRequestType:        "值的类型",
-	UsageRequestDeprecated:  "表示在大于等于该版本号时不再启作用",
-	UsageRequestArray:       "是否为数组",
-	UsageRequestItems:       "子类型，比如对象的子元素。",
-	UsageRequestSummary:     "简要介绍",
-	UsageRequestStatus:      "状态码。在 request 中，该值不可用，否则为必填项。",
-	UsageRequestEnums:       "当前参数可用的枚举值",
-	UsageRequestDescription: "详细介绍，为 HTML 内容。",
-	UsageRequestMimetype:    "媒体类型，比如 <var>application/json</var> 等。",
-	UsageRequestExamples:    "示例代码",
-	UsageRequestHeaders:     "传递的报头内容",
+	UsageRequest:             "定义了请求和返回的相关内容",
+	UsageRequestName:         "当 mimetype 为 <var>application/xml</var> 时，此值表示 XML 的顶层元素名称，否则无用。",
+	UsageRequestType:         "值的类型",
+	UsageRequestDeprecated:   "表示在大于等于该版本号时不再启作用",
+	UsageRequestSince:        "表示在大于等于该版本号时才启作用",
+	UsageRequestArray:        "是否为数组",
+	UsageRequestItems:        "子类型，比如对象的子元素。",
+	UsageRequestSummary:      "简要介绍",
+	UsageRequestStatus:       "状态码。在 request 中，该值不可用，否则为必填项。",
+	UsageRequestEnums:        "当前参数可用的枚举值",
+	UsageRequestDescription:  "详细介绍，为 HTML 内容。",
+	UsageRequestTranslations: "摘要及详细介绍的其它语言版本",
+	UsageRequestMimetype:     "媒体类型，比如 <var>application/json</var> 等。",
+	UsageRequestExamples:     "示例代码",
+	UsageRequestHeaders:      "传递的报头内容",
+	UsageRequestCookies:      "传递的 cookie 内容，在返回内容中表示由报头 Set-Cookie 设置的值。",
+	UsageRequestRef:          "引用 <code>apidoc/type</code> 中定义的类型，当前元素未指定的值会从该类型中获取。",
+	UsageRequestOneOf:        "只能匹配其中一个分支的组合类型",
+	UsageRequestAnyOf:        "至少匹配其中一个分支的组合类型",
+	UsageRequestAllOf:        "需要同时匹配所有分支的组合类型",
+	UsageRequestXAttributes:  "以 <code>x-</code> 开头的扩展属性，会作为 openapi 中 media type 的扩展字段输出。",
+	UsageRequestExtensions:   "复杂类型的扩展内容，会作为 openapi 中 media type 的扩展字段输出。",
+	UsageRequestLinks:        "返回内容与其它 API 之间的关联，仅在 response 中有效",
 
 	UsageRichtext:     "富文本内容",
 	UsageRichtextType: "指定富文本内容的格式，目前支持 <var>html</var> 和 <var>markdown</var>。",
 	UsageRichtextText: "富文本的实际内容",
+	UsageRichtextSrc:  "从外部文件加载富文本的内容，路径相对于当前文件，找不到时再相对于 <code>inputs.dir</code>，不能是绝对路径或是指向这两个目录之外的文件。已有内容时忽略该属性。",
+
+	UsageTranslation:            "其它语言的翻译内容，在输出指定语言的文档时，用于替换所在元素中对应的内容。",
+	UsageTranslationLang:        "翻译内容的语言，应该使用 BCP47 指定的格式，同一元素中不能重复。",
+	UsageTranslationTitle:       "替换所在元素的标题",
+	UsageTranslationSummary:     "替换所在元素的摘要",
+	UsageTranslationDescription: "替换所在元素的详细描述",
+
+	UsageExtension:      "扩展内容，可以表示复杂类型的值，内容为 JSON 格式。",
+	UsageExtensionName:  "扩展内容的名称，必须以 <code>x-</code> 开头，且不能与同一元素中的其它扩展内容重名。",
+	UsageExtensionValue: "JSON 格式的扩展内容",
+
+	UsageResponseLink:            "描述返回内容与其它 API 之间的关联，比如通过返回的 id 获取详细信息的 API",
+	UsageResponseLinkName:        "链接的名称，在同一个返回内容中需要唯一",
+	UsageResponseLinkAPI:         "目标 API 的 id，只能指向同一文档中的 API",
+	UsageResponseLinkSummary:     "链接的摘要",
+	UsageResponseLinkDescription: "链接的详细说明",
+	UsageResponseLinkParams:      "目标 API 中各个参数的取值",
+
+	UsageResponseLinkParam:      "指定目标 API 中某一参数的值",
+	UsageResponseLinkParamName:  "目标 API 中的参数名称，可以是路径参数、查询参数、报头或是 cookie",
+	UsageResponseLinkParamField: "参数值对应的返回字段，多层之间以 . 分隔，比如 data.id",
+
+	UsageTag:             "用于对各个 API 进行分类",
+	UsageTagName:         "标签的唯一 ID",
+	UsageTagTitle:        "标签的字面名称",
+	UsageTagDeprecated:   "该标签在大于该版本时被弃用",
+	UsageTagSince:        "该标签开始启用的版本号",
+	UsageTagTranslations: "标签名称的其它语言版本",
+	UsageTagXAttributes:  "以 <code>x-</code> 开头的扩展属性，会作为 openapi 中标签的扩展字段输出。",
+	UsageTagExtensions:   "复杂类型的扩展内容，会作为 openapi 中标签的扩展字段输出。",
+
+	UsageTypeDef:             "可复用的类型定义",
+	UsageTypeDefName:         "类型的唯一名称，供 <code>@ref</code> 引用。",
+	UsageTypeDefType:         "值的类型",
+	UsageTypeDefDeprecated:   "表示在大于等于该版本号时不再启作用",
+	UsageTypeDefItems:        "子类型，比如对象的子元素。",
+	UsageTypeDefEnums:        "当前类型可用的枚举值",
+	UsageTypeDefSummary:      "简要介绍",
+	UsageTypeDefDescription:  "详细介绍，为 HTML 内容。",
+	UsageTypeDefTranslations: "摘要及详细介绍的其它语言版本",
+
+	UsageComposition:              "组合类型，每一个 <code>param</code> 子元素表示一个分支。",
+	UsageCompositionDiscriminator: "用于区分分支的属性名称，该属性的值即为分支的 <code>@name</code>。",
+	UsageCompositionItems:         "组合类型的各个分支",
+
+	UsageServer:             "用于指定各个 API 的服务器地址",
+	UsageServerName:         "服务唯一 ID",
+	UsageServerTitle:        "服务的字面名称",
+	UsageServerURL:          "服务的基地址，与该服务关联的 API，访问地址都是相对于此地址的。",
+	UsageServerDeprecated:   "服务在大于该版本时被弃用",
+	UsageServerSince:        "服务开始启用的版本号",
+	UsageServerSummary:      "服务的摘要信息",
+	UsageServerDescription:  "服务的详细描述",
+	UsageServerTranslations: "摘要及详细描述的其它语言版本",
+	UsageServerVariables:    "服务地址中 <code>{name}</code> 形式的变量，每个变量都需要在地址中使用。",
+	UsageServerXAttributes:  "以 <code>x-</code> 开头的扩展属性，会作为 openapi 中服务的扩展字段输出。",
+	UsageServerExtensions:   "复杂类型的扩展内容，会作为 openapi 中服务的扩展字段输出。",
+
+	UsageServerVariable:        "定义服务地址中的变量",
+	UsageServerVariableName:    "变量名称，对应服务地址中的 <code>{name}</code>。",
+	UsageServerVariableDefault: "变量的默认值，如果指定了枚举值，则必须是其中之一。",
+	UsageServerVariableSummary: "变量的摘要信息",
+	UsageServerVariableEnums:   "变量的可选值",
+
+	UsageSecurity:             "定义身份验证方案",
+	UsageSecurityName:         "身份验证方案的唯一 ID",
+	UsageSecurityType:         "身份验证方案的类型，可以是 <var>http</var>、<var>apikey</var> 和 <var>oauth2</var>。",
+	UsageSecurityScheme:       "<code>@type</code> 为 <var>http</var> 时的验证方案，比如 <var>basic</var>、<var>bearer</var> 等。",
+	UsageSecurityBearerFormat: "<code>@scheme</code> 为 <var>bearer</var> 时令牌的格式，比如 <var>JWT</var>。",
+	UsageSecurityIN:           "<code>@type</code> 为 <var>apikey</var> 时令牌的位置，可以是 <var>header</var>、<var>query</var> 和 <var>cookie</var>。",
+	UsageSecurityKey:          "<code>@type</code> 为 <var>apikey</var> 时令牌在报头、查询参数或是 cookie 中的名称",
+	UsageSecuritySummary:      "身份验证方案的摘要信息",
+	UsageSecurityDescription:  "身份验证方案的详细描述",
+	UsageSecurityTranslations: "摘要及详细描述的其它语言版本",
+	UsageSecurityFlows:        "<code>@type</code> 为 <var>oauth2</var> 时支持的授权流程",
+
+	UsageOAuthFlow:                 "定义 OAuth2 的授权流程",
+	UsageOAuthFlowType:             "授权流程的类型，可以是 <var>implicit</var>、<var>password</var>、<var>client-credentials</var> 和 <var>authorization-code</var>。",
+	UsageOAuthFlowAuthorizationURL: "授权地址，<var>implicit</var> 和 <var>authorization-code</var> 必须指定该值。",
+	UsageOAuthFlowTokenURL:         "获取令牌的地址，<var>password</var>、<var>client-credentials</var> 和 <var>authorization-code</var> 必须指定该值。",
+	UsageOAuthFlowRefreshURL:       "刷新令牌的地址",
+	UsageOAuthFlowScopes:           "授权流程中可用的权限",
 
-	UsageTag:           "用于对各个 API 进行分类",
-	UsageTagName:       "标签的唯一 ID",
-	UsageTagTitle:      "标签的字面名称",
-	UsageTagDeprecated: "该标签在大于该版本时被弃用",
+	UsageOAuthScope:        "定义 OAuth2 的权限",
+	UsageOAuthScopeName:    "权限的名称",
+	UsageOAuthScopeSummary: "权限的简要介绍",
 
-	UsageServer:            "用于指定各个 API 的服务器地址",
-	UsageServerName:        "服务唯一 ID",
-	UsageServerTitle:       "服务的字面名称",
-	UsageServerURL:         "服务的基地址，与该服务关联的 API，访问地址都是相对于此地址的。",
-	UsageServerDeprecated:  "服务在大于该版本时被弃用",
-	UsageServerSummary:     "服务的摘要信息",
-	UsageServerDescription: "服务的详细描述",
+	UsageSecurityValue:      "引用 <code>apidoc/security</code> 中定义的身份验证方案，内容为方案的 ID。",
+	UsageSecurityValueScope: "以空格分隔的权限列表，仅对 <var>oauth2</var> 有效。",
 
 	UsageXMLAttr:    "是否作为父元素的属性，仅作用于 XML 元素。是否作为父元素的属性，仅用于 XML 的请求。",
 	UsageXMLExtract: "将当前元素的内容作为父元素的内容，要求父元素必须为 <var>object</var>。",
@@ -205,6 +336,7 @@ mock 服务会根据接口定义检测用户提交的数据是否合法，并生
 	// 基本类型
 	UsageString:  "普通的字符串类型，特殊字符需要使用 XML 实体，比如 <samp>&lt;</samp> 需要使用 <samp>&amp;lt;</samp> 代替。",
 	UsageNumber:  "普通的数值类型，比如：<samp>1</samp>、<samp>-11.1</samp> 等。",
+	UsageStatus:  "状态码，可以是 100 至 511 之间的数值，或是 1XX 至 5XX 表示某一范围内的状态码，default 表示其它未定义的状态码",
 	UsageBool:    "布尔值类型，取值为 <var>true</var> 或是 <var>false</var>。",
 	UsageVersion: `版本号，格式遵守 <a href="https://semver.org/lang/zh-CN/">semver</a> 规则。比如：<samp>1.0.1</samp>、<samp>1.0.1+20200618</samp>。`,
 	UsageDate:    `采用 <a href="https://tools.ietf.org/html/rfc3339">RFC3339</a> 格式表示的时间，比如：<samp>2019-12-16T00:35:48+08:00</samp>。`,
@@ -216,6 +348,9 @@ mock 服务会根据接口定义检测用户提交的数据是否合法，并生
 	<li><var>number</var> 数值类型；</li>
 	<li><var>number.int</var> 整数类型的数值；</li>
 	<li><var>number.float</var> 浮点类型的数值；</li>
+	<li><var>number.int32</var> 32 位的整数；</li>
+	<li><var>number.int64</var> 64 位的整数；</li>
+	<li><var>number.double</var> 双精度的浮点数；</li>
 	<li><var>string</var> 字符串；</li>
 	<li><var>string.url</var> URL 类型的字符串；</li>
 	<li><var>string.email</var> email 类型的字符串；</li>
@@ -223,24 +358,49 @@ mock 服务会根据接口定义检测用户提交的数据是否合法，并生
 	<li><var>string.date</var> 表示 <a href="https://tools.ietf.org/html/rfc3339#section-5.6">RFC3339</a> 中的 <code>full-date</code> 日期格式，比如 <samp>2020-01-02</samp>；</li>
 	<li><var>string.time</var> 表示 <a href="https://tools.ietf.org/html/rfc3339#section-5.6">RFC3339</a> 中的 <code>full-time</code> 时间格式，比如 <samp>15:16:17Z</samp>、<samp>15:16:17+08:00</samp>；</li>
 	<li><var>string.date-time</var> 表示 <a href="https://tools.ietf.org/html/rfc3339#section-5.6">RFC3339</a> 中的 <code>date-time</code> 格式，比如 <samp>2020-01-02T15:16:17-08:00</samp>；</li>
+	<li><var>string.uuid</var> 表示 <a href="https://tools.ietf.org/html/rfc4122">RFC4122</a> 中的 UUID，比如 <samp>123e4567-e89b-12d3-a456-426614174000</samp>；</li>
+	<li><var>string.ipv4</var> IPv4 地址；</li>
+	<li><var>string.ipv6</var> IPv6 地址；</li>
+	<li><var>string.hostname</var> 表示 <a href="https://tools.ietf.org/html/rfc1123#section-2.1">RFC1123</a> 中的主机名；</li>
+	<li><var>string.byte</var> base64 编码的内容；</li>
+	<li><var>string.binary</var> 二进制内容，比如上传的文件；</li>
+	<li><var>string.password</var> 密码，客户端在展示时应该作隐藏处理；</li>
 	</ul>`,
 
 	// 以下是有关 build.Config 的字段说明
-	UsageConfigVersion:               "此配置文件的所使用的文档版本",
-	UsageConfigInputs:                "指定输入的数据，同一项目只能解析一种语言。",
-	UsageConfigInputsLang:            "源文件的解析方式。具体支持的类型可通过命令 <samp>apidoc lang</samp> 查看支持语言。",
-	UsageConfigInputsDir:             "需要解析的源文件所在目录",
-	UsageConfigInputsExts:            "只从这些扩展名的文件中查找文档",
-	UsageConfigInputsRecursive:       "是否解析子目录下的源文件",
-	UsageConfigInputsEncoding:        `编码，默认为 <var>utf-8</var>，值可以是 <a href="https://www.iana.org/assignments/character-sets/character-sets.xhtml">character-sets</a> 中的内容。`,
-	UsageConfigInputsIgnores:         "忽略的文件或目录，比如 node_modules 等。",
-	UsageConfigOutput:                "控制输出行为",
-	UsageConfigOutputType:            "输出的类型，目前可以 <var>apidoc+xml</var>、<var>openapi+json</var> 和 <var>openapi+yaml</var>。",
-	UsageConfigOutputPath:            "指定输出的文件名，包含路径信息。",
-	UsageConfigOutputTags:            "只输出与这些标签相关联的文档，默认为全部。",
-	UsageConfigOutputStyle:           "为 XML 文件指定的 XSL 文件",
-	UsageConfigOutputNamespace:       "是否输出命名空间",
-	UsageConfigOutputNamespacePrefix: "如果输出了命名空间，还可以指定命名空间前缀。",
+	UsageConfigVersion:                 "此配置文件的所使用的文档版本",
+	UsageConfigInputs:                  "指定输入的数据，同一项目只能解析一种语言。",
+	UsageConfigInputsLang:              "源文件的解析方式。具体支持的类型可通过命令 <samp>apidoc lang</samp> 查看支持语言。",
+	UsageConfigInputsDir:               "需要解析的源文件所在目录",
+	UsageConfigInputsExts:              "只从这些扩展名的文件中查找文档",
+	UsageConfigInputsRecursive:         "是否解析子目录下的源文件",
+	UsageConfigInputsEncoding:          `编码，默认为 <var>utf-8</var>，值可以是 <a href="https://www.iana.org/assignments/character-sets/character-sets.xhtml">character-sets</a> 中的内容。`,
+	UsageConfigInputsIgnores:           "忽略的文件或目录，采用 .gitignore 的语法，支持 <code>**</code>、以 <code>/</code> 结尾的目录以及以 <code>!</code> 开头的取反规则，比如 node_modules、<code>**/testdata/**</code> 等。",
+	UsageConfigInputsDoc:               "该输入项中未指定 doc 属性的 api 所属的文档 ID",
+	UsageConfigInputsCache:             "缓存目录，指定后会以源文件的内容作为依据缓存从中提取的注释块，未发生变化的文件不会被再次解析。程序版本或是 <var>lang</var>、<var>exts</var>、<var>encoding</var> 有变化时缓存自动失效。",
+	UsageConfigInputsGitignore:         "是否同时采用 <var>dir</var> 及其子目录中 .gitignore 文件中的规则忽略文件，规则仅对文件所在的目录有效，且优先级低于 <var>ignores</var>。",
+	UsageConfigInputsFiles:             "需要解析的文件，采用 .gitignore 的语法。指定之后不再根据 <var>exts</var> 查找文件，<var>recursive</var> 和 <var>ignores</var> 依然有效。",
+	UsageConfigOutput:                  "控制输出行为",
+	UsageConfigOutputType:              "输出的类型，目前可以 <var>apidoc+xml</var>、<var>openapi+json</var> 和 <var>openapi+yaml</var>。",
+	UsageConfigOutputPath:              "指定输出的文件名，包含路径信息。",
+	UsageConfigOutputTags:              "只输出与这些标签相关联的文档，默认为全部。",
+	UsageConfigOutputInclude:           "只输出符合这些条件的 API。各字段之间需要同时满足，同一字段中的多个值只需满足其一，与 <var>tags</var> 同时指定时，需要同时满足两者。指定了过滤条件之后，未被 API 引用的标签和服务也不会被输出。",
+	UsageConfigOutputIncludeServers:    "服务的名称，API 中的其它服务也不会被输出。",
+	UsageConfigOutputIncludeMethods:    "请求方法，不区分大小写。",
+	UsageConfigOutputIncludePaths:      "路径，可以使用通配符，比如 <code>/users/*</code>。",
+	UsageConfigOutputIncludeIDs:        "API 的 ID",
+	UsageConfigOutputIncludeDeprecated: "是否为已弃用的 API，即指定了 <var>deprecated</var> 属性的 API。",
+	UsageConfigOutputExclude:           "不输出符合这些条件的 API，字段与 <var>include</var> 相同，优先级高于 <var>tags</var> 和 <var>include</var>。",
+	UsageConfigOutputDoc:               "只输出该 ID 的文档。默认为每份文档都输出一个文件，文件名为在 <var>path</var> 的扩展名之前插入文档的 ID。",
+	UsageConfigOutputTarget:            "只输出该版本号中可用的内容，<var>since</var> 晚于该版本号的内容都将被忽略。默认为输出所有内容。",
+	UsageConfigOutputRemoveDeprecated:  "是否删除在 <var>target</var> 之前的版本就已经被弃用的内容，仅在指定了 <var>target</var> 时有效。",
+	UsageConfigOutputStyle:             "为 XML 文件指定的 XSL 文件",
+	UsageConfigOutputNamespace:         "是否输出命名空间",
+	UsageConfigOutputNamespacePrefix:   "如果输出了命名空间，还可以指定命名空间前缀。",
+	UsageConfigOutputMarkdown:          "是否将 <var>markdown</var> 格式的富文本转换成 HTML，转换后的内容会过滤掉其中的 HTML 代码以及不安全的链接。链接地址可以使用 <code>api:id</code> 的形式指向文档中指定 <var>id</var> 的 API，无效的链接会以警告的形式输出。",
+	UsageConfigOutputBaseURL:           "<var>markdown</var> 中相对链接的基地址，必须是绝对地址，仅在 <var>markdown</var> 为 <var>true</var> 时有效。",
+	UsageConfigOutputLocale:            "输出文档的语言，应该使用 BCP47 指定的格式。文档中与之匹配的 <code>translation</code> 会替换原有内容，若为空，则原样输出所有语言的内容。",
+	UsageConfigOutputs:                 "多个输出项，每一项的字段与 <var>output</var> 相同。源文件只会解析一次，之后按各输出项的配置分别输出，可以与 <var>output</var> 同时使用，但输出的路径不能相同。",
 
 	// 错误信息，可能在地方用到
 	ErrInvalidUTF8Character:      "无效的 UTF8 字符",
@@ -264,12 +424,17 @@ mock 服务会根据接口定义检测用户提交的数据是否合法，并生
 	ErrDuplicateValue:            "重复的值",
 	ErrMessage:                   "%s 位于 %s",
 	ErrNotFound:                  "未找到该值",
+	ErrAPINotFoundInDoc:          "文档中不存在 id 为 %s 的 API",
+	ErrParamNotFoundInAPI:        "API 中不存在名为 %s 的参数",
+	ErrStatusOverlap:             "状态码 %s 与 %s 所表示的范围重叠",
 	ErrReadRemoteFile:            "读取远程文件 %s 时返回状态码 %d",
 	ErrServerNotInitialized:      "服务未初始化",
 	ErrInvalidLSPState:           "无效的 LSP 状态",
 	ErrInvalidURIScheme:          "无效的 URI 协议：%s",
 	ErrInvalidURI:                "无效的 URI：%s",
 	ErrFileNotFound:              "未找到文件 %s",
+	ErrCircularReference:         "存在循环引用",
+	ErrUnauthorized:              "缺少有效的身份验证信息",
 
 	// logs
 	InfoPrefix:    "[信息] ",
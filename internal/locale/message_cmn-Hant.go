@@ -29,9 +29,11 @@ mock 服務會根據接口定義檢測用戶提交的數據是否合法，並生
 	CmdNotFound:    "子命令 %s 未找到\n",
 
 	FlagSyntaxDirUsage:         "以 `URI` 形式表示的測試項目地址",
+	FlagSyntaxWatchUsage:       "監視文件的變化，並在變化之後重新檢測語法",
 	FlagBuildDirUsage:          "以 `URI` 形式表示的項目地址",
+	FlagBuildWatchUsage:        "監視文件的變化，並在變化之後重新生成文檔",
 	FlagMockPortUsage:          "指定 mock 服務的端口號",
-	FlagMockServersUsage:       "指定 mock 服務時，文檔中 server 名對應的路由前綴。",
+	FlagMockServersUsage:       "指定 mock 服務時，文檔中 server 名對應的路由前綴，“server 名.變量名”可用於指定前綴中服務變量的值。",
 	FlagMockIndentUsage:        "指定縮進內容",
 	FlagMockSliceSizeUsage:     "生成數組大小的範圍，格式為 [min,max]。",
 	FlagMockNumSliceUsage:      "生成數值類型的數據時的數值範圍，格式為 [min,max]。",
@@ -60,9 +62,15 @@ mock 服務會根據接口定義檢測用戶提交的數據是否合法，並生
 	FlagVersionKindUsage:       "只顯示該類型的版本號，可以是 apidoc、doc、lsp、openapi 和 all",
 
 	VersionInCompatible: "當前程序與配置文件中指定的版本號不兼容",
-	Complete:            "完成！文檔保存在：%s，總用時：%v",
+	Complete:            "完成！總用時：%v",
+	OutputSuccess:       "文檔已保存至 %s",
 	ConfigWriteSuccess:  "配置內容成功寫入 %s",
 	TestSuccess:         "語法沒有問題！",
+	WatchStart:          "正在監視 %s 中的文件變化，按 Ctrl+C 退出",
+	WatchRebuild:        "檢測到文件變化，已重新解析",
+	WatchAPIAdded:       "新增 API：%s",
+	WatchAPIChanged:     "修改 API：%s",
+	WatchAPIRemoved:     "刪除 API：%s",
 	LangID:              "ID",
 	LangName:            "名稱",
 	LangExts:            "擴展名",
@@ -75,42 +83,54 @@ mock 服務會根據接口定義檢測用戶提交的數據是否合法，並生
 	PackFileHeader:      "文檔由 %s 自動生成，請勿手動修改！",
 
 	// 文檔樹中各個字段的介紹
-	UsageAPIDoc:              "用於描述整個文檔的相關內容，只能出現壹次。",
+	UsageAPIDoc:              "用於描述整個文檔的相關內容，同壹項目中可以存在多個，通過 id 屬性進行區分。",
 	UsageAPIDocAPIDoc:        "文檔的版本要號",
+	UsageAPIDocID:            "文檔的唯壹 ID，同壹項目中存在多份文檔時，用於區分各個文檔。",
 	UsageAPIDocLang:          "文檔內容的本地化 ID，比如 <var>zh-Hans</var>、<var>en-US</var> 等。",
 	UsageAPIDocLogo:          "文檔的圖標，僅可使用 SVG 格式圖標。",
 	UsageAPIDocCreated:       "文檔的創建時間",
 	UsageAPIDocVersion:       "文檔的版本號",
 	UsageAPIDocTitle:         "文檔的標題",
 	UsageAPIDocDescription:   "文檔的整體描述內容",
+	UsageAPIDocTranslations:  "文檔標題及描述內容的其它語言版本",
 	UsageAPIDocContact:       "文檔作者的聯系方式",
 	UsageAPIDocLicense:       "文檔的版權信息",
 	UsageAPIDocTags:          "文檔中定義的所有標簽",
 	UsageAPIDocServers:       "API 基地址列表，每個 API 最少應該有壹個 server。",
+	UsageAPIDocSecurities:    "文檔中定義的所有身份驗證方案",
 	UsageAPIDocAPIs:          "文檔中的 API 文檔",
+	UsageAPIDocWebhooks:      "文檔中的 webhook，表示不由某一請求觸發的回調，需要指定唯一的 name",
 	UsageAPIDocHeaders:       "文檔中所有 API 都包含的公共報頭",
 	UsageAPIDocResponses:     "文檔中所有 API 文檔都需要支持的返回內容",
 	UsageAPIDocMimetypes:     "文檔所支持的 mimetype",
 	UsageAPIDocXMLNamespaces: "針對 <var>application/xml</var> 類型的內容的命名空間設置",
+	UsageAPIDocTypes:         "可復用的類型定義，可以由 <code>param</code>、<code>request</code> 和 <code>response</code> 的 <code>@ref</code> 引用。",
 
 	UsageXMLNamespace:       "為 <var>application/xml</var> 定義命名空間的相關屬性",
 	UsageXMLNamespacePrefix: "命名空間的前綴，如果為空，則表示作為默認命名空間，命局只能有壹個默認命名空間。",
 	UsageXMLNamespaceURN:    "命名空間的唯壹標識，需要全局唯壹，且區分大小寫。",
 
-	UsageAPI:            "用於定義單個 API 接口的具體內容",
-	UsageAPIVersion:     "表示此接口在該版本中添加",
-	UsageAPIMethod:      "當前接口所支持的請求方法",
-	UsageAPIID:          "接口的唯壹 ID",
-	UsageAPIPath:        "定義路徑信息",
-	UsageAPISummary:     "簡要介紹",
-	UsageAPIDescription: "該接口的詳細介紹，為 HTML 內容。",
-	UsageAPIRequests:    "定義可用的請求信息",
-	UsageAPIResponses:   "定義可能的返回信息",
-	UsageAPICallback:    "定義回調接口內容",
-	UsageAPIDeprecated:  "在此版本之後將會被棄用",
-	UsageAPIHeaders:     "傳遞的報頭內容，如果是某個 mimetype 專用的，可以放在 request 元素中。",
-	UsageAPITags:        "關聯的標簽",
-	UsageAPIServers:     "關聯的服務",
+	UsageAPI:             "用於定義單個 API 接口的具體內容",
+	UsageAPIVersion:      "表示此接口在該版本中添加",
+	UsageAPIMethod:       "當前接口所支持的請求方法",
+	UsageAPIID:           "接口的唯壹 ID",
+	UsageAPIDocument:     "接口所屬文檔的 ID，為空表示由輸入項決定，或是在僅有壹份文檔時歸屬於該文檔。",
+	UsageAPIPath:         "定義路徑信息",
+	UsageAPISummary:      "簡要介紹",
+	UsageAPIDescription:  "該接口的詳細介紹，為 HTML 內容。",
+	UsageAPITranslations: "摘要及詳細介紹的其它語言版本",
+	UsageAPIRequests:     "定義可用的請求信息",
+	UsageAPIResponses:    "定義可能的返回信息",
+	UsageAPICallbacks:    "定義回調接口內容，可以有多個，通過 name 區分",
+	UsageAPIDeprecated:   "在此版本之後將會被棄用",
+	UsageAPISince:        "表示從該版本號開始提供此接口",
+	UsageAPIHeaders:      "傳遞的報頭內容，如果是某個 mimetype 專用的，可以放在 request 元素中。",
+	UsageAPICookies:      "傳遞的 cookie 內容，如果是某個 mimetype 專用的，可以放在 request 元素中。",
+	UsageAPITags:         "關聯的標簽",
+	UsageAPIServers:      "關聯的服務",
+	UsageAPISecurities:   "訪問該接口需要的身份驗證方案，多個值之間為或的關系。",
+	UsageAPIXAttributes:  "以 <code>x-</code> 開頭的擴展屬性，會作為 openapi 中 operation 的擴展字段輸出。",
+	UsageAPIExtensions:   "復雜類型的擴展內容，會作為 openapi 中 operation 的擴展字段輸出。",
 
 	UsageLink:     "用於描述鏈接信息，壹般轉換為 HTML 的 <code>a</code> 標簽。",
 	UsageLinkText: "鏈接的字面文字",
@@ -121,75 +141,186 @@ mock 服務會根據接口定義檢測用戶提交的數據是否合法，並生
 	UsageContactURL:   "聯系人的 URL",
 	UsageContactEmail: "聯系人的電子郵件",
 
-	UsageCallback:            "定義接口的回調內容",
-	UsageCallbackMethod:      "回調的請求方法",
-	UsageCallbackPath:        "回調的請求地址",
-	UsageCallbackSummary:     "簡要介紹",
-	UsageCallbackDeprecated:  "在此版本之後將會被棄用",
-	UsageCallbackDescription: "對於回調的詳細介紹",
-	UsageCallbackResponses:   "定義可能的返回信息",
-	UsageCallbackRequests:    "定義可用的請求信息",
-	UsageCallbackHeaders:     "傳遞的報頭內容",
-
-	UsageEnum:            "定義枚舉類型的數所的枚舉值",
-	UsageEnumDeprecated:  "該屬性棄用的版本號",
-	UsageEnumValue:       "枚舉值",
-	UsageEnumSummary:     "枚舉值的說明",
-	UsageEnumDescription: "枚舉值的詳細說明",
+	UsageCallback:             "定義接口的回調內容",
+	UsageCallbackMethod:       "回調的請求方法",
+	UsageCallbackName:         "回調的名稱，在同一 API 或是文檔的 webhook 中需要唯一",
+	UsageCallbackExpression:   "回調的目標地址，可以是 {$request.body#/url} 形式的表達式，為空時採用 path 的值，兩者都為空時無法導出到 openapi",
+	UsageCallbackPath:         "回調的請求地址",
+	UsageCallbackSummary:      "簡要介紹",
+	UsageCallbackDeprecated:   "在此版本之後將會被棄用",
+	UsageCallbackDescription:  "對於回調的詳細介紹",
+	UsageCallbackTranslations: "摘要及詳細介紹的其它語言版本",
+	UsageCallbackResponses:    "定義可能的返回信息",
+	UsageCallbackRequests:     "定義可用的請求信息",
+	UsageCallbackHeaders:      "傳遞的報頭內容",
+
+	UsageEnum:             "定義枚舉類型的數所的枚舉值",
+	UsageEnumDeprecated:   "該屬性棄用的版本號",
+	UsageEnumSince:        "該枚舉值開始啟用的版本號",
+	UsageEnumValue:        "枚舉值",
+	UsageEnumSummary:      "枚舉值的說明",
+	UsageEnumDescription:  "枚舉值的詳細說明",
+	UsageEnumTranslations: "摘要及詳細說明的其它語言版本",
 
 	UsageExample:         "示例代碼",
 	UsageExampleMimetype: "特定於類型的示例代碼",
 	UsageExampleSummary:  "示例代碼的概要信息",
+	UsageExampleSrc:      "從外部文件加載示例代碼的內容，路徑相對於當前文件，找不到時再相對於 <code>inputs.dir</code>，不能是絕對路徑或是指向這兩個目錄之外的文件。已有內容時忽略該屬性。",
 	UsageExampleContent:  "示例代碼的內容，需要使用 CDATA 包含代碼。",
 
-	UsageParam:            "參數類型，基本上可以作為 request 的子集使用。",
-	UsageParamName:        "值的名稱",
-	UsageParamType:        "值的類型",
-	UsageParamDeprecated:  "表示在大於等於該版本號時不再啟作用",
-	UsageParamDefault:     "默認值",
-	UsageParamOptional:    "是否為可選的參數",
-	UsageParamArray:       "是否為數組",
-	UsageParamItems:       "子類型，比如對象的子元素。",
-	UsageParamSummary:     "簡要介紹",
-	UsageParamEnums:       "當前參數可用的枚舉值",
-	UsageParamDescription: "詳細介紹，為 HTML 內容。",
-	UsageParamArrayStyle:  "以數組的方式展示數據",
+	UsageParam:             "參數類型，基本上可以作為 request 的子集使用。",
+	UsageParamName:         "值的名稱",
+	UsageParamType:         "值的類型",
+	UsageParamDeprecated:   "表示在大於等於該版本號時不再啟作用",
+	UsageParamSince:        "表示在大於等於該版本號時才啟作用",
+	UsageParamDefault:      "默認值，必須與 type 指定的類型相符。數組以 <code>[</code> 和 <code>]</code> 包含，元素之間以逗號分隔，比如 <code>[normal,lock]</code>，也可以是 JSON 格式的數組，未以 <code>[]</code> 包含的值則作為數組的唯一元素。",
+	UsageParamOptional:     "是否為可選的參數",
+	UsageParamArray:        "是否為數組",
+	UsageParamItems:        "子類型，比如對象的子元素。",
+	UsageParamSummary:      "簡要介紹",
+	UsageParamEnums:        "當前參數可用的枚舉值",
+	UsageParamDescription:  "詳細介紹，為 HTML 內容。",
+	UsageParamTranslations: "摘要及詳細介紹的其它語言版本",
+	UsageParamArrayStyle:   "以數組的方式展示數據",
+	UsageParamXAttributes:  "以 <code>x-</code> 開頭的擴展屬性，會作為 openapi 中參數或是 schema 的擴展字段輸出。",
+	UsageParamExtensions:   "復雜類型的擴展內容，會作為 openapi 中參數或是 schema 的擴展字段輸出。",
+	UsageParamRef:          "引用 <code>apidoc/type</code> 中定義的類型，當前元素未指定的值會從該類型中獲取。",
+	UsageParamOneOf:        "只能匹配其中一個分支的組合類型",
+	UsageParamAnyOf:        "至少匹配其中一個分支的組合類型",
+	UsageParamAllOf:        "需要同時匹配所有分支的組合類型",
+	UsageParamMin:          "數值的最小值，僅對數值類型有效。",
+	UsageParamMax:          "數值的最大值，僅對數值類型有效。",
+	UsageParamExclusiveMin: "是否不包含 <code>min</code> 指定的值",
+	UsageParamExclusiveMax: "是否不包含 <code>max</code> 指定的值",
+	UsageParamMinLength:    "字符串的最小長度，僅對字符串類型有效。",
+	UsageParamMaxLength:    "字符串的最大長度，僅對字符串類型有效。",
+	UsageParamPattern:      "字符串需要匹配的正則表達式，僅對字符串類型有效。",
+	UsageParamMinItems:     "數組的最小長度，僅在 <code>array</code> 為 true 時有效。",
+	UsageParamMaxItems:     "數組的最大長度，僅在 <code>array</code> 為 true 時有效。",
+	UsageParamUniqueItems:  "數組元素是否不能重複，僅在 <code>array</code> 為 true 時有效。",
 
 	UsagePath:        "用於定義請求時與路徑相關的內容",
-	UsagePathPath:    "接口地址",
+	UsagePathPath:    "接口地址，路徑參數以 <code>{name}</code> 表示，也可以采用 <code>{name:pattern}</code> 的形式指定參數需要匹配的正則表達式。",
 	UsagePathParams:  "地址中的參數",
 	UsagePathQueries: "地址中的查詢參數",
 
-	UsageRequest:            "定義了請求和返回的相關內容",
-	UsageRequestName:        "當 mimetype 為 <var>application/xml</var> 時，此值表示 XML 的頂層元素名稱，否則無用。",
-	UsageRequestType:        "值的類型",
-	UsageRequestDeprecated:  "表示在大於等於該版本號時不再啟作用",
-	UsageRequestArray:       "是否為數組",
-	UsageRequestItems:       "子類型，比如對象的子元素。",
-	UsageRequestSummary:     "簡要介紹",
-	UsageRequestStatus:      "狀態碼。在 request 中，該值不可用，否則為必填項。",
-	UsageRequestEnums:       "當前參數可用的枚舉值",
-	UsageRequestDescription: "詳細介紹，為 HTML 內容。",
-	UsageRequestMimetype:    "媒體類型，比如 <var>application/json</var> 等。",
-	UsageRequestExamples:    "示例代碼",
-	UsageRequestHeaders:     "傳遞的報頭內容",
+	UsageRequest:             "定義了請求和返回的相關內容",
+	UsageRequestName:         "當 mimetype 為 <var>application/xml</var> 時，此值表示 XML 的頂層元素名稱，否則無用。",
+	UsageRequestType:         "值的類型",
+	UsageRequestDeprecated:   "表示在大於等於該版本號時不再啟作用",
+	UsageRequestSince:        "表示在大於等於該版本號時才啟作用",
+	UsageRequestArray:        "是否為數組",
+	UsageRequestItems:        "子類型，比如對象的子元素。",
+	UsageRequestSummary:      "簡要介紹",
+	UsageRequestStatus:       "狀態碼。在 request 中，該值不可用，否則為必填項。",
+	UsageRequestEnums:        "當前參數可用的枚舉值",
+	UsageRequestDescription:  "詳細介紹，為 HTML 內容。",
+	UsageRequestTranslations: "摘要及詳細介紹的其它語言版本",
+	UsageRequestMimetype:     "媒體類型，比如 <var>application/json</var> 等。",
+	UsageRequestExamples:     "示例代碼",
+	UsageRequestHeaders:      "傳遞的報頭內容",
+	UsageRequestCookies:      "傳遞的 cookie 內容，在返回內容中表示由報頭 Set-Cookie 設置的值。",
+	UsageRequestRef:          "引用 <code>apidoc/type</code> 中定義的類型，當前元素未指定的值會從該類型中獲取。",
+	UsageRequestOneOf:        "只能匹配其中一個分支的組合類型",
+	UsageRequestAnyOf:        "至少匹配其中一個分支的組合類型",
+	UsageRequestAllOf:        "需要同時匹配所有分支的組合類型",
+	UsageRequestXAttributes:  "以 <code>x-</code> 開頭的擴展屬性，會作為 openapi 中 media type 的擴展字段輸出。",
+	UsageRequestExtensions:   "復雜類型的擴展內容，會作為 openapi 中 media type 的擴展字段輸出。",
+	UsageRequestLinks:        "返回內容與其它 API 之間的關聯，僅在 response 中有效",
 
 	UsageRichtext:     "富文本內容",
 	UsageRichtextType: "指定富文本內容的格式，目前支持 <var>html</var> 和 <var>markdown</var>。",
 	UsageRichtextText: "富文本的實際內容",
+	UsageRichtextSrc:  "從外部文件加載富文本的內容，路徑相對於當前文件，找不到時再相對於 <code>inputs.dir</code>，不能是絕對路徑或是指向這兩個目錄之外的文件。已有內容時忽略該屬性。",
+
+	UsageTranslation:            "其它語言的翻譯內容，在輸出指定語言的文檔時，用於替換所在元素中對應的內容。",
+	UsageTranslationLang:        "翻譯內容的語言，應該使用 BCP47 指定的格式，同一元素中不能重複。",
+	UsageTranslationTitle:       "替換所在元素的標題",
+	UsageTranslationSummary:     "替換所在元素的摘要",
+	UsageTranslationDescription: "替換所在元素的詳細描述",
+
+	UsageExtension:      "擴展內容，可以表示復雜類型的值，內容為 JSON 格式。",
+	UsageExtensionName:  "擴展內容的名稱，必須以 <code>x-</code> 開頭，且不能與同一元素中的其它擴展內容重名。",
+	UsageExtensionValue: "JSON 格式的擴展內容",
+
+	UsageResponseLink:            "描述返回內容與其它 API 之間的關聯，比如通過返回的 id 獲取詳細信息的 API",
+	UsageResponseLinkName:        "鏈接的名稱，在同一個返回內容中需要唯一",
+	UsageResponseLinkAPI:         "目標 API 的 id，只能指向同一文檔中的 API",
+	UsageResponseLinkSummary:     "鏈接的摘要",
+	UsageResponseLinkDescription: "鏈接的詳細說明",
+	UsageResponseLinkParams:      "目標 API 中各個參數的取值",
+
+	UsageResponseLinkParam:      "指定目標 API 中某一參數的值",
+	UsageResponseLinkParamName:  "目標 API 中的參數名稱，可以是路徑參數、查詢參數、報頭或是 cookie",
+	UsageResponseLinkParamField: "參數值對應的返回字段，多層之間以 . 分隔，比如 data.id",
+
+	UsageTag:             "用於對各個 API 進行分類",
+	UsageTagName:         "標簽的唯壹 ID",
+	UsageTagTitle:        "標簽的字面名稱",
+	UsageTagDeprecated:   "該標簽在大於該版本時被棄用",
+	UsageTagSince:        "該標簽開始啟用的版本號",
+	UsageTagTranslations: "標簽名稱的其它語言版本",
+	UsageTagXAttributes:  "以 <code>x-</code> 開頭的擴展屬性，會作為 openapi 中標簽的擴展字段輸出。",
+	UsageTagExtensions:   "復雜類型的擴展內容，會作為 openapi 中標簽的擴展字段輸出。",
+
+	UsageTypeDef:             "可復用的類型定義",
+	UsageTypeDefName:         "類型的唯壹名稱，供 <code>@ref</code> 引用。",
+	UsageTypeDefType:         "值的類型",
+	UsageTypeDefDeprecated:   "表示在大於等於該版本號時不再啟作用",
+	UsageTypeDefItems:        "子類型，比如對象的子元素。",
+	UsageTypeDefEnums:        "當前類型可用的枚舉值",
+	UsageTypeDefSummary:      "簡要介紹",
+	UsageTypeDefDescription:  "詳細介紹，為 HTML 內容。",
+	UsageTypeDefTranslations: "摘要及詳細介紹的其它語言版本",
+
+	UsageComposition:              "組合類型，每一個 <code>param</code> 子元素表示一個分支。",
+	UsageCompositionDiscriminator: "用於區分分支的屬性名稱，該屬性的值即為分支的 <code>@name</code>。",
+	UsageCompositionItems:         "組合類型的各個分支",
+
+	UsageServer:             "用於指定各個 API 的服務器地址",
+	UsageServerName:         "服務唯壹 ID",
+	UsageServerTitle:        "服務的字面名稱",
+	UsageServerURL:          "服務的基地址，與該服務關聯的 API，訪問地址都是相對於此地址的。",
+	UsageServerDeprecated:   "服務在大於該版本時被棄用",
+	UsageServerSince:        "服務開始啟用的版本號",
+	UsageServerSummary:      "服務的摘要信息",
+	UsageServerDescription:  "服務的詳細描述",
+	UsageServerTranslations: "摘要及詳細描述的其它語言版本",
+	UsageServerVariables:    "服務地址中 <code>{name}</code> 形式的變量，每個變量都需要在地址中使用。",
+	UsageServerXAttributes:  "以 <code>x-</code> 開頭的擴展屬性，會作為 openapi 中服務的擴展字段輸出。",
+	UsageServerExtensions:   "復雜類型的擴展內容，會作為 openapi 中服務的擴展字段輸出。",
+
+	UsageServerVariable:        "定義服務地址中的變量",
+	UsageServerVariableName:    "變量名稱，對應服務地址中的 <code>{name}</code>。",
+	UsageServerVariableDefault: "變量的默認值，如果指定了枚舉值，則必須是其中之一。",
+	UsageServerVariableSummary: "變量的摘要信息",
+	UsageServerVariableEnums:   "變量的可選值",
+
+	UsageSecurity:             "定義身份驗證方案",
+	UsageSecurityName:         "身份驗證方案的唯壹 ID",
+	UsageSecurityType:         "身份驗證方案的類型，可以是 <var>http</var>、<var>apikey</var> 和 <var>oauth2</var>。",
+	UsageSecurityScheme:       "<code>@type</code> 為 <var>http</var> 時的驗證方案，比如 <var>basic</var>、<var>bearer</var> 等。",
+	UsageSecurityBearerFormat: "<code>@scheme</code> 為 <var>bearer</var> 時令牌的格式，比如 <var>JWT</var>。",
+	UsageSecurityIN:           "<code>@type</code> 為 <var>apikey</var> 時令牌的位置，可以是 <var>header</var>、<var>query</var> 和 <var>cookie</var>。",
+	UsageSecurityKey:          "<code>@type</code> 為 <var>apikey</var> 時令牌在報頭、查詢參數或是 cookie 中的名稱",
+	UsageSecuritySummary:      "身份驗證方案的摘要信息",
+	UsageSecurityDescription:  "身份驗證方案的詳細描述",
+	UsageSecurityTranslations: "摘要及詳細描述的其它語言版本",
+	UsageSecurityFlows:        "<code>@type</code> 為 <var>oauth2</var> 時支持的授權流程",
+
+	UsageOAuthFlow:                 "定義 OAuth2 的授權流程",
+	UsageOAuthFlowType:             "授權流程的類型，可以是 <var>implicit</var>、<var>password</var>、<var>client-credentials</var> 和 <var>authorization-code</var>。",
+	UsageOAuthFlowAuthorizationURL: "授權地址，<var>implicit</var> 和 <var>authorization-code</var> 必須指定該值。",
+	UsageOAuthFlowTokenURL:         "獲取令牌的地址，<var>password</var>、<var>client-credentials</var> 和 <var>authorization-code</var> 必須指定該值。",
+	UsageOAuthFlowRefreshURL:       "刷新令牌的地址",
+	UsageOAuthFlowScopes:           "授權流程中可用的權限",
 
-	UsageTag:           "用於對各個 API 進行分類",
-	UsageTagName:       "標簽的唯壹 ID",
-	UsageTagTitle:      "標簽的字面名稱",
-	UsageTagDeprecated: "該標簽在大於該版本時被棄用",
+	UsageOAuthScope:        "定義 OAuth2 的權限",
+	UsageOAuthScopeName:    "權限的名稱",
+	UsageOAuthScopeSummary: "權限的簡要介紹",
 
-	UsageServer:            "用於指定各個 API 的服務器地址",
-	UsageServerName:        "服務唯壹 ID",
-	UsageServerTitle:       "服務的字面名稱",
-	UsageServerURL:         "服務的基地址，與該服務關聯的 API，訪問地址都是相對於此地址的。",
-	UsageServerDeprecated:  "服務在大於該版本時被棄用",
-	UsageServerSummary:     "服務的摘要信息",
-	UsageServerDescription: "服務的詳細描述",
+	UsageSecurityValue:      "引用 <code>apidoc/security</code> 中定義的身份驗證方案，內容為方案的 ID。",
+	UsageSecurityValueScope: "以空格分隔的權限列表，僅對 <var>oauth2</var> 有效。",
 
 	UsageXMLAttr:    "是否作為父元素的屬性，僅作用於 XML 元素。是否作為父元素的屬性，僅用於 XML 的請求。",
 	UsageXMLExtract: "將當前元素的內容作為父元素的內容，要求父元素必須為 <var>object</var>。",
@@ -205,6 +336,7 @@ mock 服務會根據接口定義檢測用戶提交的數據是否合法，並生
 	// 基本类型
 	UsageString:  "普通的字符串類型，特殊字符需要使用 XML 實體，比如 <samp>&lt;</samp> 需要使用 <samp>&amp;lt;</samp> 代替。",
 	UsageNumber:  "普通的數值類型，比如：<samp>1</samp>、<samp>-11.1</samp> 等。",
+	UsageStatus:  "狀態碼，可以是 100 至 511 之間的數值，或是 1XX 至 5XX 表示某一範圍內的狀態碼，default 表示其它未定義的狀態碼",
 	UsageBool:    "布爾值類型，取值為 <var>true</var> 或是 <var>false</var>。",
 	UsageVersion: `版本號，格式遵守 <a href="https://semver.org/lang/zh-CN/">semver</a> 規則。比如：<samp>1.0.1</samp>、<samp>1.0.1+20200618</samp>。`,
 	UsageDate:    `采用 <a href="https://tools.ietf.org/html/rfc3339">RFC3339</a> 格式表示的時間，比如：<samp>2019-12-16T00:35:48+08:00</samp>。`,
@@ -216,6 +348,9 @@ mock 服務會根據接口定義檢測用戶提交的數據是否合法，並生
 	<li><var>number</var> 數值類型；</li>
 	<li><var>number.int</var> 整數類型的數值；</li>
 	<li><var>number.float</var> 浮點類型的數值；</li>
+	<li><var>number.int32</var> 32 位的整數；</li>
+	<li><var>number.int64</var> 64 位的整數；</li>
+	<li><var>number.double</var> 雙精度的浮點數；</li>
 	<li><var>string</var> 字符串；</li>
 	<li><var>string.url</var> URL 類型的字符串；</li>
 	<li><var>string.email</var> email 類型的字符串；</li>
@@ -223,24 +358,49 @@ mock 服務會根據接口定義檢測用戶提交的數據是否合法，並生
 	<li><var>string.date</var> 表示 <a href="https://tools.ietf.org/html/rfc3339#section-5.6">RFC3339</a> 中的 <code>full-date</code> 日期格式，比如 <samp>2020-01-02</samp>；</li>
 	<li><var>string.time</var> 表示 <a href="https://tools.ietf.org/html/rfc3339#section-5.6">RFC3339</a> 中的 <code>full-time</code> 時間格式，比如 <samp>15:16:17Z</samp>、<samp>15:16:17+08:00</samp>；</li>
 	<li><var>string.date-time</var> 表示 <a href="https://tools.ietf.org/html/rfc3339#section-5.6">RFC3339</a> 中的 <code>date-time</code> 格式，比如 <samp>2020-01-02T15:16:17-08:00</samp>；</li>
+	<li><var>string.uuid</var> 表示 <a href="https://tools.ietf.org/html/rfc4122">RFC4122</a> 中的 UUID，比如 <samp>123e4567-e89b-12d3-a456-426614174000</samp>；</li>
+	<li><var>string.ipv4</var> IPv4 地址；</li>
+	<li><var>string.ipv6</var> IPv6 地址；</li>
+	<li><var>string.hostname</var> 表示 <a href="https://tools.ietf.org/html/rfc1123#section-2.1">RFC1123</a> 中的主機名；</li>
+	<li><var>string.byte</var> base64 編碼的內容；</li>
+	<li><var>string.binary</var> 二進制內容，比如上傳的文件；</li>
+	<li><var>string.password</var> 密碼，客戶端在展示時應該作隱藏處理；</li>
 	</ul>`,
 
 	// 以下是有关 build.Config 的字段说明
-	UsageConfigVersion:               "此配置文件的所使用的文档版本",
-	UsageConfigInputs:                "指定輸入的數據，同壹項目只能解析壹種語言。",
-	UsageConfigInputsLang:            "源文件的解析方式。具體支持的類型可通過命令 <samp>apidoc lang</samp> 查看支持語言。",
-	UsageConfigInputsDir:             "需要解析的源文件所在目錄",
-	UsageConfigInputsExts:            "只從這些擴展名的文件中查找文檔",
-	UsageConfigInputsRecursive:       "是否解析子目錄下的源文件",
-	UsageConfigInputsEncoding:        `編碼，默認為 <var>utf-8</var>，值可以是 <a href="https://www.iana.org/assignments/character-sets/character-sets.xhtml">character-sets</a> 中的內容。`,
-	UsageConfigInputsIgnores:         "忽略的文件或目錄，比如 node_modules 等。",
-	UsageConfigOutput:                "控制輸出行為",
-	UsageConfigOutputType:            "輸出的類型，目前可以 <var>apidoc+xml</var>、<var>openapi+json</var> 和 <var>openapi+yaml</var>。",
-	UsageConfigOutputPath:            "指定輸出的文件名，包含路徑信息。",
-	UsageConfigOutputTags:            "只輸出與這些標簽相關聯的文檔，默認為全部。",
-	UsageConfigOutputStyle:           "為 XML 文件指定的 XSL 文件",
-	UsageConfigOutputNamespace:       "是否輸出命名空間",
-	UsageConfigOutputNamespacePrefix: "如果輸出了命名空間，還可以指定命名空間前綴。",
+	UsageConfigVersion:                 "此配置文件的所使用的文档版本",
+	UsageConfigInputs:                  "指定輸入的數據，同壹項目只能解析壹種語言。",
+	UsageConfigInputsLang:              "源文件的解析方式。具體支持的類型可通過命令 <samp>apidoc lang</samp> 查看支持語言。",
+	UsageConfigInputsDir:               "需要解析的源文件所在目錄",
+	UsageConfigInputsExts:              "只從這些擴展名的文件中查找文檔",
+	UsageConfigInputsRecursive:         "是否解析子目錄下的源文件",
+	UsageConfigInputsEncoding:          `編碼，默認為 <var>utf-8</var>，值可以是 <a href="https://www.iana.org/assignments/character-sets/character-sets.xhtml">character-sets</a> 中的內容。`,
+	UsageConfigInputsIgnores:           "忽略的文件或目錄，採用 .gitignore 的語法，支持 <code>**</code>、以 <code>/</code> 結尾的目錄以及以 <code>!</code> 開頭的取反規則，比如 node_modules、<code>**/testdata/**</code> 等。",
+	UsageConfigInputsDoc:               "該輸入項中未指定 doc 屬性的 api 所屬的文檔 ID",
+	UsageConfigInputsCache:             "緩存目錄，指定後會以源文件的內容作為依據緩存從中提取的註釋塊，未發生變化的文件不會被再次解析。程序版本或是 <var>lang</var>、<var>exts</var>、<var>encoding</var> 有變化時緩存自動失效。",
+	UsageConfigInputsGitignore:         "是否同時採用 <var>dir</var> 及其子目錄中 .gitignore 文件中的規則忽略文件，規則僅對文件所在的目錄有效，且優先級低於 <var>ignores</var>。",
+	UsageConfigInputsFiles:             "需要解析的文件，採用 .gitignore 的語法。指定之後不再根據 <var>exts</var> 查找文件，<var>recursive</var> 和 <var>ignores</var> 依然有效。",
+	UsageConfigOutput:                  "控制輸出行為",
+	UsageConfigOutputType:              "輸出的類型，目前可以 <var>apidoc+xml</var>、<var>openapi+json</var> 和 <var>openapi+yaml</var>。",
+	UsageConfigOutputPath:              "指定輸出的文件名，包含路徑信息。",
+	UsageConfigOutputTags:              "只輸出與這些標簽相關聯的文檔，默認為全部。",
+	UsageConfigOutputInclude:           "只輸出符合這些條件的 API。各字段之間需要同時滿足，同壹字段中的多個值只需滿足其壹，與 <var>tags</var> 同時指定時，需要同時滿足兩者。指定了過濾條件之後，未被 API 引用的標簽和服務也不會被輸出。",
+	UsageConfigOutputIncludeServers:    "服務的名稱，API 中的其它服務也不會被輸出。",
+	UsageConfigOutputIncludeMethods:    "請求方法，不區分大小寫。",
+	UsageConfigOutputIncludePaths:      "路徑，可以使用通配符，比如 <code>/users/*</code>。",
+	UsageConfigOutputIncludeIDs:        "API 的 ID",
+	UsageConfigOutputIncludeDeprecated: "是否為已棄用的 API，即指定了 <var>deprecated</var> 屬性的 API。",
+	UsageConfigOutputExclude:           "不輸出符合這些條件的 API，字段與 <var>include</var> 相同，優先級高於 <var>tags</var> 和 <var>include</var>。",
+	UsageConfigOutputDoc:               "只輸出該 ID 的文檔。默認為每份文檔都輸出壹個文件，文件名為在 <var>path</var> 的擴展名之前插入文檔的 ID。",
+	UsageConfigOutputTarget:            "只輸出該版本號中可用的內容，<var>since</var> 晚於該版本號的內容都將被忽略。默認為輸出所有內容。",
+	UsageConfigOutputRemoveDeprecated:  "是否刪除在 <var>target</var> 之前的版本就已經被棄用的內容，僅在指定了 <var>target</var> 時有效。",
+	UsageConfigOutputStyle:             "為 XML 文件指定的 XSL 文件",
+	UsageConfigOutputNamespace:         "是否輸出命名空間",
+	UsageConfigOutputNamespacePrefix:   "如果輸出了命名空間，還可以指定命名空間前綴。",
+	UsageConfigOutputMarkdown:          "是否將 <var>markdown</var> 格式的富文本轉換成 HTML，轉換後的內容會過濾掉其中的 HTML 代碼以及不安全的鏈接。鏈接地址可以使用 <code>api:id</code> 的形式指向文檔中指定 <var>id</var> 的 API，無效的鏈接會以警告的形式輸出。",
+	UsageConfigOutputBaseURL:           "<var>markdown</var> 中相對鏈接的基地址，必須是絕對地址，僅在 <var>markdown</var> 為 <var>true</var> 時有效。",
+	UsageConfigOutputLocale:            "輸出文檔的語言，應該使用 BCP47 指定的格式。文檔中與之匹配的 <code>translation</code> 會替換原有內容，若為空，則原樣輸出所有語言的內容。",
+	UsageConfigOutputs:                 "多個輸出項，每壹項的字段與 <var>output</var> 相同。源文件只會解析壹次，之後按各輸出項的配置分別輸出，可以與 <var>output</var> 同時使用，但輸出的路徑不能相同。",
 
 	// 錯誤信息，可能在地方用到
 	ErrInvalidUTF8Character:      "無效的 UTF8 字符",
@@ -264,12 +424,17 @@ mock 服務會根據接口定義檢測用戶提交的數據是否合法，並生
 	ErrDuplicateValue:            "重復的值",
 	ErrMessage:                   "%s 位於 %s",
 	ErrNotFound:                  "未找到該值",
+	ErrAPINotFoundInDoc:          "文檔中不存在 id 為 %s 的 API",
+	ErrParamNotFoundInAPI:        "API 中不存在名為 %s 的參數",
+	ErrStatusOverlap:             "狀態碼 %s 與 %s 所表示的範圍重疊",
 	ErrReadRemoteFile:            "讀取遠程文件 %s 時返回狀態碼 %d",
 	ErrServerNotInitialized:      "服務未初始化",
 	ErrInvalidLSPState:           "無效的 LSP 狀態",
 	ErrInvalidURIScheme:          "無效的 URI 協議：%s",
 	ErrInvalidURI:                "無效的 URI：%s",
 	ErrFileNotFound:              "未找到文件 %s",
+	ErrCircularReference:         "存在循環引用",
+	ErrUnauthorized:              "缺少有效的身份驗證信息",
 
 	// logs
 	InfoPrefix:    "[信息] ",
@@ -13,7 +13,7 @@ func (s *server) apidocOutline(f *folder) error {
 		return s.Notify("apidoc/outline", &protocol.APIDocOutline{Err: f.loadError.Error(), NoConfig: f.noConfig})
 	}
 
-	if outline := protocol.BuildAPIDocOutline(f.WorkspaceFolder, f.doc); outline != nil {
+	if outline := protocol.BuildAPIDocOutline(f.WorkspaceFolder, f.docs); outline != nil {
 		outline.NoConfig = f.noConfig
 		return s.Notify("apidoc/outline", outline)
 	}
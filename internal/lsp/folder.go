@@ -19,14 +19,14 @@ import (
 // 表示项目文件夹
 type folder struct {
 	protocol.WorkspaceFolder
-	doc       *ast.APIDoc
+	docs      *ast.Documents
 	cfg       *build.Config
 	srv       *server
 	loadError error // 加载过程中的出错信息
 	noConfig  bool
 	h         *core.MessageHandler
 
-	parsedMux sync.RWMutex // 解析 doc 时需要的锁
+	parsedMux sync.RWMutex // 解析 docs 时需要的锁
 
 	// 保存着错误和警告的信息
 	diagnostics map[core.URI]*protocol.PublishDiagnosticsParams
@@ -64,7 +64,7 @@ func (s *server) appendFolders(folders ...protocol.WorkspaceFolder) {
 	for _, ff := range folders {
 		f := &folder{
 			WorkspaceFolder: ff,
-			doc:             &ast.APIDoc{},
+			docs:            &ast.Documents{},
 			srv:             s,
 			diagnostics:     make(map[core.URI]*protocol.PublishDiagnosticsParams, 5),
 		}
@@ -99,14 +99,14 @@ func (f *folder) refresh(force bool) {
 	}
 	f.cfg = cfg
 
-	f.doc = &ast.APIDoc{}
+	f.docs = build.NewDocuments(f.cfg.Inputs...)
 	f.clearDiagnostics()
 
 	if f.h == nil {
 		f.h = core.NewMessageHandler(f.messageHandler)
 	}
 
-	f.doc.ParseBlocks(f.h, func(blocks chan core.Block) {
+	f.docs.ParseBlocks(f.h, func(blocks chan core.Block) {
 		build.ParseInputs(blocks, f.h, f.cfg.Inputs...)
 	})
 
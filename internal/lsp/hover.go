@@ -29,7 +29,7 @@ func (s *server) textDocumentHover(notify bool, in *protocol.HoverParams, out *p
 	f.parsedMux.RLock()
 	defer f.parsedMux.RUnlock()
 
-	if u := f.doc.Search(in.TextDocument.URI, in.TextDocumentPositionParams.Position, usagerType); u != nil {
+	if u := f.docs.Search(in.TextDocument.URI, in.TextDocumentPositionParams.Position, usagerType); u != nil {
 		usage := u.(usager)
 		if v := usage.Usage(); v != "" {
 			out.Range = usage.Loc().Range
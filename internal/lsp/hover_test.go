@@ -38,15 +38,15 @@ func TestServer_textDocumentHover(t *testing.T) {
 </apidoc>`
 	blk := core.Block{Data: []byte(b), Location: core.Location{URI: "file:///test/doc.go"}}
 	rslt := messagetest.NewMessageHandler()
-	doc := &ast.APIDoc{}
-	doc.Parse(rslt.Handler, blk)
+	docs := &ast.Documents{}
+	docs.Parse(rslt.Handler, blk)
 	rslt.Handler.Stop()
 	a.Empty(rslt.Errors)
 
 	s.folders = []*folder{
 		{
 			WorkspaceFolder: protocol.WorkspaceFolder{Name: "test", URI: "file:///test"},
-			doc:             doc,
+			docs:            docs,
 		},
 	}
 
@@ -32,12 +32,19 @@ type APIDocOutline struct {
 	NoConfig bool   `json:"noConfig,omitempty"` // 没有配置文件的相关信息
 
 	Location core.Location   `json:"location,omitempty"`
+	ID       string          `json:"id,omitempty"`
 	Title    string          `json:"title,omitempty"`
 	Version  string          `json:"version,omitempty"`
 	Tags     []*APIDocTag    `json:"tags,omitempty"`
 	Servers  []*APIDocServer `json:"servers,omitempty"`
 
-	APIs []*API `json:"apis,omitempty"`
+	APIs     []*API      `json:"apis,omitempty"`
+	Webhooks []*Callback `json:"webhooks,omitempty"`
+
+	// 工作区中所有文档的摘要
+	//
+	// 当前对象中与文档相关的字段与第一个元素相同，以兼容仅支持单一文档的客户端。
+	Docs []*APIDocOutline `json:"docs,omitempty"`
 }
 
 // APIDocTag 文档支持的标签属性
@@ -61,13 +68,49 @@ type API struct {
 	Servers    []string      `json:"servers,omitempty"`
 	Deprecated string        `json:"deprecated,omitempty"`
 	Summary    string        `json:"summary,omitempty"`
+	Callbacks  []*Callback   `json:"callbacks,omitempty"`
 }
 
-// BuildAPIDocOutline 根据 ast.APIDoc 构建 APIDoc
+// Callback 描述 API 的回调或是文档的 webhook
+type Callback struct {
+	Location   core.Location `json:"location"`
+	Name       string        `json:"name,omitempty"`
+	Method     string        `json:"method"`
+	Path       string        `json:"path,omitempty"` // 回调的地址，webhook 可能为空
+	Deprecated string        `json:"deprecated,omitempty"`
+	Summary    string        `json:"summary,omitempty"`
+}
+
+// BuildAPIDocOutline 根据 ast.Documents 构建 APIDocOutline
+//
+// 如果 docs 中不包含任何有效的文档内容，则返回 nil。
+func BuildAPIDocOutline(f WorkspaceFolder, docs *ast.Documents) *APIDocOutline {
+	if docs == nil {
+		return nil
+	}
+
+	var outline *APIDocOutline
+	for _, doc := range docs.Docs {
+		o := buildDocOutline(f, doc)
+		if o == nil {
+			continue
+		}
+
+		if outline == nil {
+			outline = &APIDocOutline{}
+			*outline = *o
+		}
+		outline.Docs = append(outline.Docs, o)
+	}
+
+	return outline
+}
+
+// 根据 ast.APIDoc 构建 APIDocOutline
 //
 // 如果 doc 不是一个有效的文档内容，比如是零值，则返回 nil。
 // 如果是 doc.APIs 中的某一个元素的 path 未必须，则会忽略此记录的显示。
-func BuildAPIDocOutline(f WorkspaceFolder, doc *ast.APIDoc) *APIDocOutline {
+func buildDocOutline(f WorkspaceFolder, doc *ast.APIDoc) *APIDocOutline {
 	if doc == nil || doc.Title.V() == "" {
 		return nil
 	}
@@ -94,6 +137,7 @@ func BuildAPIDocOutline(f WorkspaceFolder, doc *ast.APIDoc) *APIDocOutline {
 			URI:   doc.URI,
 			Range: doc.Range,
 		},
+		ID:      doc.ID.V(),
 		Title:   doc.Title.V(),
 		Version: doc.Version.V(),
 		Tags:    tags,
@@ -105,6 +149,13 @@ func BuildAPIDocOutline(f WorkspaceFolder, doc *ast.APIDoc) *APIDocOutline {
 		outline.appendAPI(api)
 	}
 
+	if len(doc.Webhooks) > 0 {
+		outline.Webhooks = make([]*Callback, 0, len(doc.Webhooks))
+		for _, webhook := range doc.Webhooks {
+			outline.Webhooks = append(outline.Webhooks, buildCallback(outline.Location.URI, webhook))
+		}
+	}
+
 	return outline
 }
 
@@ -135,6 +186,14 @@ func (o *APIDocOutline) appendAPI(api *ast.API) {
 		path = api.Path.Path.V()
 	}
 
+	var callbacks []*Callback
+	if len(api.Callbacks) > 0 {
+		callbacks = make([]*Callback, 0, len(api.Callbacks))
+		for _, cb := range api.Callbacks {
+			callbacks = append(callbacks, buildCallback(uri, cb))
+		}
+	}
+
 	o.APIs = append(o.APIs, &API{
 		Location: core.Location{
 			URI:   uri,
@@ -146,5 +205,30 @@ func (o *APIDocOutline) appendAPI(api *ast.API) {
 		Servers:    srvs,
 		Deprecated: api.Description.V(),
 		Summary:    summary,
+		Callbacks:  callbacks,
 	})
 }
+
+func buildCallback(uri core.URI, cb *ast.Callback) *Callback {
+	path := cb.Expression.V()
+	if path == "" && cb.Path != nil {
+		path = cb.Path.Path.V()
+	}
+
+	summary := cb.Summary.V()
+	if summary == "" {
+		summary = cb.Description.V()
+	}
+
+	return &Callback{
+		Location: core.Location{
+			URI:   uri,
+			Range: cb.Range,
+		},
+		Name:       cb.Name.V(),
+		Method:     cb.Method.V(),
+		Path:       path,
+		Deprecated: cb.Deprecated.V(),
+		Summary:    summary,
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/issue9/assert/v3"
 
+	"github.com/caixw/apidoc/v7/core"
 	"github.com/caixw/apidoc/v7/internal/ast"
 	"github.com/caixw/apidoc/v7/internal/ast/asttest"
 	"github.com/caixw/apidoc/v7/internal/xmlenc"
@@ -17,16 +18,27 @@ func TestBuildAPIDocOutline(t *testing.T) {
 	a := assert.New(t, false)
 	f := WorkspaceFolder{Name: "test"}
 
-	doc := &ast.APIDoc{}
-	outline := BuildAPIDocOutline(f, doc)
+	outline := BuildAPIDocOutline(f, &ast.Documents{Docs: []*ast.APIDoc{{}}})
 	a.Nil(outline)
 
-	doc = asttest.Get()
-	outline = BuildAPIDocOutline(f, doc)
+	doc := asttest.Get()
+	outline = BuildAPIDocOutline(f, &ast.Documents{Docs: []*ast.APIDoc{doc}})
 	a.NotNil(outline)
 
 	a.Equal(outline.Title, doc.Title.V())
 	a.Equal(2, len(outline.APIs))
+	a.Length(outline.Docs, 1).Equal(outline.Docs[0].Title, doc.Title.V())
+
+	// 多个文档
+	admin := asttest.Get()
+	admin.ID = &ast.Attribute{Value: xmlenc.String{Value: "admin"}}
+	outline = BuildAPIDocOutline(f, &ast.Documents{Docs: []*ast.APIDoc{doc, admin}})
+	a.NotNil(outline).
+		Empty(outline.ID).
+		Length(outline.Docs, 2).
+		Equal(outline.Docs[1].ID, "admin").
+		Equal(outline.Docs[1].WorkspaceFolder, f).
+		Equal(2, len(outline.Docs[1].APIs))
 }
 
 func TestAPIDocOutline_appendAPI(t *testing.T) {
@@ -49,4 +61,30 @@ func TestAPIDocOutline_appendAPI(t *testing.T) {
 	a.Equal(len(outline.APIs), 1)
 	api = outline.APIs[0]
 	a.Equal(api.Path, "?").Equal(api.Method, http.MethodDelete)
+
+	// callbacks
+	outline = &APIDocOutline{
+		Location: core.Location{URI: "file:///apis.go"},
+		APIs:     []*API{},
+	}
+	outline.appendAPI(&ast.API{
+		Callbacks: []*ast.Callback{
+			{
+				Name:       &ast.Attribute{Value: xmlenc.String{Value: "paid"}},
+				Method:     &ast.MethodAttribute{Value: xmlenc.String{Value: http.MethodPost}},
+				Expression: &ast.Attribute{Value: xmlenc.String{Value: "{$request.body#/url}"}},
+			},
+			{
+				Method: &ast.MethodAttribute{Value: xmlenc.String{Value: http.MethodPost}},
+				Path:   &ast.Path{Path: &ast.Attribute{Value: xmlenc.String{Value: "/refunds"}}},
+			},
+		},
+	})
+	api = outline.APIs[0]
+	a.Length(api.Callbacks, 2).
+		Equal(api.Callbacks[0].Name, "paid").
+		Equal(api.Callbacks[0].Path, "{$request.body#/url}").
+		Equal(api.Callbacks[0].Location.URI, "file:///apis.go").
+		Empty(api.Callbacks[1].Name).
+		Equal(api.Callbacks[1].Path, "/refunds")
 }
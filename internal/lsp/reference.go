@@ -27,7 +27,7 @@ func (s *server) textDocumentReferences(notify bool, in *protocol.ReferenceParam
 	f.parsedMux.RLock()
 	defer f.parsedMux.RUnlock()
 
-	*out = references(f.doc, in.TextDocument.URI, in.Position, in.Context.IncludeDeclaration)
+	*out = references(f.docs, in.TextDocument.URI, in.Position, in.Context.IncludeDeclaration)
 	return nil
 }
 
@@ -46,14 +46,16 @@ func (s *server) textDocumentDefinition(notify bool, in *protocol.DefinitionPara
 	f.parsedMux.RLock()
 	defer f.parsedMux.RUnlock()
 
-	if r := f.doc.Search(in.TextDocument.URI, in.TextDocumentPositionParams.Position, definitionerType); r != nil {
-		*out = []core.Location{r.(ast.Definitioner).Definition().Location}
+	if r := f.docs.Search(in.TextDocument.URI, in.TextDocumentPositionParams.Position, definitionerType); r != nil {
+		if def := r.(ast.Definitioner).Definition(); def != nil { // 未能正确关联的引用，其 Definition 为 nil
+			*out = []core.Location{def.Location}
+		}
 	}
 	return nil
 }
 
-func references(doc *ast.APIDoc, uri core.URI, pos core.Position, include bool) (locations []core.Location) {
-	r := doc.Search(uri, pos, referencerType)
+func references(docs *ast.Documents, uri core.URI, pos core.Position, include bool) (locations []core.Location) {
+	r := docs.Search(uri, pos, referencerType)
 	if r == nil {
 		return
 	}
@@ -15,7 +15,7 @@ import (
 	"github.com/caixw/apidoc/v7/internal/lsp/protocol"
 )
 
-func loadReferencesDoc(a *assert.Assertion) *ast.APIDoc {
+func loadReferencesDoc(a *assert.Assertion) *ast.Documents {
 	const referenceDefinitionDoc = `<apidoc version="1.1.1">
 	<title>标题</title>
 	<mimetype>xml</mimetype>
@@ -30,18 +30,21 @@ func loadReferencesDoc(a *assert.Assertion) *ast.APIDoc {
 		<tag>t1</tag>
 		<tag>t2</tag>
 		<path path="/users" />
-		<response status="200" />
+		<response status="200" ref="user" />
 	</api>
+	<type name="user" type="object" summary="user">
+		<param name="id" type="number" summary="id" />
+	</type>
 </apidoc>`
 
 	blk := core.Block{Data: []byte(referenceDefinitionDoc), Location: core.Location{URI: "file:///root/doc.go"}}
 	rslt := messagetest.NewMessageHandler()
-	doc := &ast.APIDoc{}
-	doc.Parse(rslt.Handler, blk)
+	docs := &ast.Documents{}
+	docs.ParseBlocks(rslt.Handler, func(blocks chan core.Block) { blocks <- blk })
 	rslt.Handler.Stop()
 	a.Empty(rslt.Errors)
 
-	return doc
+	return docs
 }
 
 func TestServer_textDocumentReferences(t *testing.T) {
@@ -54,7 +57,7 @@ func TestServer_textDocumentReferences(t *testing.T) {
 	s.folders = []*folder{
 		{
 			WorkspaceFolder: protocol.WorkspaceFolder{Name: "test", URI: "file:///root"},
-			doc:             loadReferencesDoc(a),
+			docs:            loadReferencesDoc(a),
 		},
 	}
 
@@ -82,7 +85,7 @@ func TestServer_textDocumentDefinition(t *testing.T) {
 	s.folders = []*folder{
 		{
 			WorkspaceFolder: protocol.WorkspaceFolder{Name: "test", URI: "file:///root"},
-			doc:             loadReferencesDoc(a),
+			docs:            loadReferencesDoc(a),
 		},
 	}
 
@@ -98,6 +101,20 @@ func TestServer_textDocumentDefinition(t *testing.T) {
 			End:   core.Position{Line: 3, Character: 31},
 		},
 	})
+
+	// ref
+	err = s.textDocumentDefinition(false, &protocol.DefinitionParams{TextDocumentPositionParams: protocol.TextDocumentPositionParams{
+		TextDocument: protocol.TextDocumentIdentifier{URI: "file:///root/doc.go"},
+		Position:     core.Position{Line: 14, Character: 25},
+	}}, &locs)
+	a.NotError(err).Equal(len(locs), 1)
+	a.Equal(locs[0], core.Location{
+		URI: "file:///root/doc.go",
+		Range: core.Range{
+			Start: core.Position{Line: 16, Character: 1},
+			End:   core.Position{Line: 18, Character: 8},
+		},
+	})
 }
 
 func TestReferences(t *testing.T) {
@@ -122,4 +139,16 @@ func TestReferences(t *testing.T) {
 	pos = core.Position{Line: 3, Character: 16}
 	locs = references(doc, "file:///root/doc.go", pos, true)
 	a.Equal(len(locs), 3)
+
+	// type
+	pos = core.Position{Line: 17, Character: 3}
+	locs = references(doc, "file:///root/doc.go", pos, false)
+	a.Equal(len(locs), 1).
+		Equal(locs[0], core.Location{
+			URI: "file:///root/doc.go",
+			Range: core.Range{
+				Start: core.Position{Line: 14, Character: 25},
+				End:   core.Position{Line: 14, Character: 35},
+			},
+		})
 }
@@ -34,14 +34,14 @@ func (s *server) textDocumentSemanticTokens(notify bool, in *protocol.SemanticTo
 	f.parsedMux.RLock()
 	defer f.parsedMux.RUnlock()
 
-	out.Data = semanticTokens(f.doc, in.TextDocument.URI, 0, 1, 2)
+	out.Data = semanticTokens(f.docs, in.TextDocument.URI, 0, 1, 2)
 	return nil
 }
 
 // tag 表示标签名的颜色值；
 // attr 表示属性；
 // value 表示属性的颜色值；
-func semanticTokens(doc *ast.APIDoc, uri core.URI, tag, attr, value int) []int {
+func semanticTokens(docs *ast.Documents, uri core.URI, tag, attr, value int) []int {
 	b := &tokenBuilder{
 		uri:    uri,
 		tag:    tag,
@@ -50,7 +50,9 @@ func semanticTokens(doc *ast.APIDoc, uri core.URI, tag, attr, value int) []int {
 		tokens: make([][]int, 0, 100),
 	}
 
-	b.parse(reflect.ValueOf(doc))
+	for _, doc := range docs.Docs {
+		b.parse(reflect.ValueOf(doc))
+	}
 	b.sort()
 	return b.build()
 }
@@ -108,13 +108,13 @@ func TestSemanticTokens(t *testing.T) {
 	</api>
 </apidoc>`
 	blk := core.Block{Data: []byte(b), Location: core.Location{URI: "doc.go"}}
-	doc := &ast.APIDoc{}
+	docs := &ast.Documents{}
 	rslt := messagetest.NewMessageHandler()
-	doc.Parse(rslt.Handler, blk)
+	docs.Parse(rslt.Handler, blk)
 	rslt.Handler.Stop()
 	a.Empty(rslt.Errors)
 
-	a.Equal(semanticTokens(doc, "doc.go", 1, 2, 3), []int{
+	a.Equal(semanticTokens(docs, "doc.go", 1, 2, 3), []int{
 		0, 1, 6, 1, 0, // apidoc
 		0, 7, 7, 2, 0,
 		0, 9, 5, 3, 0,
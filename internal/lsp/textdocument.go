@@ -9,7 +9,6 @@ import (
 
 	"github.com/caixw/apidoc/v7/build"
 	"github.com/caixw/apidoc/v7/core"
-	"github.com/caixw/apidoc/v7/internal/ast"
 	"github.com/caixw/apidoc/v7/internal/lang"
 	"github.com/caixw/apidoc/v7/internal/lsp/protocol"
 )
@@ -26,55 +25,49 @@ func (s *server) textDocumentDidChange(notify bool, in *protocol.DidChangeTextDo
 	f.parsedMux.Lock()
 	defer f.parsedMux.Unlock()
 
-	if !deleteURI(f.doc, in.TextDocument.URI) {
+	// 通过 include 元素等引用了当前文件的文件也需要重新解析
+	includers := f.docs.Includers(in.TextDocument.URI)
+	deleted := f.docs.DeleteURI(in.TextDocument.URI)
+	for _, uri := range includers {
+		if f.docs.DeleteURI(uri) {
+			deleted = true
+		}
+	}
+	if !deleted {
 		return nil
 	}
 
 	f.clearDiagnostics()
-	for _, blk := range in.Blocks() {
-		f.parseBlock(blk)
+	f.docs.ParseBlocks(f.h, func(blocks chan core.Block) {
+		for _, blk := range in.Blocks() {
+			if input := f.input(blk.Location.URI); input != nil {
+				lang.Parse(f.h, input.Lang, blk, blocks)
+			}
+		}
+
+		for _, uri := range includers { // 引用者的内容从文件中读取
+			if input := f.input(uri); input != nil {
+				input.ParseFile(blocks, f.h, uri)
+			}
+		}
+	})
+	if err := f.srv.apidocOutline(f); err != nil {
+		f.srv.printErr(err)
 	}
 	f.srv.textDocumentPublishDiagnostics(f)
 
 	return nil
 }
 
-func (f *folder) parseBlock(block core.Block) {
-	var input *build.Input
-	ext := filepath.Ext(block.Location.URI.String())
+// 返回 uri 对应的输入项，如果 uri 无需解析，则返回 nil。
+func (f *folder) input(uri core.URI) *build.Input {
+	ext := filepath.Ext(uri.String())
 	for _, i := range f.cfg.Inputs {
 		if sliceutil.Count(i.Exts, func(index string) bool { return index == ext }) > 0 {
-			input = i
-			break
+			return i
 		}
 	}
-	if input == nil { // 无需解析
-		return
-	}
-
-	f.doc.ParseBlocks(f.h, func(blocks chan core.Block) {
-		lang.Parse(f.h, input.Lang, block, blocks)
-	})
-
-	if err := f.srv.apidocOutline(f); err != nil {
-		f.srv.printErr(err)
-	}
-}
-
-func deleteURI(doc *ast.APIDoc, uri core.URI) (deleted bool) {
-	l := len(doc.APIs)
-	doc.APIs = sliceutil.Delete(doc.APIs, func(i *ast.API) bool {
-		return i.URI == uri
-	})
-
-	deleted = l > len(doc.APIs)
-
-	if doc.URI == uri {
-		*doc = ast.APIDoc{APIs: doc.APIs}
-		deleted = true
-	}
-
-	return deleted
+	return nil
 }
 
 // textDocument/publishDiagnostics
@@ -118,13 +111,15 @@ func (s *server) textDocumentFoldingRange(notify bool, in *protocol.FoldingRange
 	f.parsedMux.RLock()
 	defer f.parsedMux.RUnlock()
 
-	if f.doc.URI == in.TextDocument.URI {
-		folds = append(folds, protocol.BuildFoldingRange(f.doc.Base, lineFoldingOnly))
-	}
+	for _, doc := range f.docs.Docs {
+		if doc.URI == in.TextDocument.URI {
+			folds = append(folds, protocol.BuildFoldingRange(doc.Base, lineFoldingOnly))
+		}
 
-	for _, api := range f.doc.APIs {
-		if api.URI == in.TextDocument.URI {
-			folds = append(folds, protocol.BuildFoldingRange(api.Base, lineFoldingOnly))
+		for _, api := range doc.APIs {
+			if api.URI == in.TextDocument.URI {
+				folds = append(folds, protocol.BuildFoldingRange(api.Base, lineFoldingOnly))
+			}
 		}
 	}
 
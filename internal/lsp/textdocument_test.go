@@ -5,6 +5,7 @@ package lsp
 import (
 	"io/ioutil"
 	"log"
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -14,7 +15,6 @@ import (
 	"github.com/caixw/apidoc/v7/core/messagetest"
 	"github.com/caixw/apidoc/v7/internal/ast"
 	"github.com/caixw/apidoc/v7/internal/lsp/protocol"
-	"github.com/caixw/apidoc/v7/internal/xmlenc"
 )
 
 func TestServer_textDocumentDidChange(t *testing.T) {
@@ -51,34 +51,47 @@ func TestServer_textDocumentDidChange(t *testing.T) {
 	a.NotError(err)
 }
 
-func TestDeleteURI(t *testing.T) {
+func TestServer_textDocumentDidChange_include(t *testing.T) {
 	a := assert.New(t, false)
 
-	d := &ast.APIDoc{}
-	d.APIDoc = &ast.APIDocVersionAttribute{Value: xmlenc.String{Value: "1.0.0"}}
-	d.URI = core.URI("uri1")
-	d.APIs = []*ast.API{
-		{ //1
-			BaseTag: xmlenc.BaseTag{Base: xmlenc.Base{Location: core.Location{URI: "uri1"}}},
-		},
-		{ //2
-			BaseTag: xmlenc.BaseTag{Base: xmlenc.Base{Location: core.Location{URI: "uri2"}}},
-		},
-		{ //3
-			BaseTag: xmlenc.BaseTag{Base: xmlenc.Base{Location: core.Location{URI: "uri3"}}},
-		},
+	dir := t.TempDir()
+	write := func(name, data string) core.URI {
+		a.NotError(os.WriteFile(filepath.Join(dir, name), []byte(data), os.ModePerm))
+		return core.FileURI(filepath.Join(dir, name))
 	}
+	write(".apidoc.yaml", "version: "+ast.Version+"\ninputs:\n- lang: go\n  dir: .\noutput:\n  path: ./index.xml\n")
+	write("doc.go", `package doc
 
-	a.True(deleteURI(d, "uri3"))
-	a.Equal(2, len(d.APIs)).NotNil(d.APIDoc)
-
-	a.True(deleteURI(d, "uri1"))
-	a.Equal(1, len(d.APIs)).Nil(d.APIDoc)
-
-	a.True(deleteURI(d, "uri2"))
-	a.Equal(0, len(d.APIs)).Nil(d.APIDoc)
+// <apidoc version="1.0.0"><title>t</title><mimetype>json</mimetype>
+//   <include src="apis.xml" />
+// </apidoc>
+`)
+	apis := write("apis.xml", `<api method="GET"><path path="/users" /><response status="200" /></api>`)
 
-	a.False(deleteURI(d, "uri2"))
+	s := newTestServer(true, log.New(ioutil.Discard, "", 0), log.New(ioutil.Discard, "", 0))
+	s.appendFolders(protocol.WorkspaceFolder{URI: core.FileURI(dir), Name: "include"})
+	a.Length(s.folders, 1)
+	f := s.folders[0]
+	a.NotError(f.loadError).
+		Length(f.docs.Docs, 1).
+		Length(f.docs.Docs[0].APIs, 1).
+		Equal(f.docs.Docs[0].APIs[0].Method.V(), "GET")
+
+	// 被引用的文件发生变化，引用它的 doc.go 也会被重新解析。
+	text := `<api method="GET"><path path="/users" /><response status="200" /></api>
+<api method="POST"><path path="/users" /><response status="201" /></api>`
+	write("apis.xml", text)
+	err := s.textDocumentDidChange(true, &protocol.DidChangeTextDocumentParams{
+		TextDocument: protocol.VersionedTextDocumentIdentifier{
+			TextDocumentIdentifier: protocol.TextDocumentIdentifier{URI: apis},
+		},
+		ContentChanges: []protocol.TextDocumentContentChangeEvent{{Text: text}},
+	}, nil)
+	a.NotError(err).
+		Length(f.docs.Docs, 1).
+		Length(f.docs.Docs[0].APIs, 2).
+		Equal(f.docs.Docs[0].APIs[0].Method.V(), "GET").
+		Equal(f.docs.Docs[0].APIs[1].Method.V(), "POST")
 }
 
 func TestServer_textDocumentFoldingRange(t *testing.T) {
@@ -99,13 +112,13 @@ func TestServer_textDocumentFoldingRange(t *testing.T) {
 	uri := core.URI("file:///root/doc.go")
 	blk := core.Block{Data: []byte(referenceDefinitionDoc), Location: core.Location{URI: uri}}
 	rslt := messagetest.NewMessageHandler()
-	doc := &ast.APIDoc{}
-	doc.Parse(rslt.Handler, blk)
+	docs := &ast.Documents{}
+	docs.Parse(rslt.Handler, blk)
 	rslt.Handler.Stop()
 	a.Empty(rslt.Errors)
 
 	s := newTestServer(true, log.New(ioutil.Discard, "", 0), log.New(ioutil.Discard, "", 0))
-	s.folders = append(s.folders, &folder{srv: s, doc: doc})
+	s.folders = append(s.folders, &folder{srv: s, docs: docs})
 
 	a.NotNil(s)
 	result := make([]protocol.FoldingRange, 0, 10)
@@ -0,0 +1,430 @@
+// SPDX-License-Identifier: MIT
+
+package markdown
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	entityExpr   = regexp.MustCompile(`^&(?:#[0-9]{1,7}|#[xX][0-9a-fA-F]{1,6}|[a-zA-Z][a-zA-Z0-9]{1,31});`)
+	autolinkExpr = regexp.MustCompile(`^<([a-zA-Z][a-zA-Z0-9+.-]{1,31}:[^\s<>]*)>`)
+	emailExpr    = regexp.MustCompile(`^<([a-zA-Z0-9.!#$%&'*+/=?^_{|}~-]+@[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*)>`)
+	bareURLExpr  = regexp.MustCompile(`^(?:https?://|www\.)[^\s<]*[^\s<?!.,:*_~)'"]`)
+	schemeExpr   = regexp.MustCompile(`^([a-zA-Z][a-zA-Z0-9+.-]*):`)
+	tagExpr      = regexp.MustCompile(`<[^>]*>`)
+)
+
+// 允许出现在链接中的协议，其它协议的链接会被过滤。
+var safeSchemes = map[string]bool{
+	"http":   true,
+	"https":  true,
+	"mailto": true,
+	"ftp":    true,
+	"tel":    true,
+}
+
+const punctuation = "!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~"
+
+func escape(s string) string {
+	return html.EscapeString(s)
+}
+
+// 转换行内元素
+func (r *renderer) inline(text string) {
+	for i := 0; i < len(text); {
+		i = r.inlineAt(text, i)
+	}
+}
+
+// 转换从 text[i] 开始的行内元素，返回下一个元素的起始位置。
+func (r *renderer) inlineAt(text string, i int) int {
+	c := text[i]
+	switch c {
+	case '\\':
+		if i+1 < len(text) && text[i+1] == '\n' {
+			r.buf.WriteString("<br />\n")
+			return i + 2
+		}
+		if i+1 < len(text) && strings.IndexByte(punctuation, text[i+1]) >= 0 {
+			r.buf.WriteString(escape(text[i+1 : i+2]))
+			return i + 2
+		}
+	case '\n':
+		if strings.HasSuffix(r.buf.String(), "  ") {
+			r.trimTrailingSpaces()
+			r.buf.WriteString("<br />\n")
+		} else {
+			r.buf.WriteByte('\n')
+		}
+		return i + 1
+	case '`':
+		if n, ok := r.codeSpan(text, i); ok {
+			return n
+		}
+	case '&':
+		if m := entityExpr.FindString(text[i:]); m != "" {
+			if s := html.UnescapeString(m); s != m {
+				r.buf.WriteString(escape(s))
+				return i + len(m)
+			}
+		}
+	case '<':
+		if m := autolinkExpr.FindStringSubmatch(text[i:]); m != nil {
+			r.anchor(m[1], "", func() { r.buf.WriteString(escape(m[1])) })
+			return i + len(m[0])
+		}
+		if m := emailExpr.FindStringSubmatch(text[i:]); m != nil {
+			r.anchor("mailto:"+m[1], "", func() { r.buf.WriteString(escape(m[1])) })
+			return i + len(m[0])
+		}
+	case '!':
+		if i+1 < len(text) && text[i+1] == '[' {
+			if n, ok := r.linkAt(text, i+1, true); ok {
+				return n
+			}
+		}
+	case '[':
+		if n, ok := r.linkAt(text, i, false); ok {
+			return n
+		}
+	case '*', '_', '~':
+		if n, ok := r.emphasis(text, i); ok {
+			return n
+		}
+	case 'h', 'w':
+		if i == 0 || !isWordByte(text[i-1]) {
+			if m := bareURLExpr.FindString(text[i:]); m != "" {
+				url := m
+				if strings.HasPrefix(url, "www.") {
+					url = "http://" + url
+				}
+				r.anchor(url, "", func() { r.buf.WriteString(escape(m)) })
+				return i + len(m)
+			}
+		}
+	}
+
+	_, size := utf8.DecodeRuneInString(text[i:])
+	r.buf.WriteString(escape(text[i : i+size]))
+	return i + size
+}
+
+func isWordByte(b byte) bool {
+	return b == '_' || b >= '0' && b <= '9' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z'
+}
+
+func (r *renderer) trimTrailingSpaces() {
+	s := strings.TrimRight(r.buf.String(), " ")
+	r.buf.Reset()
+	r.buf.WriteString(s)
+}
+
+func (r *renderer) codeSpan(text string, i int) (int, bool) {
+	n := i
+	for n < len(text) && text[n] == '`' {
+		n++
+	}
+	fence := text[i:n]
+
+	for j := n; j < len(text); {
+		k := strings.Index(text[j:], fence)
+		if k < 0 {
+			break
+		}
+		k += j
+		end := k + len(fence)
+		if end < len(text) && text[end] == '`' { // 长度不相同的反引号
+			for end < len(text) && text[end] == '`' {
+				end++
+			}
+			j = end
+			continue
+		}
+
+		code := strings.ReplaceAll(text[n:k], "\n", " ")
+		if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.TrimSpace(code) != "" {
+			code = code[1 : len(code)-1]
+		}
+		r.buf.WriteString("<code>" + escape(code) + "</code>")
+		return end, true
+	}
+
+	r.buf.WriteString(escape(fence)) // 找不到结束符，原样输出。
+	return n, true
+}
+
+// 解析 [text](url "title") 或是 ![alt](url "title") 形式的链接，i 指向 [。
+//
+// 同时也支持引用链接定义的 [text][label]、[label][] 和 [label] 形式。
+func (r *renderer) linkAt(text string, i int, image bool) (int, bool) {
+	end := matchBracket(text, i)
+	if end < 0 {
+		return 0, false
+	}
+	label := text[i+1 : end]
+
+	if end+1 < len(text) && text[end+1] == '(' {
+		if dest, title, n, ok := parseDestination(text, end+1); ok {
+			r.writeLink(label, dest, title, image)
+			return n, true
+		}
+	}
+
+	ref, n := r.reference(text, label, end)
+	if ref == nil {
+		return 0, false
+	}
+	r.writeLink(label, ref.dest, ref.title, image)
+	return n, true
+}
+
+// 查找 text[end] 处的 ] 之后引用的链接定义，同时返回下一个元素的起始位置。
+//
+// label 为 ] 之前的内容，在未指定标签时作为标签使用。找不到时返回 nil。
+func (r *renderer) reference(text, label string, end int) (*reference, int) {
+	next := end + 1
+	if next < len(text) && text[next] == '[' {
+		if e := matchBracket(text, next); e >= 0 {
+			if l := text[next+1 : e]; !isBlank(l) {
+				label = l
+			}
+			next = e + 1
+		}
+	}
+
+	if ref, found := r.refs[normalizeLabel(label)]; found {
+		return ref, next
+	}
+	return nil, 0
+}
+
+// 输出链接或是图片，label 为链接的文本内容或是图片的 alt 属性。
+func (r *renderer) writeLink(label, dest, title string, image bool) {
+	if !image {
+		r.anchor(dest, title, func() { r.inline(label) })
+		return
+	}
+
+	url := r.url(dest)
+	if url == "" {
+		r.inline(label)
+		return
+	}
+
+	r.buf.WriteString(`<img src="` + escape(url) + `" alt="` + escape(plainText(label)) + `"`)
+	if title != "" {
+		r.buf.WriteString(` title="` + escape(title) + `"`)
+	}
+	r.buf.WriteString(" />")
+}
+
+// 输出链接，如果地址无效，则仅输出链接的文本内容。
+func (r *renderer) anchor(dest, title string, content func()) {
+	url := r.url(dest)
+	if url == "" {
+		content()
+		return
+	}
+
+	r.buf.WriteString(`<a href="` + escape(url) + `"`)
+	if title != "" {
+		r.buf.WriteString(` title="` + escape(title) + `"`)
+	}
+	r.buf.WriteString(">")
+	content()
+	r.buf.WriteString("</a>")
+}
+
+// 转换并过滤链接地址，返回空值表示该地址不可用。
+func (r *renderer) url(dest string) string {
+	if r.link != nil {
+		dest = r.link(dest)
+	}
+
+	// 与浏览器的处理方式相同，去掉地址中的换行符和制表符，以及首尾的控制字符和空格，
+	// 否则类似 java&#9;script: 的地址可以绕过对协议的检测。
+	dest = strings.Map(func(r rune) rune {
+		if r == '\t' || r == '\n' || r == '\r' {
+			return -1
+		}
+		return r
+	}, dest)
+	dest = strings.TrimFunc(dest, func(r rune) bool { return r <= ' ' })
+
+	if m := schemeExpr.FindStringSubmatch(dest); m != nil && !safeSchemes[strings.ToLower(m[1])] {
+		return ""
+	}
+	return dest
+}
+
+// 查找与 text[i] 处的 [ 相匹配的 ]
+func matchBracket(text string, i int) int {
+	depth := 0
+	for j := i; j < len(text); j++ {
+		switch text[j] {
+		case '\\':
+			j++
+		case '`':
+			if k := strings.IndexByte(text[j+1:], '`'); k >= 0 {
+				j += k + 1
+			}
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return j
+			}
+		}
+	}
+	return -1
+}
+
+// 解析 (url "title")，i 指向 (。
+func parseDestination(text string, i int) (dest, title string, next int, ok bool) {
+	end := -1
+	depth := 0
+	for j := i; j < len(text) && end < 0; j++ {
+		switch text[j] {
+		case '\\':
+			j++
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				end = j
+			}
+		}
+	}
+	if end < 0 {
+		return "", "", 0, false
+	}
+
+	content := strings.TrimSpace(text[i+1 : end])
+	if strings.HasPrefix(content, "<") {
+		k := strings.IndexByte(content, '>')
+		if k < 0 {
+			return "", "", 0, false
+		}
+		dest, content = content[1:k], strings.TrimSpace(content[k+1:])
+	} else {
+		k := strings.IndexFunc(content, unicode.IsSpace)
+		if k < 0 {
+			k = len(content)
+		}
+		dest, content = content[:k], strings.TrimSpace(content[k:])
+	}
+
+	if content != "" {
+		if len(content) < 2 {
+			return "", "", 0, false
+		}
+		first, last := content[0], content[len(content)-1]
+		if !(first == '"' && last == '"' || first == '\'' && last == '\'' || first == '(' && last == ')') {
+			return "", "", 0, false
+		}
+		title = unescapePunctuation(content[1 : len(content)-1])
+	}
+
+	return unescapePunctuation(dest), title, end + 1, true
+}
+
+func unescapePunctuation(s string) string {
+	var buf strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && strings.IndexByte(punctuation, s[i+1]) >= 0 {
+			i++
+		}
+		buf.WriteByte(s[i])
+	}
+	return html.UnescapeString(buf.String())
+}
+
+// 去掉 markdown 标记之后的文本，用于图片的 alt 属性。
+func plainText(text string) string {
+	r := &renderer{link: func(string) string { return "" }}
+	r.inline(text)
+	return html.UnescapeString(tagExpr.ReplaceAllString(r.buf.String(), ""))
+}
+
+// 解析强调、加粗以及删除线，i 指向 *、_ 或是 ~。
+func (r *renderer) emphasis(text string, i int) (int, bool) {
+	c := text[i]
+	n := i
+	for n < len(text) && text[n] == c {
+		n++
+	}
+	size := n - i
+	if c == '~' && size != 2 {
+		return 0, false
+	}
+	if size > 3 {
+		return 0, false
+	}
+
+	// 起始的分隔符之后不能是空白字符
+	if n >= len(text) || isSpaceByte(text[n]) {
+		return 0, false
+	}
+	// _ 不能出现在单词中间
+	if c == '_' && i > 0 && isWordByte(text[i-1]) {
+		return 0, false
+	}
+
+	delim := text[i:n]
+	for j := n; j < len(text); {
+		k := strings.Index(text[j:], delim)
+		if k < 0 {
+			return 0, false
+		}
+		k += j
+		end := k + size
+
+		switch {
+		case isSpaceByte(text[k-1]), // 结束的分隔符之前不能是空白字符
+			end < len(text) && text[end] == c, // 更长的分隔符
+			c == '_' && end < len(text) && isWordByte(text[end]):
+			j = k + 1
+			for j < len(text) && text[j] == c {
+				j++
+			}
+			continue
+		case text[k-1] == '\\':
+			j = k + 1
+			continue
+		}
+
+		inner := text[n:k]
+		switch {
+		case c == '~':
+			r.buf.WriteString("<del>")
+			r.inline(inner)
+			r.buf.WriteString("</del>")
+		case size == 1:
+			r.buf.WriteString("<em>")
+			r.inline(inner)
+			r.buf.WriteString("</em>")
+		case size == 2:
+			r.buf.WriteString("<strong>")
+			r.inline(inner)
+			r.buf.WriteString("</strong>")
+		default:
+			r.buf.WriteString("<em><strong>")
+			r.inline(inner)
+			r.buf.WriteString("</strong></em>")
+		}
+		return end, true
+	}
+
+	return 0, false
+}
+
+func isSpaceByte(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n'
+}
@@ -0,0 +1,524 @@
+// SPDX-License-Identifier: MIT
+
+// Package markdown 将 markdown 转换成 HTML
+//
+// 仅实现了 CommonMark 和 GFM 中的常用语法：
+// 标题、段落、引用、列表、代码块、表格、分隔线和链接定义，
+// 以及行内的强调、删除线、代码、链接、图片和自动链接。
+//
+// 输出的内容是经过过滤的：markdown 中的 HTML 代码都会被转义，
+// 链接和图片的地址仅允许相对地址以及 http、https、mailto 等协议。
+package markdown
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	atxHeadingExpr = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	hrExpr         = regexp.MustCompile(`^ {0,3}((?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	fenceExpr      = regexp.MustCompile("^( {0,3})(`{3,}|~{3,})[ \t]*([^`]*?)[ \t]*$")
+	listExpr       = regexp.MustCompile(`^( {0,3})([-+*]|\d{1,9}[.)])([ \t]+|$)`)
+	setextExpr     = regexp.MustCompile(`^ {0,3}(=+|-+)[ \t]*$`)
+	delimiterExpr  = regexp.MustCompile(`^[ \t]*\|?[ \t]*:?-+:?[ \t]*(\|[ \t]*:?-+:?[ \t]*)*\|?[ \t]*$`)
+	refDefExpr     = regexp.MustCompile(`^ {0,3}\[((?:[^\[\]\\]|\\.)+)\]:[ \t]*(<[^<>]*>|\S+)(?:[ \t]+("(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*'|\((?:[^()\\]|\\.)*\)))?[ \t]*$`)
+)
+
+// Render 将 markdown 格式的 text 转换成 HTML
+//
+// link 用于转换链接和图片的地址，返回空值表示该地址无效，此时仅输出链接的文本内容。
+// 可以为空，表示不对地址作转换。
+func Render(text string, link func(url string) string) string {
+	lines := splitLines(text)
+
+	// 链接定义可以出现在引用它的链接之后，所以先解析一遍用于收集所有的链接定义。
+	refs := make(map[string]*reference, 10)
+	(&renderer{refs: refs}).blocks(lines)
+
+	r := &renderer{link: link, refs: refs}
+	r.blocks(lines)
+	return strings.TrimSuffix(r.buf.String(), "\n")
+}
+
+type renderer struct {
+	buf  strings.Builder
+	link func(string) string
+	refs map[string]*reference // 链接定义，键名为经过 normalizeLabel 处理的标签。
+}
+
+// 由 [label]: url "title" 定义的链接
+type reference struct {
+	dest, title string
+}
+
+// 将 text 拆分成行，同时去掉所有行共同的缩进。
+func splitLines(text string) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	lines := strings.Split(text, "\n")
+
+	indent := 0
+	first := true
+	for i, line := range lines {
+		line = expandTabs(line)
+		lines[i] = line
+
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if n := indentOf(line); first || n < indent {
+			indent, first = n, false
+		}
+	}
+
+	for i, line := range lines {
+		if len(line) >= indent {
+			lines[i] = line[indent:]
+		} else {
+			lines[i] = ""
+		}
+	}
+	return lines
+}
+
+// 将行首的制表符转换成 4 个空格
+func expandTabs(line string) string {
+	for i, c := range line {
+		switch c {
+		case ' ':
+		case '\t':
+			return line[:i] + "    " + expandTabs(line[i+1:])
+		default:
+			return line
+		}
+	}
+	return line
+}
+
+func isBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+// 返回缩进的空格数量
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+func (r *renderer) blocks(lines []string) {
+	for i := 0; i < len(lines); {
+		i = r.block(lines, i)
+	}
+}
+
+// 解析从 lines[i] 开始的块，并返回下一个块的起始行。
+func (r *renderer) block(lines []string, i int) int {
+	line := lines[i]
+
+	switch {
+	case isBlank(line):
+		return i + 1
+	case indentOf(line) >= 4:
+		return r.indentedCode(lines, i)
+	case fenceExpr.MatchString(line):
+		return r.fencedCode(lines, i)
+	case atxHeadingExpr.MatchString(line):
+		m := atxHeadingExpr.FindStringSubmatch(line)
+		r.heading(len(m[1]), m[2])
+		return i + 1
+	case hrExpr.MatchString(line):
+		r.buf.WriteString("<hr />\n")
+		return i + 1
+	case strings.HasPrefix(strings.TrimLeft(line, " "), ">"):
+		return r.blockquote(lines, i)
+	case listExpr.MatchString(line):
+		return r.list(lines, i)
+	case i+1 < len(lines) && isTableRow(line) && delimiterExpr.MatchString(lines[i+1]) &&
+		len(splitRow(line)) == len(splitRow(lines[i+1])):
+		return r.table(lines, i)
+	default:
+		return r.paragraph(lines, i)
+	}
+}
+
+func (r *renderer) heading(level int, text string) {
+	tag := "h" + strconv.Itoa(level)
+	r.buf.WriteString("<" + tag + ">")
+	r.inline(strings.TrimSpace(text))
+	r.buf.WriteString("</" + tag + ">\n")
+}
+
+func (r *renderer) indentedCode(lines []string, i int) int {
+	code := make([]string, 0, 10)
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if isBlank(line) {
+			code = append(code, "")
+			continue
+		}
+		if indentOf(line) < 4 {
+			break
+		}
+		code = append(code, line[4:])
+	}
+
+	for len(code) > 0 && code[len(code)-1] == "" { // 去掉尾部的空行
+		code = code[:len(code)-1]
+	}
+	r.code("", code)
+	return i
+}
+
+func (r *renderer) fencedCode(lines []string, i int) int {
+	m := fenceExpr.FindStringSubmatch(lines[i])
+	indent, fence := len(m[1]), m[2]
+	lang := strings.Fields(m[3])
+
+	code := make([]string, 0, 10)
+	for i++; i < len(lines); i++ {
+		line := lines[i]
+		if t := strings.TrimSpace(line); indentOf(line) < 4 && strings.HasPrefix(t, fence) && strings.Trim(t, fence[:1]) == "" {
+			i++
+			break
+		}
+
+		n := indentOf(line)
+		if n > indent {
+			n = indent
+		}
+		code = append(code, line[n:])
+	}
+
+	if len(lang) > 0 {
+		r.code(html.UnescapeString(lang[0]), code)
+	} else {
+		r.code("", code)
+	}
+	return i
+}
+
+func (r *renderer) code(lang string, lines []string) {
+	r.buf.WriteString("<pre><code")
+	if lang != "" {
+		r.buf.WriteString(` class="language-` + escape(lang) + `"`)
+	}
+	r.buf.WriteString(">")
+	for _, line := range lines {
+		r.buf.WriteString(escape(line))
+		r.buf.WriteByte('\n')
+	}
+	r.buf.WriteString("</code></pre>\n")
+}
+
+func (r *renderer) blockquote(lines []string, i int) int {
+	quote := make([]string, 0, 10)
+	for ; i < len(lines); i++ {
+		line := strings.TrimLeft(lines[i], " ")
+		if !strings.HasPrefix(line, ">") {
+			// 延续上一行的段落内容
+			if isBlank(line) || len(quote) == 0 || isBlank(quote[len(quote)-1]) || r.isBlockStart(lines[i]) {
+				break
+			}
+			quote = append(quote, line)
+			continue
+		}
+
+		line = strings.TrimPrefix(line, ">")
+		quote = append(quote, strings.TrimPrefix(line, " "))
+	}
+
+	r.buf.WriteString("<blockquote>\n")
+	r.blocks(quote)
+	r.buf.WriteString("</blockquote>\n")
+	return i
+}
+
+type listItem struct {
+	lines []string
+}
+
+func (r *renderer) list(lines []string, i int) int {
+	m := listExpr.FindStringSubmatch(lines[i])
+	marker := m[2]
+	ordered := marker[0] >= '0' && marker[0] <= '9'
+	delim := marker[len(marker)-1:]
+
+	items := make([]*listItem, 0, 10)
+	loose := false
+	var item *listItem
+	var width int // 当前项内容的缩进
+
+	for ; i < len(lines); i++ {
+		line := lines[i]
+
+		if m := listExpr.FindStringSubmatch(line); item == nil || m != nil && indentOf(line) < width {
+			if m == nil || (m[2][0] >= '0' && m[2][0] <= '9') != ordered || m[2][len(m[2])-1:] != delim {
+				break
+			}
+			if item != nil && len(item.lines) > 0 && isBlank(item.lines[len(item.lines)-1]) {
+				loose = true
+			}
+
+			width = len(m[1]) + len(m[2]) + len(m[3])
+			if m[3] == "" || len(m[3]) > 4 { // 空行或是内容为缩进代码块
+				width = len(m[1]) + len(m[2]) + 1
+			}
+			item = &listItem{}
+			items = append(items, item)
+			if len(line) > width {
+				item.lines = append(item.lines, line[width:])
+			}
+			continue
+		}
+
+		switch {
+		case isBlank(line):
+			item.lines = append(item.lines, "")
+		case indentOf(line) >= width:
+			item.lines = append(item.lines, line[width:])
+		case len(item.lines) > 0 && !isBlank(item.lines[len(item.lines)-1]) && !r.isBlockStart(line):
+			item.lines = append(item.lines, strings.TrimLeft(line, " ")) // 延续上一行的段落内容
+		default:
+			return r.renderList(items, ordered, loose, marker, i)
+		}
+	}
+
+	return r.renderList(items, ordered, loose, marker, i)
+}
+
+func (r *renderer) renderList(items []*listItem, ordered, loose bool, marker string, next int) int {
+	// 尾部的空行并不属于列表项
+	for _, item := range items {
+		for len(item.lines) > 0 && isBlank(item.lines[len(item.lines)-1]) {
+			item.lines = item.lines[:len(item.lines)-1]
+		}
+	}
+
+	// 列表项中的块之间有空行，则为松散列表，嵌套列表中的空行不影响当前列表。
+	if !loose {
+	LOOP:
+		for _, item := range items {
+			nested := false // 是否处于嵌套的列表中
+			for j, line := range item.lines {
+				if isBlank(line) {
+					continue
+				}
+				afterBlank := j > 0 && isBlank(item.lines[j-1])
+
+				if indentOf(line) > 0 { // 缩进的内容属于嵌套列表的列表项，或是缩进代码块。
+					if afterBlank && !nested {
+						loose = true
+						break LOOP
+					}
+					continue
+				}
+
+				isList := listExpr.MatchString(line)
+				if afterBlank && !(nested && isList) {
+					loose = true
+					break LOOP
+				}
+				nested = isList
+			}
+		}
+	}
+
+	tag := "ul"
+	if ordered {
+		tag = "ol"
+	}
+	r.buf.WriteString("<" + tag)
+	if ordered {
+		if start, err := strconv.Atoi(marker[:len(marker)-1]); err == nil && start != 1 {
+			r.buf.WriteString(` start="` + strconv.Itoa(start) + `"`)
+		}
+	}
+	r.buf.WriteString(">\n")
+
+	for _, item := range items {
+		r.buf.WriteString("<li>")
+		if loose {
+			r.buf.WriteString("\n")
+			r.blocks(item.lines)
+		} else {
+			r.tightItem(item.lines)
+		}
+		r.buf.WriteString("</li>\n")
+	}
+
+	r.buf.WriteString("</" + tag + ">\n")
+	return next
+}
+
+// 紧凑列表的列表项中的段落不需要 p 标签
+//
+// 仅针对列表项中的顶层段落，嵌套的松散列表依然需要 p 标签。
+func (r *renderer) tightItem(lines []string) {
+	var content strings.Builder
+	for i := 0; i < len(lines); {
+		sub := &renderer{link: r.link, refs: r.refs}
+		i = sub.block(lines, i)
+
+		block := sub.buf.String()
+		if strings.HasPrefix(block, "<p>") && strings.HasSuffix(block, "</p>\n") {
+			block = strings.TrimSuffix(strings.TrimPrefix(block, "<p>"), "</p>\n") + "\n"
+		}
+		content.WriteString(block)
+	}
+	r.buf.WriteString(strings.TrimSuffix(content.String(), "\n"))
+}
+
+// 判断 line 是否为一个新块的开始，用于判断段落是否结束。
+func (r *renderer) isBlockStart(line string) bool {
+	t := strings.TrimLeft(line, " ")
+	return fenceExpr.MatchString(line) ||
+		atxHeadingExpr.MatchString(line) ||
+		hrExpr.MatchString(line) ||
+		strings.HasPrefix(t, ">") ||
+		(indentOf(line) < 4 && listExpr.MatchString(line) && !isBlank(listExpr.ReplaceAllString(line, "")))
+}
+
+func (r *renderer) paragraph(lines []string, i int) int {
+	// 段落起始处的链接定义
+	for ; i < len(lines); i++ {
+		m := refDefExpr.FindStringSubmatch(lines[i])
+		if m == nil || isBlank(normalizeLabel(m[1])) {
+			break
+		}
+		r.define(m[1], m[2], m[3])
+	}
+	if i >= len(lines) || isBlank(lines[i]) || r.isBlockStart(lines[i]) {
+		return i
+	}
+
+	para := []string{strings.TrimLeft(lines[i], " ")}
+	for i++; i < len(lines); i++ {
+		line := lines[i]
+		if isBlank(line) {
+			break
+		}
+
+		if m := setextExpr.FindStringSubmatch(line); m != nil {
+			level := 1
+			if m[1][0] == '-' {
+				level = 2
+			}
+			r.heading(level, strings.Join(para, "\n"))
+			return i + 1
+		}
+
+		if r.isBlockStart(line) ||
+			(i+1 < len(lines) && isTableRow(line) && delimiterExpr.MatchString(lines[i+1])) {
+			break
+		}
+		para = append(para, strings.TrimLeft(line, " "))
+	}
+
+	r.buf.WriteString("<p>")
+	r.inline(strings.TrimRight(strings.Join(para, "\n"), " \t"))
+	r.buf.WriteString("</p>\n")
+	return i
+}
+
+// 添加链接定义，相同标签的定义以第一个为准。
+func (r *renderer) define(label, dest, title string) {
+	label = normalizeLabel(label)
+	if _, found := r.refs[label]; found {
+		return
+	}
+
+	if strings.HasPrefix(dest, "<") {
+		dest = dest[1 : len(dest)-1]
+	}
+	if title != "" {
+		title = unescapePunctuation(title[1 : len(title)-1])
+	}
+	r.refs[label] = &reference{dest: unescapePunctuation(dest), title: title}
+}
+
+// 标签不区分大小写，且连续的空白字符视为一个空格。
+func normalizeLabel(label string) string {
+	return strings.ToLower(strings.Join(strings.Fields(label), " "))
+}
+
+func isTableRow(line string) bool {
+	return strings.Contains(line, "|")
+}
+
+// 将表格的行拆分成单元格
+func splitRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+
+	cells := make([]string, 0, 5)
+	start := 0
+	inCode := false
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '`':
+			inCode = !inCode
+		case '|':
+			if !inCode {
+				cells = append(cells, strings.TrimSpace(line[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(cells, strings.TrimSpace(line[start:]))
+}
+
+func (r *renderer) table(lines []string, i int) int {
+	header := splitRow(lines[i])
+	aligns := make([]string, 0, len(header))
+	for _, cell := range splitRow(lines[i+1]) {
+		left, right := strings.HasPrefix(cell, ":"), strings.HasSuffix(cell, ":")
+		switch {
+		case left && right:
+			aligns = append(aligns, "center")
+		case left:
+			aligns = append(aligns, "left")
+		case right:
+			aligns = append(aligns, "right")
+		default:
+			aligns = append(aligns, "")
+		}
+	}
+
+	r.buf.WriteString("<table>\n<thead>\n")
+	r.row("th", header, aligns)
+	r.buf.WriteString("</thead>\n")
+
+	i += 2
+	if i < len(lines) && !isBlank(lines[i]) && isTableRow(lines[i]) {
+		r.buf.WriteString("<tbody>\n")
+		for ; i < len(lines) && !isBlank(lines[i]) && !r.isBlockStart(lines[i]); i++ {
+			r.row("td", splitRow(lines[i]), aligns)
+		}
+		r.buf.WriteString("</tbody>\n")
+	}
+	r.buf.WriteString("</table>\n")
+
+	return i
+}
+
+func (r *renderer) row(tag string, cells, aligns []string) {
+	r.buf.WriteString("<tr>\n")
+	for i, align := range aligns {
+		r.buf.WriteString("<" + tag)
+		if align != "" {
+			r.buf.WriteString(` align="` + align + `"`)
+		}
+		r.buf.WriteString(">")
+		if i < len(cells) {
+			r.inline(strings.ReplaceAll(cells[i], `\|`, "|"))
+		}
+		r.buf.WriteString("</" + tag + ">\n")
+	}
+	r.buf.WriteString("</tr>\n")
+}
@@ -0,0 +1,290 @@
+// SPDX-License-Identifier: MIT
+
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/issue9/assert/v3"
+)
+
+func TestRender(t *testing.T) {
+	a := assert.New(t, false)
+
+	data := []*struct {
+		md, html string
+	}{
+		{md: "", html: ""},
+		{md: "text", html: "<p>text</p>"},
+		{md: "line1\nline2\n\nline3", html: "<p>line1\nline2</p>\n<p>line3</p>"},
+		{md: "line1  \nline2", html: "<p>line1<br />\nline2</p>"},
+
+		// 去掉共同的缩进
+		{md: "\n    # title\n\n    text\n", html: "<h1>title</h1>\n<p>text</p>"},
+
+		// 标题
+		{md: "## title ##", html: "<h2>title</h2>"},
+		{md: "title\n===", html: "<h1>title</h1>"},
+		{md: "title\n---", html: "<h2>title</h2>"},
+		{md: "#title", html: "<p>#title</p>"},
+
+		// 分隔线
+		{md: "a\n\n* * *\n\nb", html: "<p>a</p>\n<hr />\n<p>b</p>"},
+
+		// 代码
+		{md: "```go\nfunc main() {\n\t<b>\n}\n```", html: "<pre><code class=\"language-go\">func main() {\n    &lt;b&gt;\n}\n</code></pre>"},
+		{md: "~~~\ncode\n~~~\ntext", html: "<pre><code>code\n</code></pre>\n<p>text</p>"},
+		{md: "text\n\n    code\n\n    code2", html: "<p>text</p>\n<pre><code>code\n\ncode2\n</code></pre>"},
+		{md: "use `a < b` and ``x`y``", html: "<p>use <code>a &lt; b</code> and <code>x`y</code></p>"},
+
+		// 引用
+		{md: "> quote\ncontinue\n\ntext", html: "<blockquote>\n<p>quote\ncontinue</p>\n</blockquote>\n<p>text</p>"},
+
+		// 列表
+		{md: "- a\n- b\n  - c\n- d", html: "<ul>\n<li>a</li>\n<li>b\n<ul>\n<li>c</li>\n</ul></li>\n<li>d</li>\n</ul>"},
+		{md: "3. a\n4. b", html: "<ol start=\"3\">\n<li>a</li>\n<li>b</li>\n</ol>"},
+		{md: "1. a\n\n2. b", html: "<ol>\n<li>\n<p>a</p>\n</li>\n<li>\n<p>b</p>\n</li>\n</ol>"},
+		{md: "- a\n\ntext", html: "<ul>\n<li>a</li>\n</ul>\n<p>text</p>"},
+
+		// 表格
+		{
+			md:   "| name | value |\n|:-----|------:|\n| `a|b` | 1 |\n| c \\| d |",
+			html: "<table>\n<thead>\n<tr>\n<th align=\"left\">name</th>\n<th align=\"right\">value</th>\n</tr>\n</thead>\n<tbody>\n<tr>\n<td align=\"left\"><code>a|b</code></td>\n<td align=\"right\">1</td>\n</tr>\n<tr>\n<td align=\"left\">c | d</td>\n<td align=\"right\"></td>\n</tr>\n</tbody>\n</table>",
+		},
+
+		// 强调
+		{md: "*em* **strong** ***both*** ~~del~~", html: "<p><em>em</em> <strong>strong</strong> <em><strong>both</strong></em> <del>del</del></p>"},
+		{md: "snake_case_name and _em_", html: "<p>snake_case_name and <em>em</em></p>"},
+		{md: "a * b * c", html: "<p>a * b * c</p>"},
+		{md: `\*not em\*`, html: "<p>*not em*</p>"},
+
+		// 链接
+		{md: `[link](https://example.com "title")`, html: `<p><a href="https://example.com" title="title">link</a></p>`},
+		{md: `[**b**](/path)`, html: `<p><a href="/path"><strong>b</strong></a></p>`},
+		{md: `![alt *x*](img.png)`, html: `<p><img src="img.png" alt="alt x" /></p>`},
+		{md: `<https://example.com/?a=1&b=2>`, html: `<p><a href="https://example.com/?a=1&amp;b=2">https://example.com/?a=1&amp;b=2</a></p>`},
+		{md: `<user@example.com>`, html: `<p><a href="mailto:user@example.com">user@example.com</a></p>`},
+		{md: `visit https://example.com/path.`, html: `<p>visit <a href="https://example.com/path">https://example.com/path</a>.</p>`},
+		{md: `visit www.example.com`, html: `<p>visit <a href="http://www.example.com">www.example.com</a></p>`},
+		{md: `[not link] (x)`, html: `<p>[not link] (x)</p>`},
+
+		// 过滤
+		{md: `<script>alert(1)</script>`, html: `<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>`},
+		{md: `[x](javascript:alert(1))`, html: `<p>x</p>`},
+		{md: `<javascript:alert(1)>`, html: `<p>javascript:alert(1)</p>`},
+		{md: `![x](data:image/png;base64,xx)`, html: `<p>x</p>`},
+		{md: `&copy; &amp; &nbsp1`, html: `<p>© &amp; &amp;nbsp1</p>`},
+	}
+
+	for i, item := range data {
+		a.Equal(Render(item.md, nil), item.html, "not equal at %d", i)
+	}
+}
+
+func TestRender_list(t *testing.T) {
+	a := assert.New(t, false)
+
+	data := []*struct {
+		md, html string
+	}{
+		// 多层嵌套
+		{
+			md:   "- a\n  - b\n    - c\n  - d\n- e",
+			html: "<ul>\n<li>a\n<ul>\n<li>b\n<ul>\n<li>c</li>\n</ul></li>\n<li>d</li>\n</ul></li>\n<li>e</li>\n</ul>",
+		},
+
+		// 有序列表与无序列表相互嵌套
+		{
+			md:   "1. a\n   - b\n   - c\n2. d",
+			html: "<ol>\n<li>a\n<ul>\n<li>b</li>\n<li>c</li>\n</ul></li>\n<li>d</li>\n</ol>",
+		},
+		{
+			md:   "- a\n  1. b\n  2. c",
+			html: "<ul>\n<li>a\n<ol>\n<li>b</li>\n<li>c</li>\n</ol></li>\n</ul>",
+		},
+
+		// 嵌套列表中的行内元素和代码块
+		{
+			md:   "- a\n  - `b` **c**\n\n        code",
+			html: "<ul>\n<li>a\n<ul>\n<li>\n<p><code>b</code> <strong>c</strong></p>\n<pre><code>code\n</code></pre>\n</li>\n</ul></li>\n</ul>",
+		},
+
+		// 不同的标记符号表示不同的列表
+		{md: "- a\n+ b", html: "<ul>\n<li>a</li>\n</ul>\n<ul>\n<li>b</li>\n</ul>"},
+		{md: "1. a\n1) b", html: "<ol>\n<li>a</li>\n</ol>\n<ol>\n<li>b</li>\n</ol>"},
+
+		// 缩进不足的行为延续的段落内容
+		{md: "- a\nb\n- c", html: "<ul>\n<li>a\nb</li>\n<li>c</li>\n</ul>"},
+	}
+
+	for i, item := range data {
+		a.Equal(Render(item.md, nil), item.html, "not equal at %d", i)
+	}
+}
+
+func TestRender_reference(t *testing.T) {
+	a := assert.New(t, false)
+
+	data := []*struct {
+		md, html string
+	}{
+		{md: "[a][x]\n\n[x]: https://example.com", html: `<p><a href="https://example.com">a</a></p>`},
+		{md: "[x]: https://example.com\n\n[a][x]", html: `<p><a href="https://example.com">a</a></p>`},
+		{md: "[x][]\n\n[x]: /x \"title\"", html: `<p><a href="/x" title="title">x</a></p>`},
+		{md: "[x]\n\n[x]: </x y> 'title'", html: `<p><a href="/x y" title="title">x</a></p>`},
+		{md: "[b  *C*]\n\n[B *c*]: /bc (title)", html: `<p><a href="/bc" title="title">b  <em>C</em></a></p>`},
+		{md: "![alt][logo]\n\n[logo]: logo.png", html: `<p><img src="logo.png" alt="alt" /></p>`},
+
+		// 相同的标签以第一个定义为准
+		{md: "[x]\n\n[x]: /1\n[x]: /2", html: `<p><a href="/1">x</a></p>`},
+
+		// 定义之后的段落
+		{md: "[x]: /x\ntext [x]", html: `<p>text <a href="/x">x</a></p>`},
+
+		// 不存在的定义
+		{md: "[a][x] [y]", html: `<p>[a][x] [y]</p>`},
+		{md: "[a][x]\n\n[y]: /y", html: `<p>[a][x]</p>`},
+
+		// 代码块中的内容不是定义
+		{md: "```\n[x]: /x\n```\n[x]", html: "<pre><code>[x]: /x\n</code></pre>\n<p>[x]</p>"},
+
+		// 行内链接优先
+		{md: "[x](/inline)\n\n[x]: /ref", html: `<p><a href="/inline">x</a></p>`},
+
+		// 定义中的地址同样会被过滤
+		{md: "[x]\n\n[x]: javascript:alert(1)", html: `<p>x</p>`},
+	}
+
+	for i, item := range data {
+		a.Equal(Render(item.md, nil), item.html, "not equal at %d", i)
+	}
+}
+
+func TestRender_html(t *testing.T) {
+	a := assert.New(t, false)
+
+	data := []*struct {
+		md, html string
+	}{
+		{md: `a <b>bold</b> & c`, html: `<p>a &lt;b&gt;bold&lt;/b&gt; &amp; c</p>`},
+		{md: `<img src=x onerror="alert(1)">`, html: `<p>&lt;img src=x onerror=&#34;alert(1)&#34;&gt;</p>`},
+		{md: "<div>\n*a*\n</div>", html: "<p>&lt;div&gt;\n<em>a</em>\n&lt;/div&gt;</p>"},
+		{md: `<!-- comment -->`, html: `<p>&lt;!-- comment --&gt;</p>`},
+		{md: `**<i>x</i>**`, html: `<p><strong>&lt;i&gt;x&lt;/i&gt;</strong></p>`},
+		{md: `&lt;b&gt;`, html: `<p>&lt;b&gt;</p>`},
+		{md: `&#60;script&#62;`, html: `<p>&lt;script&gt;</p>`},
+		{md: "# <h1>", html: "<h1>&lt;h1&gt;</h1>"},
+		{md: "| <b> |\n|---|", html: "<table>\n<thead>\n<tr>\n<th>&lt;b&gt;</th>\n</tr>\n</thead>\n</table>"},
+
+		// 属性中的内容
+		{md: `[x](/a "t\"><script>")`, html: `<p><a href="/a" title="t&#34;&gt;&lt;script&gt;">x</a></p>`},
+		{md: `![a"b](/a?x=1&y="2")`, html: `<p><img src="/a?x=1&amp;y=&#34;2&#34;" alt="a&#34;b" /></p>`},
+		{md: "```x\"><script>\ncode\n```", html: "<pre><code class=\"language-x&#34;&gt;&lt;script&gt;\">code\n</code></pre>"},
+	}
+
+	for i, item := range data {
+		a.Equal(Render(item.md, nil), item.html, "not equal at %d", i)
+	}
+}
+
+func TestRender_codeSpan(t *testing.T) {
+	a := assert.New(t, false)
+
+	data := []*struct {
+		md, html string
+	}{
+		{md: "`a`", html: "<p><code>a</code></p>"},
+		{md: "`` `a` ``", html: "<p><code>`a`</code></p>"},
+		{md: "``a`b``", html: "<p><code>a`b</code></p>"},
+		{md: "`a``b`", html: "<p><code>a``b</code></p>"},
+		{md: "```a``b```", html: "<p><code>a``b</code></p>"},
+		{md: "`` ` ``", html: "<p><code>`</code></p>"},
+		{md: "` `` `", html: "<p><code>``</code></p>"},
+		{md: "`  `", html: "<p><code>  </code></p>"},
+		{md: "`a\nb`", html: "<p><code>a b</code></p>"},
+		{md: "`<a>&amp;`", html: "<p><code>&lt;a&gt;&amp;amp;</code></p>"},
+		{md: "`*a*` [`b`](/b)", html: `<p><code>*a*</code> <a href="/b"><code>b</code></a></p>`},
+
+		// 没有结束符
+		{md: "``a`", html: "<p>``a`</p>"},
+		{md: "`a", html: "<p>`a</p>"},
+	}
+
+	for i, item := range data {
+		a.Equal(Render(item.md, nil), item.html, "not equal at %d", i)
+	}
+}
+
+func TestRender_url(t *testing.T) {
+	a := assert.New(t, false)
+
+	data := []*struct {
+		md, html string
+	}{
+		// 允许的协议
+		{md: `[x](http://example.com)`, html: `<p><a href="http://example.com">x</a></p>`},
+		{md: `[x](HTTPS://example.com)`, html: `<p><a href="HTTPS://example.com">x</a></p>`},
+		{md: `[x](mailto:a@example.com)`, html: `<p><a href="mailto:a@example.com">x</a></p>`},
+		{md: `[x](tel:123)`, html: `<p><a href="tel:123">x</a></p>`},
+		{md: `[x](/path?a:b)`, html: `<p><a href="/path?a:b">x</a></p>`},
+		{md: `[x](#id)`, html: `<p><a href="#id">x</a></p>`},
+
+		// 过滤的协议
+		{md: `[x](javascript:alert(1))`, html: `<p>x</p>`},
+		{md: `[x](JavaScript:alert(1))`, html: `<p>x</p>`},
+		{md: `[x](JAVASCRIPT:alert(1))`, html: `<p>x</p>`},
+		{md: `[x](vbscript:msgbox(1))`, html: `<p>x</p>`},
+		{md: `[x](data:text/html;base64,PHNjcmlwdD4=)`, html: `<p>x</p>`},
+		{md: `[x](DATA:text/html,x)`, html: `<p>x</p>`},
+		{md: `[x](file:///etc/passwd)`, html: `<p>x</p>`},
+		{md: `![x](javascript:alert(1))`, html: `<p>x</p>`},
+		{md: `<JavaScript:alert(1)>`, html: `<p>JavaScript:alert(1)</p>`},
+		{md: `<data:text/html,x>`, html: `<p>data:text/html,x</p>`},
+
+		// 实体编码
+		{md: `[x](javascript&#58;alert(1))`, html: `<p>x</p>`},
+		{md: `[x](javascript&#x3A;alert(1))`, html: `<p>x</p>`},
+		{md: `[x](javascript&colon;alert(1))`, html: `<p>x</p>`},
+		{md: `[x](&#106;avascript:alert(1))`, html: `<p>x</p>`},
+		{md: `[x](&#x4A;avascript:alert(1))`, html: `<p>x</p>`},
+		{md: `[x](&#100;ata:text/html,x)`, html: `<p>x</p>`},
+
+		// 浏览器会忽略的字符
+		{md: `[x](java&#9;script:alert(1))`, html: `<p>x</p>`},
+		{md: `[x](java&#x0A;script:alert(1))`, html: `<p>x</p>`},
+		{md: `[x](java&Tab;script:alert(1))`, html: `<p>x</p>`},
+		{md: `[x](&#1;javascript:alert(1))`, html: `<p>x</p>`},
+		{md: `[x](<  javascript:alert(1)>)`, html: `<p>x</p>`},
+
+		// 转义字符
+		{md: `[x](javascript\:alert(1))`, html: `<p>x</p>`},
+		{md: `[x](<javascript:alert(1)>)`, html: `<p>x</p>`},
+	}
+
+	for i, item := range data {
+		a.Equal(Render(item.md, nil), item.html, "not equal at %d", i)
+	}
+}
+
+func TestRender_link(t *testing.T) {
+	a := assert.New(t, false)
+
+	urls := make([]string, 0, 5)
+	link := func(url string) string {
+		urls = append(urls, url)
+		switch {
+		case url == "api:exists":
+			return "#exists"
+		case strings.HasPrefix(url, "api:"):
+			return ""
+		case !strings.Contains(url, "://"):
+			return "https://example.com/" + url
+		}
+		return url
+	}
+
+	html := Render("[a](api:exists) [b](api:not-exists) [c](docs/c.md) <https://example.com> ![d](d.png)", link)
+	a.Equal(html, `<p><a href="#exists">a</a> b <a href="https://example.com/docs/c.md">c</a> <a href="https://example.com">https://example.com</a> <img src="https://example.com/d.png" alt="d" /></p>`).
+		Equal(urls, []string{"api:exists", "api:not-exists", "docs/c.md", "https://example.com", "d.png"})
+}
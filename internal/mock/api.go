@@ -5,7 +5,9 @@ package mock
 import (
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 
@@ -24,6 +26,11 @@ func (m *mock) buildAPI(api *ast.API) http.Handler {
 			m.msgHandler.Locale(core.Warn, locale.DeprecatedWarn, r.Method, r.URL.Path, api.Deprecated.V())
 		}
 
+		if err := validSecurity(m.doc, api.Securities, r); err != nil {
+			m.handleUnauthorized(w, r, api.Securities, err)
+			return
+		}
+
 		if err := validQueries(api.Path.Queries, r); err != nil {
 			m.handleError(w, r, "", err)
 			return
@@ -37,6 +44,11 @@ func (m *mock) buildAPI(api *ast.API) http.Handler {
 			}
 		}
 
+		if err := validCookies(api.Cookies, r); err != nil {
+			m.handleError(w, r, "", err)
+			return
+		}
+
 		if len(api.Requests) > 0 { // GET、OPTIONS 之类的可能没有 body
 			if err := validRequest(m.doc.XMLNamespaces, api.Requests, r); err != nil {
 				m.handleError(w, r, "request.body.", err)
@@ -53,7 +65,11 @@ func validRequest(ns []*ast.XMLNamespace, requests []*ast.Request, r *http.Reque
 	if ct == "" || ct == "*/*" || strings.HasSuffix(ct, "/*") { // 用户提交的 content-type 必须是明确的值
 		return core.NewError(locale.ErrInvalidValue).WithField("headers[content-type]")
 	}
-	req := findRequestByContentType(requests, ct)
+	mt, params, err := mime.ParseMediaType(ct) // multipart/form-data 等会带 boundary 等参数
+	if err != nil {
+		return core.NewError(locale.ErrInvalidFormat).WithField("headers[content-type]")
+	}
+	req := findRequestByContentType(requests, mt)
 	if req == nil {
 		return core.NewError(locale.ErrInvalidValue).WithField("headers[content-type]")
 	}
@@ -63,6 +79,9 @@ func validRequest(ns []*ast.XMLNamespace, requests []*ast.Request, r *http.Reque
 			return err
 		}
 	}
+	if err := validCookies(req.Cookies, r); err != nil {
+		return err
+	}
 
 	content, err := io.ReadAll(r.Body)
 	if err != nil {
@@ -72,11 +91,15 @@ func validRequest(ns []*ast.XMLNamespace, requests []*ast.Request, r *http.Reque
 		return err
 	}
 
-	switch ct {
+	switch mt {
 	case "application/json":
 		return validJSON(req, content)
 	case "application/xml", "text/xml":
 		return validXML(ns, req, content)
+	case mimetypeFormURLEncoded:
+		return validURLEncodedForm(req, content)
+	case mimetypeMultipartForm:
+		return validMultipartForm(req, params["boundary"], content)
 	default:
 		return core.NewError(locale.ErrInvalidValue).WithField("headers[content-type]")
 	}
@@ -104,20 +127,31 @@ func (m *mock) renderResponse(api *ast.API, w http.ResponseWriter, r *http.Reque
 	w.Header().Set("Content-Type", accept)
 	w.Header().Set("Server", core.Name)
 	for _, item := range resp.Headers {
-		switch primitive, _ := ast.ParseType(item.Type.V()); primitive {
-		case ast.TypeBool:
-			w.Header().Set(item.Name.V(), strconv.FormatBool(m.gen.generateBool()))
-		case ast.TypeNumber:
-			w.Header().Set(item.Name.V(), fmt.Sprint(m.gen.generateNumber(item)))
-		case ast.TypeString:
-			w.Header().Set(item.Name.V(), m.gen.generateString(item))
-		default:
+		val, err := m.gen.generateValue(item)
+		if err != nil {
+			m.handleError(w, r, "response.headers", err)
+			return
+		}
+		if val == nil {
 			m.handleError(w, r, "response.headers", locale.NewError(locale.ErrInvalidFormat))
 			return
 		}
+		w.Header().Set(item.Name.V(), fmt.Sprint(val))
+	}
+	for _, item := range resp.Cookies {
+		val, err := m.gen.generateValue(item)
+		if err != nil {
+			m.handleError(w, r, "response.cookies", err)
+			return
+		}
+		if val == nil {
+			m.handleError(w, r, "response.cookies", locale.NewError(locale.ErrInvalidFormat))
+			return
+		}
+		http.SetCookie(w, &http.Cookie{Name: item.Name.V(), Value: fmt.Sprint(val)})
 	}
 
-	w.WriteHeader(resp.Status.V())
+	w.WriteHeader(resp.Status.Code())
 	if _, err := w.Write(data); err != nil {
 		m.msgHandler.Error(err) // 此时状态码已经输出
 	}
@@ -185,6 +219,29 @@ func matchContentType(ct string, accepts []*qheader.Item) bool {
 
 // 处理 serveHTTP 中的错误
 func (m *mock) handleError(w http.ResponseWriter, r *http.Request, field string, err error) {
+	m.writeError(w, r, field, err, http.StatusBadRequest)
+}
+
+// 处理身份验证失败的请求
+func (m *mock) handleUnauthorized(w http.ResponseWriter, r *http.Request, securities []*ast.SecurityValue, err error) {
+	for _, s := range securities {
+		sec := m.doc.Security(s.V())
+		if sec == nil {
+			continue
+		}
+
+		switch sec.Type.V() {
+		case ast.SecurityTypeHTTP:
+			w.Header().Add("WWW-Authenticate", sec.Scheme.V())
+		case ast.SecurityTypeOAuth2:
+			w.Header().Add("WWW-Authenticate", "Bearer")
+		}
+	}
+
+	m.writeError(w, r, "security", err, http.StatusUnauthorized)
+}
+
+func (m *mock) writeError(w http.ResponseWriter, r *http.Request, field string, err error, status int) {
 	// 这并不是一个真实存在的 URI
 	file := core.URI(r.Method + ": " + r.URL.Path)
 
@@ -201,12 +258,100 @@ func (m *mock) handleError(w http.ResponseWriter, r *http.Request, field string,
 	}
 
 	m.msgHandler.Error(err)
-	w.WriteHeader(http.StatusBadRequest)
+	w.WriteHeader(status)
+}
+
+// 验证请求是否包含 securities 中任意一种身份验证方案所需的凭证
+//
+// 未在 doc 中定义的身份验证方案会被忽略。
+func validSecurity(doc *ast.APIDoc, securities []*ast.SecurityValue, r *http.Request) error {
+	var required bool
+	for _, s := range securities {
+		sec := doc.Security(s.V())
+		if sec == nil {
+			continue
+		}
+
+		if hasCredential(sec, r) {
+			return nil
+		}
+		required = true
+	}
+
+	if required {
+		return core.NewError(locale.ErrUnauthorized)
+	}
+	return nil
+}
+
+func hasCredential(sec *ast.Security, r *http.Request) bool {
+	switch sec.Type.V() {
+	case ast.SecurityTypeHTTP:
+		return hasAuthorization(r, sec.Scheme.V())
+	case ast.SecurityTypeOAuth2:
+		return hasAuthorization(r, "bearer")
+	case ast.SecurityTypeAPIKey:
+		key := sec.Key.V()
+		switch sec.IN.V() {
+		case ast.SecurityInHeader:
+			return r.Header.Get(key) != ""
+		case ast.SecurityInQuery:
+			return r.URL.Query().Get(key) != ""
+		case ast.SecurityInCookie:
+			c, err := r.Cookie(key)
+			return err == nil && c.Value != ""
+		}
+	}
+	return false
+}
+
+// 报头 Authorization 是否包含指定验证方案的凭证
+func hasAuthorization(r *http.Request, scheme string) bool {
+	if strings.ToLower(scheme) == "basic" {
+		_, _, ok := r.BasicAuth()
+		return ok
+	}
+
+	auth := r.Header.Get("Authorization")
+	if len(auth) <= len(scheme)+1 || auth[len(scheme)] != ' ' {
+		return false
+	}
+	return strings.EqualFold(auth[:len(scheme)], scheme) && strings.TrimSpace(auth[len(scheme)+1:]) != ""
 }
 
 func validQueries(queries []*ast.Param, r *http.Request) error {
-	for _, query := range queries {
-		field := "queries[" + query.Name.V() + "]."
+	if len(queries) == 0 {
+		return nil
+	}
+	return validValues(queries, r.URL.Query(), "queries")
+}
+
+// 验证请求中的 cookie 是否符合 cookies 的定义
+func validCookies(cookies []*ast.Param, r *http.Request) error {
+	for _, cookie := range cookies {
+		var val string
+		if c, err := r.Cookie(cookie.Name.V()); err == nil {
+			val = c.Value
+		}
+
+		field := "cookies[" + cookie.Name.V() + "]"
+		if err := validSimpleParam(cookie, field, val); err != nil {
+			if serr, ok := err.(*core.Error); ok {
+				serr.Field = field + "." + serr.Field
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// 验证 values 中的值是否符合 params 的定义
+//
+// 用于查询参数以及表单等以 url.Values 表示的数据，name 为错误信息中字段的前缀。
+func validValues(params []*ast.Param, values url.Values, name string) error {
+	for _, param := range params {
+		field := name + "[" + param.Name.V() + "]."
 
 		valid := func(p *ast.Param, v string) error {
 			err := validSimpleParam(p, field, v)
@@ -216,27 +361,33 @@ func validQueries(queries []*ast.Param, r *http.Request) error {
 			return err
 		}
 
-		if !query.Array.V() {
-			if err := valid(query, r.FormValue(query.Name.V())); err != nil {
+		if !param.Array.V() {
+			if err := valid(param, values.Get(param.Name.V())); err != nil {
 				return err
 			}
-		} else if !query.ArrayStyle.V() { // 默认的 form 格式
-			if err := r.ParseForm(); err != nil {
-				return err
-			}
-			for _, v := range r.Form[query.Name.V()] {
-				if err := valid(query, v); err != nil {
-					return err
-				}
-			}
+			continue
+		}
+
+		var vals []string
+		if !param.ArrayStyle.V() { // 默认的 form 格式
+			vals = values[param.Name.V()]
 		} else {
-			values := strings.Split(r.FormValue(query.Name.V()), ",")
-			for _, v := range values {
-				if err := valid(query, v); err != nil {
-					return err
-				}
+			vals = strings.Split(values.Get(param.Name.V()), ",")
+		}
+
+		for _, v := range vals {
+			if err := valid(param, v); err != nil {
+				return err
 			}
 		}
+
+		if len(vals) == 0 && param.Optional.V() {
+			continue
+		}
+		if err := validItems(param, len(vals), vals); err != nil {
+			err.Field = field + err.Field
+			return err
+		}
 	}
 
 	return nil
@@ -265,6 +416,15 @@ func validSimpleParam(p *ast.Param, name, val string) error {
 		if !is.Number(val) {
 			return core.NewError(locale.ErrInvalidFormat)
 		}
+	case ast.TypeInt32, ast.TypeInt64:
+		v, err := strconv.ParseFloat(val, 64)
+		if err != nil || !isValidNumberFormat(p.Type.V(), v) {
+			return core.NewError(locale.ErrInvalidFormat)
+		}
+	case ast.TypeUUID, ast.TypeIPv4, ast.TypeIPv6, ast.TypeHostname, ast.TypeByte:
+		if !isValidStringFormat(p.Type.V(), val) {
+			return core.NewError(locale.ErrInvalidFormat)
+		}
 	case ast.TypeString:
 	case ast.TypeObject:
 	case ast.TypeNone:
@@ -273,6 +433,12 @@ func validSimpleParam(p *ast.Param, name, val string) error {
 		}
 	}
 
+	if val != "" || !p.Optional.V() {
+		if err := validTextConstraints(p, val); err != nil {
+			return err
+		}
+	}
+
 	if isEnum(p) {
 		found := false
 		for _, e := range p.Enums {
@@ -11,6 +11,7 @@ import (
 	"github.com/issue9/assert/v3"
 	"github.com/issue9/qheader"
 
+	"github.com/caixw/apidoc/v7/core"
 	"github.com/caixw/apidoc/v7/internal/ast"
 	"github.com/caixw/apidoc/v7/internal/xmlenc"
 )
@@ -239,6 +240,36 @@ func TestValidRequest(t *testing.T) {
 	r.Header.Set("content-type", "not-exists")
 	r.Header.Set("encoding", "xxx")
 	a.Error(validRequest(nil, []*ast.Request{dataWithHeader.Type}, r))
+
+	// 匹配 application/x-www-form-urlencoded
+	form := &ast.Request{
+		Mimetype: &ast.Attribute{Value: xmlenc.String{Value: "application/x-www-form-urlencoded"}},
+		Type:     &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeObject}},
+		Items:    []*ast.Param{newFormParam("age", ast.TypeNumber)},
+	}
+	r = httptest.NewRequest(http.MethodPost, "/path", bytes.NewBufferString("age=5"))
+	r.Header.Set("content-type", "application/x-www-form-urlencoded; charset=utf-8")
+	a.NotError(validRequest(nil, []*ast.Request{form}, r))
+
+	// 匹配 multipart/form-data
+	multi := &ast.Request{
+		Mimetype: &ast.Attribute{Value: xmlenc.String{Value: "multipart/form-data"}},
+		Type:     formRequest.Type,
+		Items:    formRequest.Items,
+	}
+	content, boundary := newMultipartForm(a, map[string]string{"name": "n1", "age": "5"}, &formFile{
+		field:    "avatar",
+		filename: "avatar.png",
+		content:  "png",
+	})
+	r = httptest.NewRequest(http.MethodPost, "/path", bytes.NewBuffer(content))
+	r.Header.Set("content-type", "multipart/form-data; boundary="+boundary)
+	a.NotError(validRequest(nil, []*ast.Request{multi}, r))
+
+	// content-type 格式错误
+	r = httptest.NewRequest(http.MethodPost, "/path", bytes.NewBuffer(content))
+	r.Header.Set("content-type", "multipart/form-data; boundary")
+	a.Error(validRequest(nil, []*ast.Request{multi}, r))
 }
 
 func TestBuildResponse(t *testing.T) {
@@ -294,6 +325,28 @@ func TestValidSimpleParam(t *testing.T) {
 			p:     &ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeNumber}}},
 			v:     "-10.2",
 		},
+		{
+			title: "number.int32",
+			p:     &ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeInt32}}},
+			v:     "-1024",
+		},
+		{
+			title: "number.int32 failed",
+			p:     &ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeInt32}}},
+			v:     "4294967296",
+			err:   true,
+		},
+		{
+			title: "string.uuid",
+			p:     &ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeUUID}}},
+			v:     "123e4567-e89b-12d3-a456-426614174000",
+		},
+		{
+			title: "string.ipv4 failed",
+			p:     &ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeIPv4}}},
+			v:     "::1",
+			err:   true,
+		},
 		{
 			title: "number failed",
 			p:     &ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeNumber}}},
@@ -477,6 +530,45 @@ func TestValidQueries(t *testing.T) {
 			r:   httptest.NewRequest(http.MethodGet, "/users?k1=1&k2=2,3,not-number", nil),
 			err: true,
 		},
+		{
+			title: "数组-超出 max-items",
+			p: []*ast.Param{
+				{
+					Name:     &ast.Attribute{Value: xmlenc.String{Value: "k2"}},
+					Type:     &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeNumber}},
+					Array:    &ast.BoolAttribute{Value: ast.Bool{Value: true}},
+					MaxItems: newNumberAttribute(2),
+				},
+			},
+			r:   httptest.NewRequest(http.MethodGet, "/users?k2=2&k2=3&k2=4", nil),
+			err: true,
+		},
+		{
+			title: "数组-重复的元素",
+			p: []*ast.Param{
+				{
+					Name:        &ast.Attribute{Value: xmlenc.String{Value: "k2"}},
+					Type:        &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeNumber}},
+					Array:       &ast.BoolAttribute{Value: ast.Bool{Value: true}},
+					ArrayStyle:  &ast.BoolAttribute{Value: ast.Bool{Value: true}},
+					UniqueItems: newBoolAttribute(true),
+				},
+			},
+			r:   httptest.NewRequest(http.MethodGet, "/users?k2=2,3,2", nil),
+			err: true,
+		},
+		{
+			title: "超出 max",
+			p: []*ast.Param{
+				{
+					Name: &ast.Attribute{Value: xmlenc.String{Value: "k1"}},
+					Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeNumber}},
+					Max:  newNumberAttribute(10),
+				},
+			},
+			r:   httptest.NewRequest(http.MethodGet, "/users?k1=11", nil),
+			err: true,
+		},
 	}
 
 	for _, item := range data {
@@ -488,3 +580,106 @@ func TestValidQueries(t *testing.T) {
 		}
 	}
 }
+
+func TestValidSecurity(t *testing.T) {
+	a := assert.New(t, false)
+
+	doc := &ast.APIDoc{
+		Securities: []*ast.Security{
+			{
+				Name:   &ast.Attribute{Value: xmlenc.String{Value: "basic"}},
+				Type:   &ast.Attribute{Value: xmlenc.String{Value: ast.SecurityTypeHTTP}},
+				Scheme: &ast.Attribute{Value: xmlenc.String{Value: "basic"}},
+			},
+			{
+				Name:   &ast.Attribute{Value: xmlenc.String{Value: "bearer"}},
+				Type:   &ast.Attribute{Value: xmlenc.String{Value: ast.SecurityTypeHTTP}},
+				Scheme: &ast.Attribute{Value: xmlenc.String{Value: "bearer"}},
+			},
+			{
+				Name: &ast.Attribute{Value: xmlenc.String{Value: "query"}},
+				Type: &ast.Attribute{Value: xmlenc.String{Value: ast.SecurityTypeAPIKey}},
+				IN:   &ast.Attribute{Value: xmlenc.String{Value: ast.SecurityInQuery}},
+				Key:  &ast.Attribute{Value: xmlenc.String{Value: "token"}},
+			},
+			{
+				Name: &ast.Attribute{Value: xmlenc.String{Value: "cookie"}},
+				Type: &ast.Attribute{Value: xmlenc.String{Value: ast.SecurityTypeAPIKey}},
+				IN:   &ast.Attribute{Value: xmlenc.String{Value: ast.SecurityInCookie}},
+				Key:  &ast.Attribute{Value: xmlenc.String{Value: "token"}},
+			},
+			{
+				Name: &ast.Attribute{Value: xmlenc.String{Value: "oauth"}},
+				Type: &ast.Attribute{Value: xmlenc.String{Value: ast.SecurityTypeOAuth2}},
+			},
+		},
+	}
+	values := func(names ...string) []*ast.SecurityValue {
+		vals := make([]*ast.SecurityValue, 0, len(names))
+		for _, name := range names {
+			vals = append(vals, &ast.SecurityValue{Content: ast.Content{Value: name}})
+		}
+		return vals
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/path", nil)
+	a.NotError(validSecurity(doc, nil, r))
+	a.NotError(validSecurity(doc, values("not-exists"), r))
+	a.Error(validSecurity(doc, values("basic"), r))
+	a.Error(validSecurity(doc, values("bearer", "query", "cookie", "oauth"), r))
+
+	r.SetBasicAuth("user", "pass")
+	a.NotError(validSecurity(doc, values("basic"), r))
+	a.Error(validSecurity(doc, values("bearer"), r))
+
+	r = httptest.NewRequest(http.MethodGet, "/path", nil)
+	r.Header.Set("Authorization", "bearer ")
+	a.Error(validSecurity(doc, values("bearer"), r))
+	r.Header.Set("Authorization", "Bearer token")
+	a.NotError(validSecurity(doc, values("bearer"), r))
+	a.NotError(validSecurity(doc, values("oauth"), r))
+	a.Error(validSecurity(doc, values("basic"), r))
+
+	r = httptest.NewRequest(http.MethodGet, "/path?token=123", nil)
+	a.NotError(validSecurity(doc, values("basic", "query"), r))
+	a.Error(validSecurity(doc, values("cookie"), r))
+
+	r = httptest.NewRequest(http.MethodGet, "/path", nil)
+	r.AddCookie(&http.Cookie{Name: "token", Value: "123"})
+	a.NotError(validSecurity(doc, values("cookie"), r))
+}
+
+func TestValidCookies(t *testing.T) {
+	a := assert.New(t, false)
+
+	cookies := []*ast.Param{
+		{
+			Name: &ast.Attribute{Value: xmlenc.String{Value: "session"}},
+			Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeString}},
+		},
+		{
+			Name:     &ast.Attribute{Value: xmlenc.String{Value: "page"}},
+			Type:     &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeNumber}},
+			Optional: &ast.BoolAttribute{Value: ast.Bool{Value: true}},
+		},
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/path", nil)
+	a.NotError(validCookies(nil, r))
+	a.NotError(validCookies(cookies, r))
+
+	r.AddCookie(&http.Cookie{Name: "page", Value: "5"})
+	a.NotError(validCookies(cookies, r))
+
+	r = httptest.NewRequest(http.MethodGet, "/path", nil)
+	r.AddCookie(&http.Cookie{Name: "page", Value: "x"})
+	err := validCookies(cookies, r)
+	a.Error(err)
+	serr, ok := err.(*core.Error)
+	a.True(ok).Equal(serr.Field, "cookies[page].")
+
+	// 非字符串的必填项
+	cookies[1].Optional = nil
+	r = httptest.NewRequest(http.MethodGet, "/path", nil)
+	a.Error(validCookies(cookies, r))
+}
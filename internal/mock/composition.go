@@ -0,0 +1,200 @@
+// SPDX-License-Identifier: MIT
+
+package mock
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/internal/ast"
+	"github.com/caixw/apidoc/v7/internal/locale"
+	"github.com/caixw/apidoc/v7/internal/xmlenc"
+)
+
+// 用于过滤组合类型中 one-of 和 any-of 的分支，返回 false 表示不采用该分支。
+type branchFilter func(c *ast.Composition, branch *ast.Param) bool
+
+// 将组合类型 p 展开为所有可能的普通参数
+//
+// p 本身的子元素以及 all-of 中的分支会合并到每一个结果中，
+// one-of 和 any-of 中的每一个分支都会产生一个结果。
+// 返回的参数不再包含组合类型，且 Array 属性与 p 无关，调用方需要自行处理数组。
+func expandComposition(p *ast.Param, filter branchFilter) []*ast.Param {
+	base := *p
+	base.Array = nil
+	base.OneOf, base.AnyOf, base.AllOf = nil, nil, nil
+	params := []*ast.Param{&base}
+
+	if p.AllOf != nil {
+		for _, branch := range p.AllOf.Items {
+			params = mergeBranches(params, nil, expandBranch(branch, filter))
+		}
+	}
+
+	for _, c := range []*ast.Composition{p.OneOf, p.AnyOf} {
+		if c == nil {
+			continue
+		}
+
+		branches := make([]*ast.Param, 0, len(c.Items))
+		for _, branch := range c.Items {
+			if filter != nil && !filter(c, branch) {
+				continue
+			}
+
+			for _, item := range expandBranch(branch, filter) {
+				branches = append(branches, withDiscriminator(item, c, branch.Name.V()))
+			}
+		}
+		params = mergeBranches(params, c, branches)
+	}
+
+	return params
+}
+
+func expandBranch(branch *ast.Param, filter branchFilter) []*ast.Param {
+	if !branch.Composed() {
+		return []*ast.Param{branch}
+	}
+
+	params := expandComposition(branch, filter)
+	if branch.Array.V() {
+		for _, p := range params {
+			p.Array = branch.Array
+		}
+	}
+	return params
+}
+
+// 将 params 中的每一个元素与 branches 中的每一个元素进行合并
+func mergeBranches(params []*ast.Param, c *ast.Composition, branches []*ast.Param) []*ast.Param {
+	if c != nil && len(branches) == 0 { // 所有分支都被过滤
+		return nil
+	}
+
+	merged := make([]*ast.Param, 0, len(params)*len(branches))
+	for _, p := range params {
+		for _, branch := range branches {
+			merged = append(merged, mergeBranch(p, branch))
+		}
+	}
+	return merged
+}
+
+// 合并 p 和 branch 的内容
+//
+// 如果 p 未指定类型且没有子元素，则以 branch 为准，否则仅合并 branch 的子元素。
+func mergeBranch(p, branch *ast.Param) *ast.Param {
+	var merged ast.Param
+	if p.Type.V() == ast.TypeNone && len(p.Items) == 0 {
+		merged = *branch
+		merged.Name = p.Name
+		merged.Optional = p.Optional
+		merged.XML = p.XML
+	} else {
+		merged = *p
+	}
+
+	if len(p.Items) > 0 && len(branch.Items) > 0 {
+		merged.Items = make([]*ast.Param, 0, len(p.Items)+len(branch.Items))
+		merged.Items = append(merged.Items, p.Items...)
+		merged.Items = append(merged.Items, branch.Items...)
+	} else if len(branch.Items) > 0 {
+		merged.Items = branch.Items
+	}
+
+	if len(merged.Items) > 0 && merged.Type.V() != ast.TypeObject {
+		merged.Type = &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeObject}}
+	}
+
+	return &merged
+}
+
+// 将 p 中由 c.Discriminator 指定的属性的值限定为 name
+func withDiscriminator(p *ast.Param, c *ast.Composition, name string) *ast.Param {
+	d := c.Discriminator.V()
+	if d == "" {
+		return p
+	}
+
+	merged := *p
+	merged.Items = make([]*ast.Param, 0, len(p.Items))
+	for _, item := range p.Items {
+		if item.Name.V() == d {
+			prop := *item
+			prop.Enums = []*ast.Enum{{Value: &ast.Attribute{Value: xmlenc.String{Value: name}}}}
+			item = &prop
+		}
+		merged.Items = append(merged.Items, item)
+	}
+	return &merged
+}
+
+// 验证组合类型的值
+//
+// 如果指定了 discriminator，则根据该属性的值选择分支，否则采用第一个验证通过的分支。
+func validComposition(p *ast.Param, content []byte) error {
+	var filter branchFilter
+
+	if hasDiscriminator(p) {
+		obj := map[string]any{}
+		if err := json.Unmarshal(content, &obj); err != nil {
+			return core.NewError(locale.ErrInvalidFormat)
+		}
+
+		filter = func(c *ast.Composition, branch *ast.Param) bool {
+			d := c.Discriminator.V()
+			if d == "" {
+				return true
+			}
+			v, found := obj[d]
+			return found && fmt.Sprint(v) == branch.Name.V()
+		}
+
+		for _, c := range []*ast.Composition{p.OneOf, p.AnyOf} {
+			if c == nil || c.Discriminator.V() == "" {
+				continue
+			}
+			v, found := obj[c.Discriminator.V()]
+			if !found || c.Branch(fmt.Sprint(v)) == nil {
+				return core.NewError(locale.ErrInvalidValue).WithField(c.Discriminator.V())
+			}
+		}
+	}
+
+	var err error
+	for _, candidate := range expandComposition(p, filter) {
+		validator := &jsonValidator{param: candidate, states: []byte{0}, names: []string{}}
+		if err = validator.valid(json.NewDecoder(bytes.NewReader(content))); err == nil {
+			return nil
+		}
+	}
+
+	if err == nil { // 没有任何可用的分支
+		err = core.NewError(locale.ErrInvalidValue)
+	}
+	return err
+}
+
+func hasDiscriminator(p *ast.Param) bool {
+	return (p.OneOf != nil && p.OneOf.Discriminator.V() != "") ||
+		(p.AnyOf != nil && p.AnyOf.Discriminator.V() != "")
+}
+
+// 从组合类型 p 中选取一种用于生成数据
+//
+// one-of 和 any-of 中的分支通过 g.Index 选取。
+func (g *GenOptions) chooseBranch(p *ast.Param) *ast.Param {
+	chosen := make(map[*ast.Composition]*ast.Param, 3)
+	params := expandComposition(p, func(c *ast.Composition, branch *ast.Param) bool {
+		b, found := chosen[c]
+		if !found {
+			b = c.Items[g.Index(len(c.Items))]
+			chosen[c] = b
+		}
+		return b == branch
+	})
+	return params[0]
+}
@@ -0,0 +1,156 @@
+// SPDX-License-Identifier: MIT
+
+package mock
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/issue9/assert/v3"
+
+	"github.com/caixw/apidoc/v7/internal/ast"
+	"github.com/caixw/apidoc/v7/internal/xmlenc"
+)
+
+func newCompositionBranch(name string, items ...*ast.Param) *ast.Param {
+	p := newFormParam(name, ast.TypeObject)
+	p.Items = items
+	return p
+}
+
+// 根据 type 字段区分 cat 和 dog 的 one-of 类型
+func newPetParam() *ast.Param {
+	return &ast.Param{
+		Name: &ast.Attribute{Value: xmlenc.String{Value: "pet"}},
+		Items: []*ast.Param{
+			newFormParam("name", ast.TypeString),
+		},
+		OneOf: &ast.Composition{
+			Discriminator: &ast.Attribute{Value: xmlenc.String{Value: "type"}},
+			Items: []*ast.Param{
+				newCompositionBranch("cat", newFormParam("type", ast.TypeString), newFormParam("meow", ast.TypeBool)),
+				newCompositionBranch("dog", newFormParam("type", ast.TypeString), newFormParam("bark", ast.TypeNumber)),
+			},
+		},
+	}
+}
+
+func TestExpandComposition(t *testing.T) {
+	a := assert.New(t, false)
+
+	params := expandComposition(newPetParam(), nil)
+	a.Length(params, 2)
+	cat, dog := params[0], params[1]
+	a.Equal(cat.Type.V(), ast.TypeObject).
+		Equal(cat.Name.V(), "pet").
+		Length(cat.Items, 3).
+		Equal(cat.Items[0].Name.V(), "name").
+		Equal(cat.Items[1].Enums[0].Value.V(), "cat").
+		Equal(cat.Items[2].Name.V(), "meow").
+		False(cat.Composed())
+	a.Length(dog.Items, 3).
+		Equal(dog.Items[1].Enums[0].Value.V(), "dog").
+		Equal(dog.Items[2].Name.V(), "bark")
+
+	// filter
+	params = expandComposition(newPetParam(), func(c *ast.Composition, branch *ast.Param) bool {
+		return branch.Name.V() == "dog"
+	})
+	a.Length(params, 1).Equal(params[0].Items[2].Name.V(), "bark")
+	params = expandComposition(newPetParam(), func(c *ast.Composition, branch *ast.Param) bool {
+		return false
+	})
+	a.Empty(params)
+
+	// all-of 与 any-of
+	p := &ast.Param{
+		AllOf: &ast.Composition{Items: []*ast.Param{
+			newCompositionBranch("base", newFormParam("id", ast.TypeNumber)),
+			newCompositionBranch("named", newFormParam("name", ast.TypeString)),
+		}},
+		AnyOf: &ast.Composition{Items: []*ast.Param{
+			newFormParam("s", ast.TypeString),
+			newCompositionBranch("o", newFormParam("x", ast.TypeBool)),
+		}},
+	}
+	params = expandComposition(p, nil)
+	a.Length(params, 2)
+	a.Length(params[0].Items, 2).Equal(params[0].Type.V(), ast.TypeObject)
+	a.Length(params[1].Items, 3).Equal(params[1].Items[2].Name.V(), "x")
+}
+
+func TestValidComposition(t *testing.T) {
+	a := assert.New(t, false)
+
+	p := newPetParam()
+	a.NotError(validComposition(p, []byte(`{"name":"n","type":"cat","meow":true}`)))
+	a.NotError(validComposition(p, []byte(`{"name":"n","type":"dog","bark":5}`)))
+	a.Error(validComposition(p, []byte(`{"name":"n","type":"dog","meow":true}`)))
+	a.Error(validComposition(p, []byte(`{"name":"n","type":"fish"}`)))
+	a.Error(validComposition(p, []byte(`{"name":"n","meow":true}`)))
+	a.Error(validComposition(p, []byte(`[]`)))
+
+	// 未指定 discriminator，采用第一个匹配的分支。
+	p.OneOf.Discriminator = nil
+	a.NotError(validComposition(p, []byte(`{"name":"n","type":"dog","meow":true}`)))
+	a.Error(validComposition(p, []byte(`{"name":"n","type":"dog","x":true}`)))
+
+	// 基本类型的分支
+	p = &ast.Param{OneOf: &ast.Composition{Items: []*ast.Param{
+		newFormParam("s", ast.TypeString),
+		newFormParam("n", ast.TypeNumber),
+	}}}
+	a.NotError(validComposition(p, []byte(`"str"`)))
+	a.NotError(validComposition(p, []byte(`5`)))
+	a.Error(validComposition(p, []byte(`true`)))
+}
+
+func TestJSONValidator_composition(t *testing.T) {
+	a := assert.New(t, false)
+
+	pets := newPetParam()
+	pets.Array = newBoolAttribute(true)
+	pets.MaxItems = newNumberAttribute(2)
+	r := &ast.Request{
+		Type:  &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeObject}},
+		Items: []*ast.Param{newFormParam("id", ast.TypeNumber), newPetParam(), pets},
+	}
+	r.Items[2].Name = &ast.Attribute{Value: xmlenc.String{Value: "pets"}}
+
+	a.NotError(validJSON(r, []byte(`{"id":1,"pet":{"name":"n","type":"cat","meow":true},"pets":[]}`)))
+	a.NotError(validJSON(r, []byte(`{"pet":{"name":"n","type":"dog","bark":1},"pets":[{"name":"n","type":"cat","meow":true}],"id":1}`)))
+	a.Error(validJSON(r, []byte(`{"id":1,"pet":{"name":"n","type":"cat","bark":1},"pets":[]}`)))
+	a.Error(validJSON(r, []byte(`{"id":1,"pet":{"name":"n","type":"cat","meow":true},"pets":[{"name":"n","type":"fish"}]}`)))
+	a.Error(validJSON(r, []byte(`{"id":1,"pet":{"name":"n","type":"cat","meow":true},"pets":[{},{},{}]}`)))
+
+	// 顶层的组合类型
+	p := newPetParam()
+	r = &ast.Request{OneOf: p.OneOf, Items: p.Items}
+	a.NotError(validJSON(r, []byte(`{"name":"n","type":"cat","meow":true}`)))
+	a.Error(validJSON(r, []byte(`{"name":"n","type":"cat","bark":1}`)))
+	a.Error(validJSON(r, nil))
+}
+
+func TestBuildJSON_composition(t *testing.T) {
+	a := assert.New(t, false)
+
+	p := newPetParam()
+	r := &ast.Request{OneOf: p.OneOf, Items: p.Items}
+
+	data, err := buildJSON(r, indent, testOptions)
+	a.NotError(err).Equal(string(data), `{
+    "name": "1024",
+    "type": "cat",
+    "meow": true
+}`)
+	a.NotError(validJSON(r, data))
+
+	g := *testOptions
+	g.Index = func(max int) int { return max - 1 }
+	data, err = buildJSON(r, indent, &g)
+	a.NotError(err)
+	obj := map[string]any{}
+	a.NotError(json.Unmarshal(data, &obj))
+	a.Equal(obj["type"], "dog").Equal(obj["bark"], 1024.0)
+	a.NotError(validJSON(r, data))
+}
@@ -0,0 +1,279 @@
+// SPDX-License-Identifier: MIT
+
+package mock
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"regexp/syntax"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/internal/ast"
+	"github.com/caixw/apidoc/v7/internal/locale"
+)
+
+const (
+	// 正则表达式中 *、+ 等未限定上限的重复项，在生成数据时最多重复的次数。
+	maxPatternRepeat = 8
+
+	// 生成不重复的数组元素或是符合约束条件的字符串时，最多尝试的次数。
+	maxGenerateRetries = 10
+)
+
+// 验证数值 v 是否在 p 指定的范围之内
+func validNumber(p *ast.Param, v float64) *core.Error {
+	if p.Min != nil {
+		min := p.Min.Float64()
+		if v < min || (v == min && p.ExclusiveMin.V()) {
+			return core.NewError(locale.ErrInvalidValue)
+		}
+	}
+
+	if p.Max != nil {
+		max := p.Max.Float64()
+		if v > max || (v == max && p.ExclusiveMax.V()) {
+			return core.NewError(locale.ErrInvalidValue)
+		}
+	}
+
+	return nil
+}
+
+// 验证字符串 v 的长度以及格式是否符合 p 的要求
+func validString(p *ast.Param, v string) *core.Error {
+	size := utf8.RuneCountInString(v)
+	if p.MinLength != nil && size < p.MinLength.IntValue() {
+		return core.NewError(locale.ErrInvalidValue)
+	}
+	if p.MaxLength != nil && size > p.MaxLength.IntValue() {
+		return core.NewError(locale.ErrInvalidValue)
+	}
+
+	if p.Pattern != nil {
+		// 语法错误已经在加载文档时检测，此处不再处理。
+		if matched, err := regexp.MatchString(p.Pattern.V(), v); err == nil && !matched {
+			return core.NewError(locale.ErrInvalidFormat)
+		}
+	}
+
+	return nil
+}
+
+// 验证以文本形式表示的值 v 是否符合 p 中的约束条件
+//
+// 仅验证约束条件，v 的格式是否与类型相符，由调用方负责。
+func validTextConstraints(p *ast.Param, v string) *core.Error {
+	switch primitive, _ := ast.ParseType(p.Type.V()); primitive {
+	case ast.TypeNumber:
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return validNumber(p, f)
+		}
+	case ast.TypeString:
+		return validString(p, v)
+	}
+	return nil
+}
+
+// 验证数组的元素数量以及元素是否重复
+//
+// size 表示数组元素的数量；
+// values 为数组元素的值，仅在元素为基本类型时才有内容。
+func validItems(p *ast.Param, size int, values []string) *core.Error {
+	if p.MinItems != nil && size < p.MinItems.IntValue() {
+		return core.NewError(locale.ErrInvalidValue)
+	}
+	if p.MaxItems != nil && size > p.MaxItems.IntValue() {
+		return core.NewError(locale.ErrInvalidValue)
+	}
+
+	if p.UniqueItems.V() {
+		exists := make(map[string]struct{}, len(values))
+		for _, v := range values {
+			if _, found := exists[v]; found {
+				return core.NewError(locale.ErrDuplicateValue)
+			}
+			exists[v] = struct{}{}
+		}
+	}
+
+	return nil
+}
+
+// 将 v 调整到 p 指定的范围之内
+//
+// 如果 v 本身已经在范围之内，则原样返回。
+func fitNumber(p *ast.Param, v any) any {
+	if p.Min == nil && p.Max == nil {
+		return v
+	}
+
+	var f float64
+	t := p.Type.V()
+	isInt := t == ast.TypeInt || t == ast.TypeInt32 || t == ast.TypeInt64
+	switch vv := v.(type) {
+	case int:
+		f, isInt = float64(vv), true
+	case int32:
+		f, isInt = float64(vv), true
+	case int64:
+		f, isInt = float64(vv), true
+	case float32:
+		f = float64(vv)
+	case float64:
+		f = vv
+	default:
+		return v
+	}
+
+	if validNumber(p, f) == nil {
+		return v
+	}
+
+	lo, hi := p.Min.Float64(), p.Max.Float64()
+	if isInt {
+		lo, hi = math.Ceil(lo), math.Floor(hi)
+		if p.ExclusiveMin.V() && lo == p.Min.Float64() {
+			lo++
+		}
+		if p.ExclusiveMax.V() && hi == p.Max.Float64() {
+			hi--
+		}
+	}
+
+	switch {
+	case p.Min != nil && p.Max != nil:
+		f = lo + (hi-lo)/2
+	case p.Min != nil:
+		f = lo + 1
+	default:
+		f = hi - 1
+	}
+
+	if isInt {
+		return int(math.Floor(f))
+	}
+	return f
+}
+
+// 将字符串 v 的长度调整到 p 要求的范围之内
+func fitStringLength(p *ast.Param, v string) string {
+	if p.MinLength != nil {
+		if v == "" {
+			v = "0"
+		}
+		for min := p.MinLength.IntValue(); utf8.RuneCountInString(v) < min; {
+			v += v
+		}
+	}
+
+	if p.MaxLength != nil {
+		if max := p.MaxLength.IntValue(); utf8.RuneCountInString(v) > max {
+			v = string([]rune(v)[:max])
+		}
+	}
+
+	return v
+}
+
+// 生成 size 个互不相同的值
+//
+// 如果在尝试 maxGenerateRetries 轮之后依然无法生成足够多的值，
+// 则返回的数量会少于 size。
+func generateUniqueValues(size int, gen func() any) []any {
+	values := make([]any, 0, size)
+	exists := make(map[string]struct{}, size)
+	for i := 0; len(values) < size && i < size*maxGenerateRetries; i++ {
+		v := gen()
+		key := fmt.Sprint(v)
+		if _, found := exists[key]; found {
+			continue
+		}
+		exists[key] = struct{}{}
+		values = append(values, v)
+	}
+	return values
+}
+
+// 生成一个匹配正则表达式 pattern 的字符串
+func (g *GenOptions) generatePattern(pattern string) (string, error) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", err
+	}
+
+	buf := &strings.Builder{}
+	g.writePattern(buf, re.Simplify())
+	return buf.String(), nil
+}
+
+func (g *GenOptions) writePattern(buf *strings.Builder, re *syntax.Regexp) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		buf.WriteString(string(re.Rune))
+	case syntax.OpCharClass:
+		buf.WriteRune(g.generateRune(re.Rune))
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		buf.WriteRune(rune('a' + g.Index(26)))
+	case syntax.OpCapture:
+		g.writePattern(buf, re.Sub[0])
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			g.writePattern(buf, sub)
+		}
+	case syntax.OpAlternate:
+		g.writePattern(buf, re.Sub[g.Index(len(re.Sub))])
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		var min, max int
+		switch re.Op {
+		case syntax.OpStar:
+			min, max = 0, maxPatternRepeat
+		case syntax.OpPlus:
+			min, max = 1, maxPatternRepeat
+		case syntax.OpQuest:
+			min, max = 0, 1
+		default:
+			min, max = re.Min, re.Max
+			if max < 0 {
+				max = min + maxPatternRepeat
+			}
+		}
+
+		size := min + g.Index(max-min+1)
+		for i := 0; i < size; i++ {
+			g.writePattern(buf, re.Sub[0])
+		}
+	} // 其它诸如 ^、$ 以及 \b 等零宽度的匹配项，不需要输出任何内容。
+}
+
+// 从 ranges 表示的字符范围中选取一个字符
+//
+// ranges 的格式与 syntax.Regexp.Rune 相同，两个元素表示一个范围。
+// 优先从可打印的 ASCII 字符中选取。
+func (g *GenOptions) generateRune(ranges []rune) rune {
+	printable := make([]rune, 0, len(ranges))
+	for i := 0; i+1 < len(ranges); i += 2 {
+		lo, hi := ranges[i], ranges[i+1]
+		if lo < '!' {
+			lo = '!'
+		}
+		if hi > '~' {
+			hi = '~'
+		}
+		if lo <= hi {
+			printable = append(printable, lo, hi)
+		}
+	}
+	if len(printable) > 0 {
+		ranges = printable
+	} else if len(ranges) < 2 { // 不可能匹配任何字符的字符集
+		return 0
+	}
+
+	index := g.Index(len(ranges)/2) * 2
+	lo, hi := ranges[index], ranges[index+1]
+	return lo + rune(g.Index(int(hi-lo)+1))
+}
@@ -0,0 +1,186 @@
+// SPDX-License-Identifier: MIT
+
+package mock
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/issue9/assert/v3"
+
+	"github.com/caixw/apidoc/v7/internal/ast"
+	"github.com/caixw/apidoc/v7/internal/xmlenc"
+)
+
+func newNumberAttribute(v int) *ast.NumberAttribute {
+	return &ast.NumberAttribute{Value: ast.Number{Int: v}}
+}
+
+func newBoolAttribute(v bool) *ast.BoolAttribute {
+	return &ast.BoolAttribute{Value: ast.Bool{Value: v}}
+}
+
+func TestValidNumber(t *testing.T) {
+	a := assert.New(t, false)
+
+	p := &ast.Param{}
+	a.Nil(validNumber(p, -100))
+
+	p = &ast.Param{Min: newNumberAttribute(1), Max: newNumberAttribute(10)}
+	a.Nil(validNumber(p, 1))
+	a.Nil(validNumber(p, 10))
+	a.Nil(validNumber(p, 5.5))
+	a.NotNil(validNumber(p, 0.5))
+	a.NotNil(validNumber(p, 11))
+
+	p.ExclusiveMin = newBoolAttribute(true)
+	p.ExclusiveMax = newBoolAttribute(true)
+	a.NotNil(validNumber(p, 1))
+	a.NotNil(validNumber(p, 10))
+	a.Nil(validNumber(p, 9.9))
+}
+
+func TestValidString(t *testing.T) {
+	a := assert.New(t, false)
+
+	p := &ast.Param{MinLength: newNumberAttribute(2), MaxLength: newNumberAttribute(4)}
+	a.Nil(validString(p, "ab"))
+	a.Nil(validString(p, "中文字符"))
+	a.NotNil(validString(p, "a"))
+	a.NotNil(validString(p, "abcde"))
+
+	p = &ast.Param{Pattern: &ast.Attribute{Value: xmlenc.String{Value: `^\d+$`}}}
+	a.Nil(validString(p, "123"))
+	a.NotNil(validString(p, "12a"))
+}
+
+func TestValidTextConstraints(t *testing.T) {
+	a := assert.New(t, false)
+
+	p := &ast.Param{
+		Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeInt}},
+		Max:  newNumberAttribute(10),
+	}
+	a.Nil(validTextConstraints(p, "10"))
+	a.NotNil(validTextConstraints(p, "11"))
+	a.Nil(validTextConstraints(p, "not-number")) // 格式错误不由此函数负责
+
+	p = &ast.Param{
+		Type:      &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeString}},
+		MaxLength: newNumberAttribute(1),
+	}
+	a.Nil(validTextConstraints(p, "1"))
+	a.NotNil(validTextConstraints(p, "11"))
+}
+
+func TestValidItems(t *testing.T) {
+	a := assert.New(t, false)
+
+	p := &ast.Param{MinItems: newNumberAttribute(1), MaxItems: newNumberAttribute(3)}
+	a.Nil(validItems(p, 1, nil))
+	a.Nil(validItems(p, 3, []string{"1", "1", "1"}))
+	a.NotNil(validItems(p, 0, nil))
+	a.NotNil(validItems(p, 4, nil))
+
+	p.UniqueItems = newBoolAttribute(true)
+	a.Nil(validItems(p, 3, []string{"1", "2", "3"}))
+	a.NotNil(validItems(p, 3, []string{"1", "2", "1"}))
+}
+
+func TestFitNumber(t *testing.T) {
+	a := assert.New(t, false)
+
+	p := &ast.Param{}
+	a.Equal(fitNumber(p, 1024), 1024)
+
+	p = &ast.Param{Min: newNumberAttribute(1), Max: newNumberAttribute(10)}
+	a.Equal(fitNumber(p, 5), 5)
+	a.Equal(fitNumber(p, 1024), 5)
+	a.Equal(fitNumber(p, float32(1024)), 5.5)
+
+	p = &ast.Param{Min: newNumberAttribute(1), Max: newNumberAttribute(2), ExclusiveMin: newBoolAttribute(true)}
+	a.Equal(fitNumber(p, 1024), 2)
+
+	p = &ast.Param{Max: newNumberAttribute(10), ExclusiveMax: newBoolAttribute(true)}
+	a.Equal(fitNumber(p, 10), 8)
+
+	p = &ast.Param{Min: newNumberAttribute(2000)}
+	a.Equal(fitNumber(p, 1024), 2001)
+}
+
+func TestFitStringLength(t *testing.T) {
+	a := assert.New(t, false)
+
+	p := &ast.Param{MinLength: newNumberAttribute(5), MaxLength: newNumberAttribute(6)}
+	a.Equal(fitStringLength(p, "12"), "121212")
+	a.Equal(fitStringLength(p, "1234567890"), "123456")
+	a.Equal(fitStringLength(p, ""), "000000")
+
+	p = &ast.Param{MaxLength: newNumberAttribute(0)}
+	a.Equal(fitStringLength(p, "12"), "")
+}
+
+func TestGenerateUniqueValues(t *testing.T) {
+	a := assert.New(t, false)
+
+	i := 0
+	values := generateUniqueValues(5, func() any {
+		i++
+		return i % 3
+	})
+	a.Length(values, 3)
+
+	values = generateUniqueValues(5, func() any {
+		i++
+		return i
+	})
+	a.Length(values, 5)
+}
+
+func TestGenOptions_generatePattern(t *testing.T) {
+	a := assert.New(t, false)
+
+	patterns := []string{
+		`^\d+$`,
+		`^[a-z]{3,5}-\d{2}$`,
+		`^(abc|def)?x*$`,
+		`^[^a-z]+$`,
+		`^.\w\s?$`,
+		`^[\p{Han}]+$`,
+	}
+
+	for _, pattern := range patterns {
+		v, err := testOptions.generatePattern(pattern)
+		a.NotError(err).True(regexp.MustCompile(pattern).MatchString(v), "%s 无法匹配 %s", v, pattern)
+	}
+
+	v, err := testOptions.generatePattern("[a-z")
+	a.Error(err).Empty(v)
+}
+
+func TestGenOptions_generateString(t *testing.T) {
+	a := assert.New(t, false)
+
+	p := &ast.Param{
+		Type:      &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeString}},
+		MaxLength: newNumberAttribute(2),
+	}
+	v, err := testOptions.generateString(p)
+	a.NotError(err).Equal(v, "10")
+
+	p = &ast.Param{
+		Type:    &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeString}},
+		Pattern: &ast.Attribute{Value: xmlenc.String{Value: `^[a-z]+\d$`}},
+	}
+	v, err = testOptions.generateString(p)
+	a.NotError(err).Equal(v, "a0")
+
+	// 无效的正则表达式
+	p = &ast.Param{
+		Type:      &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeString}},
+		MinLength: newNumberAttribute(10),
+		Pattern:   &ast.Attribute{Value: xmlenc.String{Value: `[a-`}},
+	}
+	v, err = testOptions.generateString(p)
+	a.Error(err).Empty(v)
+}
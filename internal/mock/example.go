@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: MIT
+
+package mock
+
+import (
+	"mime"
+	"strings"
+
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/internal/ast"
+	"github.com/caixw/apidoc/v7/internal/xmlenc"
+)
+
+func init() {
+	ast.RegisterCheck(checkExamples)
+}
+
+// 检测 doc 中的示例代码是否与其所在的 request 或 response 的定义相符
+//
+// 仅检测 mimetype 为 JSON 和 XML 的示例代码，不相符的内容以警告的形式输出至 h，
+// 其定位信息指向示例代码中出错的内容。
+func checkExamples(h *core.MessageHandler, doc *ast.APIDoc) {
+	requests := make([]*ast.Request, 0, len(doc.Responses)+len(doc.APIs)*2)
+	requests = append(requests, doc.Responses...)
+	for _, api := range doc.APIs {
+		requests = append(requests, api.Requests...)
+		requests = append(requests, api.Responses...)
+		for _, cb := range api.Callbacks {
+			requests = append(requests, cb.Requests...)
+			requests = append(requests, cb.Responses...)
+		}
+	}
+	for _, cb := range doc.Webhooks {
+		requests = append(requests, cb.Requests...)
+		requests = append(requests, cb.Responses...)
+	}
+
+	for _, r := range requests {
+		for _, ex := range r.Examples {
+			if err := checkExample(doc.XMLNamespaces, r, ex); err != nil {
+				h.Warning(err)
+			}
+		}
+	}
+}
+
+func checkExample(ns []*ast.XMLNamespace, r *ast.Request, ex *ast.Example) *core.Error {
+	if ex.Content == nil {
+		return nil
+	}
+	content := []byte(ex.Content.Value.Value)
+
+	var start, end int
+	var err error
+	switch exampleType(ex.Mimetype.V()) {
+	case "json":
+		start, end, err = validJSONRange(r, content)
+	case "xml":
+		start, end, err = validXMLRange(ns, r, content)
+	default:
+		return nil
+	}
+	if err == nil {
+		return nil
+	}
+
+	cerr, ok := err.(*core.Error)
+	if !ok {
+		cerr = core.WithError(err).WithField("example")
+	}
+	return cerr.WithLocation(contentLocation(ex.Content.Value, start, end))
+}
+
+// 根据示例代码的 mimetype 返回其格式，仅支持 json 和 xml，其它返回空值。
+func exampleType(mimetype string) string {
+	mt := strings.ToLower(strings.TrimSpace(mimetype))
+	if v, _, err := mime.ParseMediaType(mt); err == nil {
+		mt = v
+	}
+
+	switch {
+	case mt == "json" || strings.HasSuffix(mt, "/json") || strings.HasSuffix(mt, "+json"):
+		return "json"
+	case mt == "xml" || strings.HasSuffix(mt, "/xml") || strings.HasSuffix(mt, "+xml"):
+		return "xml"
+	default:
+		return ""
+	}
+}
+
+// 返回 s 中字节范围 [start,end) 在文档中的定位信息
+//
+// 范围的起始处的空白以及 JSON 中的 , 和 : 会被忽略。
+func contentLocation(s xmlenc.String, start, end int) core.Location {
+	v := s.Value
+	if end > len(v) {
+		end = len(v)
+	}
+	for start < end && strings.IndexByte(" \t\r\n,:", v[start]) >= 0 {
+		start++
+	}
+
+	pos := s.Location.Range.Start
+	startPos := pos
+	for i, r := range v {
+		if i == start {
+			startPos = pos
+		}
+		if i >= end {
+			break
+		}
+
+		if r == '\n' {
+			pos.Line++
+			pos.Character = 0
+		} else {
+			pos.Character++
+		}
+	}
+	if start >= len(v) {
+		startPos = pos
+	}
+
+	return core.Location{URI: s.Location.URI, Range: core.Range{Start: startPos, End: pos}}
+}
@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: MIT
+
+package mock
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v3"
+
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/core/messagetest"
+	"github.com/caixw/apidoc/v7/internal/ast"
+	"github.com/caixw/apidoc/v7/internal/xmlenc"
+)
+
+func decodeRequest(a *assert.Assertion, data string) *ast.Request {
+	rslt := messagetest.NewMessageHandler()
+	p, err := xmlenc.NewParser(rslt.Handler, core.Block{Data: []byte(data), Location: core.Location{URI: "file:///doc.go"}})
+	a.NotError(err).NotNil(p)
+
+	r := &ast.Request{}
+	xmlenc.Decode(p, r, core.XMLNamespace)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors)
+	return r
+}
+
+func TestCheckExamples(t *testing.T) {
+	a := assert.New(t, false)
+
+	r := decodeRequest(a, `<response type="object">
+	<param name="id" type="number" summary="id" />
+	<param name="name" type="string" summary="name" />
+	<example mimetype="application/json"><![CDATA[{"id":1,"name":"n"}]]></example>
+	<example mimetype="json"><![CDATA[{
+  "id": 1,
+  "name": 5
+}]]></example>
+	<example mimetype="application/xml"><![CDATA[<root><id>x</id></root>]]></example>
+	<example mimetype="text/plain"><![CDATA[{"id":"x"}]]></example>
+</response>`)
+	doc := &ast.APIDoc{APIs: []*ast.API{{Responses: []*ast.Request{r}}}}
+
+	rslt := messagetest.NewMessageHandler()
+	checkExamples(rslt.Handler, doc)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors).Length(rslt.Warns, 2)
+
+	err, ok := rslt.Warns[0].(*core.Error)
+	a.True(ok).
+		Equal(err.Field, "name").
+		Equal(err.Location, core.Location{
+			URI:   "file:///doc.go",
+			Range: core.Range{Start: core.Position{Line: 6, Character: 10}, End: core.Position{Line: 6, Character: 11}},
+		})
+
+	err, ok = rslt.Warns[1].(*core.Error)
+	a.True(ok).Equal(err.Location.Range.Start.Line, 8)
+}
+
+func TestExampleType(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.Equal(exampleType("json"), "json").
+		Equal(exampleType("application/json"), "json").
+		Equal(exampleType("application/problem+json; charset=utf-8"), "json").
+		Equal(exampleType("XML"), "xml").
+		Equal(exampleType("text/xml"), "xml").
+		Equal(exampleType("text/plain"), "").
+		Equal(exampleType(""), "")
+}
+
+func TestContentLocation(t *testing.T) {
+	a := assert.New(t, false)
+
+	s := xmlenc.String{
+		Value:    "{\n  \"id\": 1,\n  \"中文\": 2\n}",
+		Location: core.Location{URI: "uri", Range: core.Range{Start: core.Position{Line: 2, Character: 5}}},
+	}
+
+	loc := contentLocation(s, 0, 1)
+	a.Equal(loc.Range, core.Range{Start: core.Position{Line: 2, Character: 5}, End: core.Position{Line: 2, Character: 6}})
+
+	// 忽略前导的空白和分隔符
+	loc = contentLocation(s, 1, 8)
+	a.Equal(loc.Range, core.Range{Start: core.Position{Line: 3, Character: 2}, End: core.Position{Line: 3, Character: 6}})
+
+	// 多字节字符
+	loc = contentLocation(s, 14, 23)
+	a.Equal(loc.Range, core.Range{Start: core.Position{Line: 4, Character: 2}, End: core.Position{Line: 4, Character: 6}})
+
+	loc = contentLocation(s, len(s.Value), 100)
+	a.Equal(loc.Range, core.Range{Start: core.Position{Line: 5, Character: 1}, End: core.Position{Line: 5, Character: 1}})
+}
+
+func TestCheckExamples_invalidDoc(t *testing.T) {
+	a := assert.New(t, false)
+
+	rslt := messagetest.NewMessageHandler()
+	docs := &ast.Documents{}
+	docs.ParseBlocks(rslt.Handler, func(blocks chan core.Block) {
+		blocks <- core.Block{Location: core.Location{URI: "file:///doc.go"}, Data: []byte(`<apidoc version="1.0.0">
+	<title>title</title>
+	<mimetype>application/xml</mimetype>
+	<api method="GET">
+		<path path="/users" />
+		<response status="200" type="object" summary="ok">
+			<param name="id" type="foo" summary="x" />
+			<example mimetype="application/xml"><![CDATA[<root><id>1</id></root>]]></example>
+		</response>
+	</api>
+</apidoc>`)}
+	})
+	rslt.Handler.Stop()
+	a.NotEmpty(rslt.Errors).Empty(rslt.Warns)
+}
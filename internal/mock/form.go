@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: MIT
+
+package mock
+
+import (
+	"bytes"
+	"mime"
+	"mime/multipart"
+	"net/url"
+
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/internal/ast"
+	"github.com/caixw/apidoc/v7/internal/locale"
+)
+
+const (
+	mimetypeFormURLEncoded = "application/x-www-form-urlencoded"
+	mimetypeMultipartForm  = "multipart/form-data"
+
+	// 解析 multipart/form-data 时，保存在内存中的最大字节数，超出部分会写入临时文件。
+	maxMultipartMemory = 32 << 20
+)
+
+// 验证 application/x-www-form-urlencoded 格式的请求内容
+func validURLEncodedForm(req *ast.Request, content []byte) error {
+	values, err := url.ParseQuery(string(content))
+	if err != nil {
+		return core.NewError(locale.ErrInvalidFormat)
+	}
+	return validValues(req.Items, values, "form")
+}
+
+// 验证 multipart/form-data 格式的请求内容
+//
+// boundary 为报头 content-type 中的 boundary 参数。
+// 类型为 string.binary 的字段会被当作上传的文件进行验证，其它字段与普通的表单字段相同。
+func validMultipartForm(req *ast.Request, boundary string, content []byte) error {
+	if boundary == "" {
+		return core.NewError(locale.ErrInvalidValue).WithField("headers[content-type]")
+	}
+
+	form, err := multipart.NewReader(bytes.NewReader(content), boundary).ReadForm(maxMultipartMemory)
+	if err != nil {
+		return core.NewError(locale.ErrInvalidFormat)
+	}
+	defer form.RemoveAll()
+
+	values := make([]*ast.Param, 0, len(req.Items))
+	for _, item := range req.Items {
+		if item.Type.V() != ast.TypeBinary {
+			values = append(values, item)
+			continue
+		}
+
+		// 未指定 filename 的字段会被 ReadForm 当作普通字段
+		if _, found := form.Value[item.Name.V()]; found {
+			return core.NewError(locale.ErrIsEmpty, "filename").WithField("form[" + item.Name.V() + "].filename")
+		}
+		if err := validFiles(item, form.File[item.Name.V()]); err != nil {
+			err.Field = "form[" + item.Name.V() + "]." + err.Field
+			return err
+		}
+	}
+
+	return validValues(values, form.Value, "form")
+}
+
+// 验证上传的文件是否符合 p 的要求
+//
+// content-type 如果存在，则必须是合法的值；
+// min-length 和 max-length 用于限定单个文件的字节数。
+func validFiles(p *ast.Param, files []*multipart.FileHeader) *core.Error {
+	if len(files) == 0 {
+		if p.Optional.V() {
+			return nil
+		}
+		return core.NewError(locale.ErrIsEmpty, p.Name.V())
+	}
+
+	if !p.Array.V() && len(files) > 1 {
+		return core.NewError(locale.ErrInvalidValue)
+	}
+
+	names := make([]string, 0, len(files))
+	for _, file := range files {
+		if ct := file.Header.Get("Content-Type"); ct != "" {
+			if _, _, err := mime.ParseMediaType(ct); err != nil {
+				return core.NewError(locale.ErrInvalidFormat).WithField("content-type")
+			}
+		}
+
+		if p.MinLength != nil && file.Size < int64(p.MinLength.IntValue()) {
+			return core.NewError(locale.ErrInvalidValue)
+		}
+		if p.MaxLength != nil && file.Size > int64(p.MaxLength.IntValue()) {
+			return core.NewError(locale.ErrInvalidValue)
+		}
+
+		names = append(names, file.Filename)
+	}
+
+	if p.Array.V() {
+		return validItems(p, len(files), names)
+	}
+	return nil
+}
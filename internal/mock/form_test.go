@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: MIT
+
+package mock
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/textproto"
+	"testing"
+
+	"github.com/issue9/assert/v3"
+
+	"github.com/caixw/apidoc/v7/internal/ast"
+	"github.com/caixw/apidoc/v7/internal/xmlenc"
+)
+
+func newFormParam(name, typ string) *ast.Param {
+	return &ast.Param{
+		Name: &ast.Attribute{Value: xmlenc.String{Value: name}},
+		Type: &ast.TypeAttribute{Value: xmlenc.String{Value: typ}},
+	}
+}
+
+var formRequest = &ast.Request{
+	Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeObject}},
+	Items: []*ast.Param{
+		newFormParam("name", ast.TypeString),
+		newFormParam("age", ast.TypeNumber),
+		newFormParam("avatar", ast.TypeBinary),
+	},
+}
+
+type formFile struct {
+	field, filename, contentType, content string
+}
+
+// 生成 multipart/form-data 格式的内容，返回内容及 boundary。
+func newMultipartForm(a *assert.Assertion, values map[string]string, files ...*formFile) ([]byte, string) {
+	buf := &bytes.Buffer{}
+	w := multipart.NewWriter(buf)
+
+	for k, v := range values {
+		a.NotError(w.WriteField(k, v))
+	}
+
+	for _, f := range files {
+		h := textproto.MIMEHeader{}
+		h.Set("Content-Disposition", `form-data; name="`+f.field+`"; filename="`+f.filename+`"`)
+		if f.contentType != "" {
+			h.Set("Content-Type", f.contentType)
+		}
+		part, err := w.CreatePart(h)
+		a.NotError(err).NotNil(part)
+		_, err = part.Write([]byte(f.content))
+		a.NotError(err)
+	}
+
+	a.NotError(w.Close())
+	return buf.Bytes(), w.Boundary()
+}
+
+func TestValidURLEncodedForm(t *testing.T) {
+	a := assert.New(t, false)
+
+	req := &ast.Request{
+		Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeObject}},
+		Items: []*ast.Param{
+			newFormParam("name", ast.TypeString),
+			newFormParam("age", ast.TypeNumber),
+		},
+	}
+
+	a.NotError(validURLEncodedForm(req, []byte("name=n1&age=5")))
+	a.Error(validURLEncodedForm(req, []byte("name=n1&age=x")))
+	a.Error(validURLEncodedForm(req, []byte("name=n1")))
+	a.Error(validURLEncodedForm(req, []byte("name=%zz&age=5")))
+}
+
+func TestValidMultipartForm(t *testing.T) {
+	a := assert.New(t, false)
+
+	content, boundary := newMultipartForm(a, map[string]string{"name": "n1", "age": "5"}, &formFile{
+		field:       "avatar",
+		filename:    "avatar.png",
+		contentType: "image/png",
+		content:     "png",
+	})
+	a.NotError(validMultipartForm(formRequest, boundary, content))
+
+	// 缺少 boundary
+	a.Error(validMultipartForm(formRequest, "", content))
+
+	// boundary 不匹配
+	a.Error(validMultipartForm(formRequest, "not-exists", content))
+
+	// 缺少文件
+	content, boundary = newMultipartForm(a, map[string]string{"name": "n1", "age": "5"})
+	a.Error(validMultipartForm(formRequest, boundary, content))
+
+	// 文件字段以普通字段的形式提交
+	content, boundary = newMultipartForm(a, map[string]string{"name": "n1", "age": "5", "avatar": "png"})
+	a.Error(validMultipartForm(formRequest, boundary, content))
+
+	// 普通字段格式错误
+	content, boundary = newMultipartForm(a, map[string]string{"name": "n1", "age": "x"}, &formFile{
+		field:    "avatar",
+		filename: "avatar.png",
+		content:  "png",
+	})
+	a.Error(validMultipartForm(formRequest, boundary, content))
+}
+
+func TestValidFiles(t *testing.T) {
+	a := assert.New(t, false)
+
+	p := newFormParam("file", ast.TypeBinary)
+	a.Error(validFiles(p, nil))
+	p.Optional = newBoolAttribute(true)
+	a.NotError(validFiles(p, nil))
+
+	file := &multipart.FileHeader{Filename: "1.txt", Size: 5, Header: textproto.MIMEHeader{}}
+	a.NotError(validFiles(p, []*multipart.FileHeader{file}))
+	a.Error(validFiles(p, []*multipart.FileHeader{file, file}))
+
+	// content-type
+	file.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	a.NotError(validFiles(p, []*multipart.FileHeader{file}))
+	file.Header.Set("Content-Type", "text/")
+	a.Error(validFiles(p, []*multipart.FileHeader{file}))
+	file.Header.Del("Content-Type")
+
+	// 文件大小
+	p.MaxLength = newNumberAttribute(4)
+	a.Error(validFiles(p, []*multipart.FileHeader{file}))
+	p.MaxLength = newNumberAttribute(5)
+	a.NotError(validFiles(p, []*multipart.FileHeader{file}))
+
+	// 数组
+	p.Array = newBoolAttribute(true)
+	p.MaxItems = newNumberAttribute(2)
+	p.UniqueItems = newBoolAttribute(true)
+	file2 := &multipart.FileHeader{Filename: "2.txt", Size: 5, Header: textproto.MIMEHeader{}}
+	a.NotError(validFiles(p, []*multipart.FileHeader{file, file2}))
+	a.Error(validFiles(p, []*multipart.FileHeader{file, file}))
+	a.Error(validFiles(p, []*multipart.FileHeader{file, file2, file2}))
+}
@@ -26,24 +26,52 @@ type jsonValidator struct {
 	states []byte
 
 	names []string // 按顺序保存变量名称
+
+	arrays []*jsonArray // 当前所在的数组，用于验证数组元素的数量以及唯一性。
+
+	// 最后读取的内容在原始数据中的字节范围，用于定位出错的位置。
+	start, end int64
+}
+
+type jsonArray struct {
+	param  *ast.Param
+	field  string
+	size   int
+	values []string // 基本类型的元素值
 }
 
 func validJSON(p *ast.Request, content []byte) error {
+	_, _, err := validJSONRange(p, content)
+	return err
+}
+
+// 验证 content 是否符合 p 的定义
+//
+// 验证失败时，start 和 end 表示出错的内容在 content 中的字节范围。
+func validJSONRange(p *ast.Request, content []byte) (start, end int, err error) {
 	if p == nil {
 		if bytes.Equal(content, []byte("null")) {
-			return nil
+			return 0, 0, nil
 		}
-		return core.NewError(locale.ErrInvalidFormat)
-	} else if p.Type.V() == ast.TypeNone && len(content) == 0 {
-		return nil
+		return 0, len(content), core.NewError(locale.ErrInvalidFormat)
+	} else if p.Type.V() == ast.TypeNone && len(content) == 0 && !p.Param().Composed() {
+		return 0, 0, nil
 	}
 
 	if !json.Valid(content) {
-		return core.NewError(locale.ErrInvalidFormat)
+		var v any
+		var serr *json.SyntaxError
+		if errors.As(json.Unmarshal(content, &v), &serr) && serr.Offset > 0 {
+			return int(serr.Offset) - 1, int(serr.Offset), core.NewError(locale.ErrInvalidFormat)
+		}
+		return 0, len(content), core.NewError(locale.ErrInvalidFormat)
 	}
 
 	validator := newJSONValidator(p)
-	return validator.valid(json.NewDecoder(bytes.NewReader(content)))
+	if err = validator.valid(json.NewDecoder(bytes.NewReader(content))); err != nil {
+		return int(validator.start), int(validator.end), err
+	}
+	return 0, 0, nil
 }
 
 func newJSONValidator(r *ast.Request) *jsonValidator {
@@ -56,7 +84,19 @@ func newJSONValidator(r *ast.Request) *jsonValidator {
 
 func (validator *jsonValidator) valid(d *json.Decoder) error {
 	for {
+		validator.start = d.InputOffset()
+
+		if p := validator.composition(d); p != nil {
+			err := validator.validComposition(d, p)
+			validator.end = d.InputOffset()
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
 		token, err := d.Token()
+		validator.end = d.InputOffset()
 		if errors.Is(err, io.EOF) && token == nil { // 正常结束
 			return nil
 		}
@@ -76,6 +116,7 @@ func (validator *jsonValidator) valid(d *json.Decoder) error {
 				validator.popName()
 			case '[':
 				err = validator.validValue(ast.TypeString, v)
+				validator.addArrayItem(v)
 			case 0: // 表示数据为单个值，比如 "str"
 				err = validator.validValue(ast.TypeString, v)
 			// case ']', '}': // 格式错误，由 json.Valid 保证
@@ -90,8 +131,11 @@ func (validator *jsonValidator) valid(d *json.Decoder) error {
 		case json.Delim: // [、]、{、}
 			switch v {
 			case '[':
+				validator.addArrayItem(nil)
 				validator.pushState('[')
+				validator.pushArray()
 			case ']':
+				err = validator.popArray()
 				validator.popName()
 
 				validator.popState()
@@ -99,6 +143,7 @@ func (validator *jsonValidator) valid(d *json.Decoder) error {
 					validator.popState()
 				}
 			case '{':
+				validator.addArrayItem(nil)
 				validator.pushState('{')
 			case '}':
 				validator.popName()
@@ -113,12 +158,16 @@ func (validator *jsonValidator) valid(d *json.Decoder) error {
 			if validator.state() != '[' {
 				validator.popState()
 				validator.popName()
+			} else {
+				validator.addArrayItem(v)
 			}
 		case float64, json.Number: // json number
 			err = validator.validValue(ast.TypeNumber, v)
 			if validator.state() != '[' { // 只有键值对结束时，才弹出键名
 				validator.popState()
 				validator.popName()
+			} else {
+				validator.addArrayItem(v)
 			}
 		}
 
@@ -128,6 +177,59 @@ func (validator *jsonValidator) valid(d *json.Decoder) error {
 	}
 }
 
+// 如果下一个值对应的是组合类型，则返回该类型，否则返回 nil。
+func (validator *jsonValidator) composition(d *json.Decoder) *ast.Param {
+	p := validator.find()
+	if p == nil || !p.Composed() {
+		return nil
+	}
+
+	switch validator.state() {
+	case ':':
+		if !p.Array.V() {
+			return p
+		}
+	case '[':
+		if p.Array.V() && d.More() {
+			return p
+		}
+	case 0:
+		if !p.Array.V() && d.More() {
+			return p
+		}
+	}
+	return nil
+}
+
+// 读取下一个完整的值，并按组合类型 p 进行验证。
+func (validator *jsonValidator) validComposition(d *json.Decoder, p *ast.Param) error {
+	var raw json.RawMessage
+	if err := d.Decode(&raw); err != nil {
+		return err
+	}
+
+	if err := validComposition(p, raw); err != nil {
+		var cerr *core.Error
+		if len(validator.names) > 0 && errors.As(err, &cerr) {
+			field := strings.Join(validator.names, ".")
+			if cerr.Field != "" {
+				field += "." + cerr.Field
+			}
+			cerr.Field = field
+		}
+		return err
+	}
+
+	switch validator.state() {
+	case ':':
+		validator.popState()
+		validator.popName()
+	case '[':
+		validator.addArrayItem(nil)
+	}
+	return nil
+}
+
 // 如果 t == "" 表示不需要验证类型，比如 null 可以赋值给任何类型
 func (validator *jsonValidator) validValue(t string, v any) error {
 	field := strings.Join(validator.names, ".")
@@ -176,8 +278,39 @@ func (validator *jsonValidator) validValue(t string, v any) error {
 		if !isValidRFC3339DateTime(vv) {
 			return core.NewError(locale.ErrInvalidFormat).WithField(field)
 		}
+	case ast.TypeUUID, ast.TypeIPv4, ast.TypeIPv6, ast.TypeHostname, ast.TypeByte:
+		vv, ok := v.(string)
+		if !ok || !isValidStringFormat(pt, vv) {
+			return core.NewError(locale.ErrInvalidFormat).WithField(field)
+		}
+	case ast.TypeInt32, ast.TypeInt64:
+		vv, ok := v.(float64)
+		if !ok || !isValidNumberFormat(pt, vv) {
+			return core.NewError(locale.ErrInvalidFormat).WithField(field)
+		}
 	case ast.TypeImage: // 可能是相对站点的根路径，不作类型检测
-	case ast.TypeInt, ast.TypeFloat: // 数值类型都被 json 解释为 float64，无法判断值是浮点还是整数。
+	case ast.TypeBinary, ast.TypePassword:
+	case ast.TypeInt, ast.TypeFloat, ast.TypeDouble: // 数值类型都被 json 解释为 float64，无法判断值是浮点还是整数。
+	case ast.TypeNone, ast.TypeBool, ast.TypeNumber, ast.TypeString, ast.TypeObject:
+	default: // 无效的类型已经在加载文档时报告
+		return core.NewError(locale.ErrInvalidValue).WithField(field)
+	}
+
+	switch vv := v.(type) {
+	case float64:
+		if err := validNumber(p, vv); err != nil {
+			return err.WithField(field)
+		}
+	case json.Number:
+		if f, err := vv.Float64(); err == nil {
+			if err := validNumber(p, f); err != nil {
+				return err.WithField(field)
+			}
+		}
+	case string:
+		if err := validString(p, vv); err != nil {
+			return err.WithField(field)
+		}
 	}
 
 	if isEnum(p) {
@@ -220,6 +353,46 @@ func (validator *jsonValidator) popName() {
 	}
 }
 
+func (validator *jsonValidator) pushArray() {
+	validator.arrays = append(validator.arrays, &jsonArray{
+		param: validator.find(),
+		field: strings.Join(validator.names, "."),
+	})
+}
+
+// 弹出最后一个数组，同时验证该数组的元素是否符合要求。
+func (validator *jsonValidator) popArray() error {
+	if len(validator.arrays) == 0 {
+		return nil
+	}
+
+	arr := validator.arrays[len(validator.arrays)-1]
+	validator.arrays = validator.arrays[:len(validator.arrays)-1]
+
+	if arr.param == nil { // 参数不存在的错误由 validValue 负责
+		return nil
+	}
+	if err := validItems(arr.param, arr.size, arr.values); err != nil {
+		return err.WithField(arr.field)
+	}
+	return nil
+}
+
+// 向当前所在的数组添加一个元素
+//
+// 仅在当前状态为数组时才有效，v 为 nil 表示非基本类型的元素。
+func (validator *jsonValidator) addArrayItem(v any) {
+	if validator.state() != '[' || len(validator.arrays) == 0 {
+		return
+	}
+
+	arr := validator.arrays[len(validator.arrays)-1]
+	arr.size++
+	if v != nil {
+		arr.values = append(arr.values, fmt.Sprint(v))
+	}
+}
+
 // 如果 names 为空，返回 validator.param
 func (validator *jsonValidator) find() *ast.Param {
 	p := validator.param
@@ -245,7 +418,7 @@ type jsonBuilder struct {
 }
 
 func buildJSON(p *ast.Request, indent string, g *GenOptions) ([]byte, error) {
-	if p != nil && p.Type.V() == ast.TypeNone {
+	if p != nil && p.Type.V() == ast.TypeNone && !p.Param().Composed() {
 		return nil, nil
 	}
 
@@ -270,10 +443,20 @@ func (builder *jsonBuilder) encode(p *ast.Param, chkArray bool, g *GenOptions) e
 		builder.w.WString("[\n")
 		builder.deep++
 
-		size := g.generateSliceSize()
+		size := g.generateSliceSize(p)
+		values, err := g.generateUniqueValues(p, size)
+		if err != nil {
+			return err
+		}
+		if values != nil {
+			size = len(values)
+		}
+
 		last := size - 1
 		for i := 0; i < size; i++ {
-			if err := builder.writeIndent().encode(p, false, g); err != nil {
+			if values != nil {
+				builder.writeIndent().writeValue(values[i])
+			} else if err := builder.writeIndent().encode(p, false, g); err != nil {
 				return err
 			}
 
@@ -288,6 +471,10 @@ func (builder *jsonBuilder) encode(p *ast.Param, chkArray bool, g *GenOptions) e
 		return builder.writeIndent().w.WString("]").Err
 	}
 
+	if p.Composed() {
+		return builder.encode(g.chooseBranch(p), true, g)
+	}
+
 	switch primitive, _ := ast.ParseType(p.Type.V()); primitive {
 	case ast.TypeNone:
 		builder.writeValue(nil)
@@ -296,7 +483,11 @@ func (builder *jsonBuilder) encode(p *ast.Param, chkArray bool, g *GenOptions) e
 	case ast.TypeNumber:
 		builder.writeValue(g.generateNumber(p))
 	case ast.TypeString:
-		builder.writeValue(g.generateString(p))
+		s, err := g.generateString(p)
+		if err != nil {
+			return err
+		}
+		builder.writeValue(s)
 	case ast.TypeObject:
 		builder.w.WString("{\n")
 		builder.deep++
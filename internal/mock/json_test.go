@@ -32,6 +32,45 @@ func TestJSONValidator_valid(t *testing.T) {
 	v = newJSONValidator(r)
 	d = json.NewDecoder(strings.NewReader(`5.0`))
 	a.Error(v.valid(d))
+
+	// 扩展的子类型
+	r = &ast.Request{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeInt32}}}
+	a.NotError(newJSONValidator(r).valid(json.NewDecoder(strings.NewReader(`1024`))))
+	a.Error(newJSONValidator(r).valid(json.NewDecoder(strings.NewReader(`1024.5`))))
+	a.Error(newJSONValidator(r).valid(json.NewDecoder(strings.NewReader(`4294967296`))))
+
+	r = &ast.Request{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeIPv4}}}
+	a.NotError(newJSONValidator(r).valid(json.NewDecoder(strings.NewReader(`"127.0.0.1"`))))
+	a.Error(newJSONValidator(r).valid(json.NewDecoder(strings.NewReader(`"::1"`))))
+
+	// 约束条件
+	r = &ast.Request{
+		Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeObject}},
+		Items: []*ast.Param{
+			{
+				Name: &ast.Attribute{Value: xmlenc.String{Value: "id"}},
+				Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeNumber}},
+				Min:  newNumberAttribute(1),
+			},
+			{
+				Name:        &ast.Attribute{Value: xmlenc.String{Value: "tags"}},
+				Type:        &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeString}},
+				Array:       newBoolAttribute(true),
+				MaxLength:   newNumberAttribute(3),
+				MaxItems:    newNumberAttribute(2),
+				UniqueItems: newBoolAttribute(true),
+			},
+		},
+	}
+	valid := func(content string) error {
+		return newJSONValidator(r).valid(json.NewDecoder(strings.NewReader(content)))
+	}
+	a.NotError(valid(`{"id":1,"tags":["a","b"]}`))
+	a.NotError(valid(`{"tags":[],"id":1}`))
+	a.Error(valid(`{"id":0,"tags":["a"]}`))
+	a.Error(valid(`{"id":1,"tags":["abcd"]}`))
+	a.Error(valid(`{"id":1,"tags":["a","b","c"]}`))
+	a.Error(valid(`{"id":1,"tags":["a","a"]}`))
 }
 
 func TestJSONValidator_find(t *testing.T) {
@@ -118,6 +157,18 @@ func TestValidJSON(t *testing.T) {
 		err := validJSON(item.Type, []byte(item.JSON))
 		a.NotError(err, "测试 %s 时返回错误值 %s", item.Title, err)
 	}
+
+	// 无效的类型
+	r := &ast.Request{
+		Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeObject}},
+		Items: []*ast.Param{
+			{
+				Name: &ast.Attribute{Value: xmlenc.String{Value: "id"}},
+				Type: &ast.TypeAttribute{Value: xmlenc.String{Value: "string.xxx"}},
+			},
+		},
+	}
+	a.Error(validJSON(r, []byte(`{"id":"1"}`)))
 }
 
 func TestBuildJSON(t *testing.T) {
@@ -129,4 +180,36 @@ func TestBuildJSON(t *testing.T) {
 		a.NotError(err, "测试 %s 返回了错误值 %s", item.Title, err).
 			Equal(string(data), item.JSON, "测试 %s 失败 v1:%s,v2:%s", item.Title, string(data), item.JSON)
 	}
+
+	// 约束条件
+	r := &ast.Request{
+		Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeObject}},
+		Items: []*ast.Param{
+			{
+				Name:     &ast.Attribute{Value: xmlenc.String{Value: "id"}},
+				Type:     &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeNumber}},
+				Array:    newBoolAttribute(true),
+				Max:      newNumberAttribute(10),
+				MaxItems: newNumberAttribute(2),
+			},
+			{
+				Name:        &ast.Attribute{Value: xmlenc.String{Value: "name"}},
+				Type:        &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeString}},
+				Array:       newBoolAttribute(true),
+				MaxLength:   newNumberAttribute(2),
+				UniqueItems: newBoolAttribute(true),
+			},
+		},
+	}
+	data, err := buildJSON(r, indent, testOptions)
+	a.NotError(err).Equal(string(data), `{
+    "id": [
+        9,
+        9
+    ],
+    "name": [
+        "10"
+    ]
+}`)
+	a.NotError(validJSON(r, data))
 }
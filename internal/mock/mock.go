@@ -8,6 +8,7 @@ import (
 	"image/gif"
 	"image/jpeg"
 	"image/png"
+	"math"
 	"net/http"
 	"strconv"
 	"strings"
@@ -15,6 +16,7 @@ import (
 
 	"github.com/issue9/mux/v7/examples/std"
 	"github.com/issue9/qheader"
+	"github.com/issue9/sliceutil"
 	"github.com/issue9/version"
 
 	"github.com/caixw/apidoc/v7/core"
@@ -38,7 +40,8 @@ type mock struct {
 // msg 用于处理各类输出消息，仅在 ServeHTTP 中的消息才输出到 msg；
 // d doc.APIDoc 实例，调用方需要保证该数据类型的正确性；
 // indent 缩进字符串；
-// servers 用于指定 d.Servers 中每一个服务对应的路由前缀；
+// servers 用于指定 d.Servers 中每一个服务对应的路由前缀，
+// 前缀中可以包含服务地址中的变量，变量值由键名为“服务名.变量名”的项指定；
 // gen 生成随机数据的函数；
 func New(msg *core.MessageHandler, d *ast.APIDoc, indent, imageURL string, servers map[string]string, gen *GenOptions) (http.Handler, error) {
 	c, err := version.SemVerCompatible(d.APIDoc.V(), ast.Version)
@@ -49,6 +52,11 @@ func New(msg *core.MessageHandler, d *ast.APIDoc, indent, imageURL string, serve
 		return nil, locale.NewError(locale.VersionInCompatible)
 	}
 
+	prefixes, err := serverPrefixes(d, servers)
+	if err != nil {
+		return nil, err
+	}
+
 	mu := std.NewRouter("apidoc mock server")
 	router := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		h := w.Header()
@@ -64,7 +72,7 @@ func New(msg *core.MessageHandler, d *ast.APIDoc, indent, imageURL string, serve
 		router:     mu,
 		h:          router,
 		indent:     indent,
-		servers:    servers,
+		servers:    prefixes,
 		gen:        gen,
 	}
 
@@ -96,10 +104,41 @@ func Load(h *core.MessageHandler, path core.URI, indent, imageURL string, server
 
 	// 加载并验证
 	d := &ast.APIDoc{}
-	d.Parse(h, b)
+	d.ParseBlocks(h, func(blocks chan core.Block) { blocks <- b })
 	return New(h, d, indent, imageURL, servers, gen)
 }
 
+// 计算 d.Servers 中各个服务对应的路由前缀
+//
+// servers 中以服务名为键名的项表示该服务的路由前缀，未指定则采用 /服务名 作为前缀。
+// 前缀中可以包含 {name} 形式的服务变量，比如 /{region}，
+// 变量值由 servers 中键名为“服务名.变量名”的项指定，未指定则采用变量的默认值。
+func serverPrefixes(d *ast.APIDoc, servers map[string]string) (map[string]string, error) {
+	prefixes := make(map[string]string, len(d.Servers))
+	for _, srv := range d.Servers {
+		name := srv.Name.V()
+		prefix, found := servers[name]
+		if !found {
+			prefix = "/" + name
+		}
+
+		for _, v := range srv.Variables {
+			key := name + "." + v.Name.V()
+			val, found := servers[key]
+			if !found {
+				val = v.Default.V()
+			} else if len(v.Enums) > 0 && sliceutil.Count(v.Enums, func(e *ast.Enum) bool { return e.Value.V() == val }) == 0 {
+				return nil, core.NewError(locale.ErrInvalidValue).WithField(key)
+			}
+			prefix = strings.ReplaceAll(prefix, "{"+v.Name.V()+"}", val)
+		}
+
+		prefixes[name] = prefix
+	}
+
+	return prefixes, nil
+}
+
 func (m *mock) parse() {
 	for _, api := range m.doc.APIs {
 		handler := m.buildAPI(api)
@@ -191,3 +230,38 @@ func isValidRFC3339DateTime(val string) bool {
 	_, err := time.Parse(time.RFC3339, val)
 	return err == nil
 }
+
+// 验证 val 是否符合字符串子类型 t 的格式要求
+//
+// 仅处理需要验证格式的子类型，其它类型始终返回 true。
+func isValidStringFormat(t, val string) bool {
+	switch t {
+	case ast.TypeUUID, ast.TypeIPv4, ast.TypeIPv6, ast.TypeHostname, ast.TypeByte:
+		_, ok := ast.ParseValue(t, val)
+		return ok
+	}
+	return true
+}
+
+// 验证 val 是否符合数值子类型 t 的要求
+//
+// 仅处理有取值范围要求的子类型，其它类型始终返回 true。
+func isValidNumberFormat(t string, val float64) bool {
+	switch t {
+	case ast.TypeInt32:
+		return isValidInteger(val, 32)
+	case ast.TypeInt64:
+		return isValidInteger(val, 64)
+	}
+	return true
+}
+
+// 判断 val 是否为 bitSize 位的整数
+func isValidInteger(val float64, bitSize int) bool {
+	if val != math.Trunc(val) {
+		return false
+	}
+
+	max := math.Ldexp(1, bitSize-1)
+	return val >= -max && val < max
+}
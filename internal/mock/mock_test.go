@@ -9,6 +9,7 @@ import (
 	"image/png"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/issue9/assert/v3"
@@ -738,6 +739,208 @@ func TestNew(t *testing.T) {
 	rslt.Handler.Stop()
 }
 
+func TestNew_security(t *testing.T) {
+	a := assert.New(t, false)
+	rslt := messagetest.NewMessageHandler()
+	d := &ast.APIDoc{}
+	d.ParseBlocks(rslt.Handler, func(blocks chan core.Block) {
+		blocks <- core.Block{Data: []byte(`<apidoc version="1.1.1" apidoc="6.1.0">
+	<title>title</title>
+	<mimetype>application/json</mimetype>
+	<security name="bearer" type="http" scheme="bearer" />
+	<api method="GET">
+		<path path="/users" />
+		<security>bearer</security>
+		<response status="200" mimetype="application/json" type="string" />
+	</api>
+</apidoc>`)}
+	})
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors)
+
+	rslt = messagetest.NewMessageHandler()
+	mock, err := New(rslt.Handler, d, indent, "/images", nil, testOptions)
+	a.NotError(err).NotNil(mock)
+	srv := rest.NewServer(a, mock, nil)
+
+	srv.Get("/users").
+		Header("accept", "application/json").
+		Do(nil).
+		Status(http.StatusUnauthorized).
+		Header("WWW-Authenticate", "bearer")
+
+	srv.Get("/users").
+		Header("accept", "application/json").
+		Header("Authorization", "Bearer token").
+		Do(nil).
+		Status(http.StatusOK)
+
+	rslt.Handler.Stop()
+	a.Equal(1, len(rslt.Errors))
+}
+
+func TestNew_cookie(t *testing.T) {
+	a := assert.New(t, false)
+	rslt := messagetest.NewMessageHandler()
+	d := &ast.APIDoc{}
+	d.ParseBlocks(rslt.Handler, func(blocks chan core.Block) {
+		blocks <- core.Block{Data: []byte(`<apidoc version="1.1.1" apidoc="6.1.0">
+	<title>title</title>
+	<mimetype>application/json</mimetype>
+	<api method="GET">
+		<path path="/users" />
+		<cookie name="session" type="string" min-length="3" summary="session" />
+		<response status="200" mimetype="application/json" type="string">
+			<cookie name="token" type="number" min="1" max="1" summary="token" />
+		</response>
+	</api>
+</apidoc>`)}
+	})
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors)
+
+	rslt = messagetest.NewMessageHandler()
+	mock, err := New(rslt.Handler, d, indent, "/images", nil, testOptions)
+	a.NotError(err).NotNil(mock)
+	srv := rest.NewServer(a, mock, nil)
+
+	srv.Get("/users").
+		Header("accept", "application/json").
+		Do(nil).
+		Status(http.StatusBadRequest)
+
+	srv.Get("/users").
+		Header("accept", "application/json").
+		Header("Cookie", "session=1").
+		Do(nil).
+		Status(http.StatusBadRequest)
+
+	srv.Get("/users").
+		Header("accept", "application/json").
+		Header("Cookie", "session=123").
+		Do(nil).
+		Status(http.StatusOK).
+		Header("Set-Cookie", "token=1")
+
+	rslt.Handler.Stop()
+	a.Equal(2, len(rslt.Errors))
+}
+
+func TestNew_pathPattern(t *testing.T) {
+	a := assert.New(t, false)
+	rslt := messagetest.NewMessageHandler()
+	d := &ast.APIDoc{}
+	d.ParseBlocks(rslt.Handler, func(blocks chan core.Block) {
+		blocks <- core.Block{Data: []byte(`<apidoc version="1.1.1" apidoc="6.1.0">
+	<title>title</title>
+	<mimetype>application/json</mimetype>
+	<api method="GET">
+		<path path="/users/{id:\d+}">
+			<param name="id" type="number" summary="id" />
+		</path>
+		<response status="200" mimetype="application/json" type="string" />
+	</api>
+</apidoc>`)}
+	})
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors)
+
+	rslt = messagetest.NewMessageHandler()
+	mock, err := New(rslt.Handler, d, indent, "/images", nil, testOptions)
+	a.NotError(err).NotNil(mock)
+	srv := rest.NewServer(a, mock, nil)
+
+	srv.Get("/users/1").
+		Header("accept", "application/json").
+		Do(nil).
+		Status(http.StatusOK)
+
+	srv.Get("/users/abc").
+		Header("accept", "application/json").
+		Do(nil).
+		Status(http.StatusNotFound)
+
+	rslt.Handler.Stop()
+}
+
+func TestNew_statusRange(t *testing.T) {
+	a := assert.New(t, false)
+	rslt := messagetest.NewMessageHandler()
+	d := &ast.APIDoc{}
+	d.ParseBlocks(rslt.Handler, func(blocks chan core.Block) {
+		blocks <- core.Block{Data: []byte(`<apidoc version="1.1.1" apidoc="6.1.0">
+	<title>title</title>
+	<mimetype>application/json</mimetype>
+	<api method="GET">
+		<path path="/errors" />
+		<response status="5XX" mimetype="application/json" type="string" />
+	</api>
+	<api method="GET">
+		<path path="/users" />
+		<response status="default" mimetype="application/json" type="string" />
+	</api>
+</apidoc>`)}
+	})
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors)
+
+	rslt = messagetest.NewMessageHandler()
+	mock, err := New(rslt.Handler, d, indent, "/images", nil, testOptions)
+	a.NotError(err).NotNil(mock)
+	srv := rest.NewServer(a, mock, nil)
+
+	srv.Get("/errors").
+		Header("accept", "application/json").
+		Do(nil).
+		Status(http.StatusInternalServerError)
+
+	srv.Get("/users").
+		Header("accept", "application/json").
+		Do(nil).
+		Status(http.StatusOK)
+
+	rslt.Handler.Stop()
+}
+
+func TestServerPrefixes(t *testing.T) {
+	a := assert.New(t, false)
+
+	newAttr := func(v string) *ast.Attribute {
+		return &ast.Attribute{Value: xmlenc.String{Value: v}}
+	}
+
+	d := &ast.APIDoc{Servers: []*ast.Server{
+		{Name: newAttr("admin")},
+		{
+			Name: newAttr("api"),
+			Variables: []*ast.ServerVariable{
+				{
+					Name:    newAttr("region"),
+					Default: newAttr("cn"),
+					Enums: []*ast.Enum{
+						{Value: newAttr("cn")},
+						{Value: newAttr("us")},
+					},
+				},
+				{Name: newAttr("base"), Default: newAttr("v1")},
+			},
+		},
+	}}
+
+	prefixes, err := serverPrefixes(d, nil)
+	a.NotError(err).Equal(prefixes, map[string]string{"admin": "/admin", "api": "/api"})
+
+	prefixes, err = serverPrefixes(d, map[string]string{"admin": "/a", "api": "/{region}/{base}"})
+	a.NotError(err).Equal(prefixes, map[string]string{"admin": "/a", "api": "/cn/v1"})
+
+	prefixes, err = serverPrefixes(d, map[string]string{"api": "/{region}/{base}", "api.region": "us", "api.base": "v2"})
+	a.NotError(err).Equal(prefixes, map[string]string{"admin": "/admin", "api": "/us/v2"})
+
+	// 不在枚举值中
+	prefixes, err = serverPrefixes(d, map[string]string{"api": "/{region}", "api.region": "eu"})
+	a.Error(err).Nil(prefixes)
+}
+
 func TestLoad(t *testing.T) {
 	a := assert.New(t, false)
 	rslt := messagetest.NewMessageHandler()
@@ -791,3 +994,67 @@ func TestIsValidRFC3339DateTime(t *testing.T) {
 	a.False(isValidRFC3339DateTime("2020-01-02T17:18:79Z")) // 错误的日期
 	a.False(isValidRFC3339DateTime("2020-01-32T17:18:19Z")) // 错误的日期
 }
+
+func TestIsValidStringFormat(t *testing.T) {
+	a := assert.New(t, false)
+
+	// 不需要验证格式的类型
+	a.True(isValidStringFormat(ast.TypeString, "::1"))
+	a.True(isValidStringFormat(ast.TypePassword, ""))
+}
+
+func TestIsValidUUID(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.True(isValidStringFormat(ast.TypeUUID, "123e4567-e89b-12d3-a456-426614174000"))
+	a.True(isValidStringFormat(ast.TypeUUID, "123E4567-E89B-12D3-A456-426614174000"))
+
+	a.False(isValidStringFormat(ast.TypeUUID, "123e4567e89b12d3a456426614174000"))
+	a.False(isValidStringFormat(ast.TypeUUID, "123e4567-e89b-12d3-a456-42661417400g"))
+}
+
+func TestIsValidIP(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.True(isValidStringFormat(ast.TypeIPv4, "127.0.0.1"))
+	a.False(isValidStringFormat(ast.TypeIPv4, "::1"))
+	a.False(isValidStringFormat(ast.TypeIPv4, "256.0.0.1"))
+
+	a.True(isValidStringFormat(ast.TypeIPv6, "::1"))
+	a.True(isValidStringFormat(ast.TypeIPv6, "2001:db8::ff00:42:8329"))
+	a.False(isValidStringFormat(ast.TypeIPv6, "127.0.0.1"))
+	a.False(isValidStringFormat(ast.TypeIPv6, "fe80::1%eth0"))
+}
+
+func TestIsValidHostname(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.True(isValidStringFormat(ast.TypeHostname, "localhost"))
+	a.True(isValidStringFormat(ast.TypeHostname, "api.example.com"))
+	a.True(isValidStringFormat(ast.TypeHostname, "1-api.example.com"))
+
+	a.False(isValidStringFormat(ast.TypeHostname, ""))
+	a.False(isValidStringFormat(ast.TypeHostname, "-api.example.com"))
+	a.False(isValidStringFormat(ast.TypeHostname, "api..example.com"))
+	a.False(isValidStringFormat(ast.TypeHostname, "api_1.example.com"))
+	a.False(isValidStringFormat(ast.TypeHostname, strings.Repeat("a", 64)+".com"))
+}
+
+func TestIsValidBase64(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.True(isValidStringFormat(ast.TypeByte, "YXBpZG9j"))
+	a.True(isValidStringFormat(ast.TypeByte, ""))
+	a.False(isValidStringFormat(ast.TypeByte, "YXBpZG9j="))
+	a.False(isValidStringFormat(ast.TypeByte, "apidoc!"))
+}
+
+func TestIsValidInteger(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.True(isValidInteger(1024, 32))
+	a.True(isValidInteger(-2147483648, 32))
+	a.False(isValidInteger(2147483648, 32))
+	a.True(isValidInteger(2147483648, 64))
+	a.False(isValidInteger(1.5, 64))
+}
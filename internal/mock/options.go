@@ -49,16 +49,86 @@ func (g *GenOptions) generateNumber(p *ast.Param) any {
 		}
 		return v
 	}
-	return g.Number(p)
+	return fitNumber(p, g.Number(p))
 }
 
-func (g *GenOptions) generateString(p *ast.Param) string {
+// 生成字符串，如果 p 的 pattern 属性无法生成内容，则返回错误信息。
+func (g *GenOptions) generateString(p *ast.Param) (string, error) {
 	if isEnum(p) {
-		return p.Enums[g.Index(len(p.Enums))].Value.V()
+		return p.Enums[g.Index(len(p.Enums))].Value.V(), nil
 	}
-	return g.String(p)
+
+	v := g.String(p)
+	if validString(p, v) == nil {
+		return v, nil
+	}
+
+	if p.Pattern == nil {
+		return fitStringLength(p, v), nil
+	}
+
+	for i := 0; i < maxGenerateRetries; i++ {
+		s, err := g.generatePattern(p.Pattern.V())
+		if err != nil {
+			return "", p.Pattern.Location.WithError(err).WithField(p.Pattern.AttributeName.String())
+		}
+		v = s
+		if validString(p, v) == nil {
+			break
+		}
+	}
+	return v, nil
+}
+
+// 生成基本类型的值，非基本类型返回 nil。
+func (g *GenOptions) generateValue(p *ast.Param) (any, error) {
+	switch primitive, _ := ast.ParseType(p.Type.V()); primitive {
+	case ast.TypeBool:
+		return g.generateBool(), nil
+	case ast.TypeNumber:
+		return g.generateNumber(p), nil
+	case ast.TypeString:
+		return g.generateString(p)
+	}
+	return nil, nil
 }
 
-func (g *GenOptions) generateSliceSize() int {
-	return g.SliceSize()
+// 生成数组的长度，会根据 p 中的 min-items 和 max-items 作调整。
+func (g *GenOptions) generateSliceSize(p *ast.Param) int {
+	size := g.SliceSize()
+	if p.MinItems != nil && size < p.MinItems.IntValue() {
+		size = p.MinItems.IntValue()
+	}
+	if p.MaxItems != nil && size > p.MaxItems.IntValue() {
+		size = p.MaxItems.IntValue()
+	}
+	return size
+}
+
+// 生成数组中基本类型的元素，如果 p 要求元素不可重复，则会去掉重复的值。
+//
+// 非基本类型或是不要求元素唯一时，返回 nil，由调用方自行生成。
+func (g *GenOptions) generateUniqueValues(p *ast.Param, size int) ([]any, error) {
+	if !p.UniqueItems.V() || p.Type.V() == ast.TypeObject || p.Type.V() == ast.TypeNone {
+		return nil, nil
+	}
+
+	if isEnum(p) && size > len(p.Enums) {
+		size = len(p.Enums)
+	}
+
+	var err error
+	values := generateUniqueValues(size, func() any {
+		if err != nil {
+			return nil
+		}
+
+		var v any
+		v, err = g.generateValue(p)
+		return v
+	})
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
 }
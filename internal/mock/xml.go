@@ -21,9 +21,34 @@ import (
 type xmlValidator struct {
 	namespaces []*ast.XMLNamespace
 	decoder    *xml.Decoder
+
+	// 最后一个验证的元素内容，用于判断数组元素是否重复。
+	lastValue string
+
+	// 最后读取的内容在原始数据中的字节范围，用于定位出错的位置。
+	start, end int64
 }
 
 func validXML(ns []*ast.XMLNamespace, p *ast.Request, content []byte) error {
+	_, _, err := validXMLRange(ns, p, content)
+	return err
+}
+
+// 验证 content 是否符合 p 的定义
+//
+// 验证失败时，start 和 end 表示出错的内容在 content 中的字节范围。
+func validXMLRange(ns []*ast.XMLNamespace, p *ast.Request, content []byte) (start, end int, err error) {
+	validator := &xmlValidator{
+		namespaces: ns,
+		decoder:    xml.NewDecoder(bytes.NewReader(content)),
+	}
+	if err = validator.valid(p, content); err != nil {
+		return int(validator.start), int(validator.end), err
+	}
+	return 0, 0, nil
+}
+
+func (v *xmlValidator) valid(p *ast.Request, content []byte) error {
 	if len(content) == 0 {
 		if p == nil || p.Type.V() == ast.TypeNone {
 			return nil
@@ -31,12 +56,8 @@ func validXML(ns []*ast.XMLNamespace, p *ast.Request, content []byte) error {
 		return core.NewError(locale.ErrInvalidFormat)
 	}
 
-	validator := &xmlValidator{
-		namespaces: ns,
-		decoder:    xml.NewDecoder(bytes.NewReader(content)),
-	}
 	for {
-		token, err := validator.decoder.Token()
+		token, err := v.token()
 		if errors.Is(err, io.EOF) && token == nil { // 正常结束
 			return nil
 		}
@@ -46,16 +67,24 @@ func validXML(ns []*ast.XMLNamespace, p *ast.Request, content []byte) error {
 
 		switch elem := token.(type) {
 		case xml.StartElement:
-			if err := validator.validXMLNamespaces(elem); err != nil {
+			if err := v.validXMLNamespaces(elem); err != nil {
 				return err
 			}
-			return validator.validXMLElement(elem, p.Param(), true, elem.Name.Local)
+			return v.validXMLElement(elem, p.Param(), true, elem.Name.Local)
 		case xml.EndElement:
 			return core.NewError(locale.ErrInvalidFormat)
 		}
 	}
 }
 
+// 读取下一个 token，同时记录其在原始数据中的位置。
+func (v *xmlValidator) token() (xml.Token, error) {
+	v.start = v.decoder.InputOffset()
+	token, err := v.decoder.Token()
+	v.end = v.decoder.InputOffset()
+	return token, err
+}
+
 func (v *xmlValidator) validXMLNamespaces(start xml.StartElement) error {
 ATTR:
 	for _, attr := range start.Attr {
@@ -146,9 +175,13 @@ func (v *xmlValidator) validXMLElement(start xml.StartElement, p *ast.Param, chk
 
 	var chardata []byte
 	var started bool
+
+	// 数组元素的数量以及基本类型的元素值
+	var size int
+	var values []string
 LOOP:
 	for {
-		token, err := v.decoder.Token()
+		token, err := v.token()
 		if errors.Is(err, io.EOF) && token == nil { // 正常结束
 			return nil
 		}
@@ -159,9 +192,14 @@ LOOP:
 		switch elem := token.(type) {
 		case xml.StartElement:
 			if chkArray && p.Array.V() && v.validXMLName(elem.Name, p, false) {
+				v.lastValue = ""
 				if err := v.validXMLElement(elem, p, false, buildXMLField(field, p)); err != nil {
 					return err
 				}
+				size++
+				if p.Type.V() != ast.TypeObject {
+					values = append(values, v.lastValue)
+				}
 				chardata = nil
 				started = true
 				continue LOOP
@@ -185,8 +223,16 @@ LOOP:
 				return core.NewError(locale.ErrNotFoundEndTag).WithField(elem.Name.Local)
 			}
 
+			// 仅在有包裹元素时才能确定数组元素的数量
+			if chkArray && p.Array.V() && parseXMLWrappedName(p, true) != "" {
+				if err := validItems(p, size, values); err != nil {
+					return err.WithField(field)
+				}
+			}
+
 			if chardata != nil && !started {
-				return validXMLValue(p, p.Name.V(), string(chardata))
+				v.lastValue = string(chardata)
+				return validXMLValue(p, p.Name.V(), v.lastValue)
 			}
 			return nil
 		case xml.CharData:
@@ -239,10 +285,18 @@ func validXMLValue(p *ast.Param, field, v string) error {
 		if _, err := strconv.ParseInt(v, 10, 64); err != nil {
 			return core.NewError(locale.ErrInvalidFormat).WithField(field)
 		}
-	case ast.TypeFloat:
+	case ast.TypeFloat, ast.TypeDouble:
 		if _, err := strconv.ParseFloat(v, 64); err != nil {
 			return core.NewError(locale.ErrInvalidFormat).WithField(field)
 		}
+	case ast.TypeInt32:
+		if _, err := strconv.ParseInt(v, 10, 32); err != nil {
+			return core.NewError(locale.ErrInvalidFormat).WithField(field)
+		}
+	case ast.TypeInt64:
+		if _, err := strconv.ParseInt(v, 10, 64); err != nil {
+			return core.NewError(locale.ErrInvalidFormat).WithField(field)
+		}
 	case ast.TypeBool:
 		if _, err := strconv.ParseBool(v); err != nil {
 			return core.NewError(locale.ErrInvalidFormat).WithField(field)
@@ -267,10 +321,27 @@ func validXMLValue(p *ast.Param, field, v string) error {
 		if !isValidRFC3339DateTime(v) {
 			return core.NewError(locale.ErrInvalidFormat).WithField(field)
 		}
-	case ast.TypeString, ast.TypeObject, ast.TypeImage:
+	case ast.TypeUUID, ast.TypeIPv4, ast.TypeIPv6, ast.TypeHostname, ast.TypeByte:
+		if !isValidStringFormat(p.Type.V(), v) {
+			return core.NewError(locale.ErrInvalidFormat).WithField(field)
+		}
+		if err := validString(p, v); err != nil {
+			return err.WithField(field)
+		}
 		return nil
-	default:
-		panic(fmt.Sprintf("文档中类型定义错误 %s", p.Type.V()))
+	case ast.TypeString, ast.TypeImage, ast.TypeBinary, ast.TypePassword:
+		if err := validString(p, v); err != nil {
+			return err.WithField(field)
+		}
+		return nil
+	case ast.TypeObject:
+		return nil
+	default: // 无效的类型已经在加载文档时报告
+		return core.NewError(locale.ErrInvalidValue).WithField(field)
+	}
+
+	if err := validTextConstraints(p, v); err != nil {
+		return err.WithField(field)
 	}
 
 	if isEnum(p) {
@@ -339,20 +410,32 @@ func parseXML(ns []*ast.XMLNamespace, p *ast.Param, chkArray, root bool, g *GenO
 	}
 
 	if p.Type.V() != ast.TypeObject {
-		builder.chardata = genXMLValue(g, p)
+		v, err := genXMLValue(g, p)
+		if err != nil {
+			return nil, err
+		}
+		builder.chardata = v
 		goto RET
 	}
 
 	for _, item := range p.Items {
 		switch {
 		case item.XMLAttr.V():
+			v, err := genXMLValue(g, item)
+			if err != nil {
+				return nil, err
+			}
 			attr := xml.Attr{
 				Name:  buildXMLName(item, false),
-				Value: fmt.Sprint(genXMLValue(g, item)),
+				Value: fmt.Sprint(v),
 			}
 			builder.start.Attr = append(builder.start.Attr, attr)
 		case item.XMLExtract.V():
-			builder.chardata = genXMLValue(g, item)
+			v, err := genXMLValue(g, item)
+			if err != nil {
+				return nil, err
+			}
+			builder.chardata = v
 			builder.cdata = item.XMLCData.V()
 		case item.Array.V():
 			if err := parseXMLArray(ns, item, builder, g); err != nil {
@@ -403,7 +486,23 @@ func parseXMLArray(ns []*ast.XMLNamespace, p *ast.Param, parent *xmlBuilder, g *
 		parent.items = append(parent.items, b)
 	}
 
-	for i := 0; i < g.generateSliceSize(); i++ {
+	size := g.generateSliceSize(p)
+	values, err := g.generateUniqueValues(p, size)
+	if err != nil {
+		return err
+	}
+	if values != nil {
+		for _, val := range values {
+			b.items = append(b.items, &xmlBuilder{
+				start:    xml.StartElement{Name: buildXMLName(p, false)},
+				chardata: val,
+				cdata:    p.XMLCData.V(),
+			})
+		}
+		return nil
+	}
+
+	for i := 0; i < size; i++ {
 		bb, err := parseXML(ns, p, false, false, g)
 		if err != nil {
 			return err
@@ -438,14 +537,14 @@ func (builder *xmlBuilder) encode(e *xml.Encoder) error {
 	return e.EncodeToken(builder.start.End())
 }
 
-func genXMLValue(g *GenOptions, p *ast.Param) any {
+func genXMLValue(g *GenOptions, p *ast.Param) (any, error) {
 	switch primitive, _ := ast.ParseType(p.Type.V()); primitive {
 	case ast.TypeNone:
-		return ""
+		return "", nil
 	case ast.TypeBool:
-		return g.generateBool()
+		return g.generateBool(), nil
 	case ast.TypeNumber:
-		return g.generateNumber(p)
+		return g.generateNumber(p), nil
 	case ast.TypeString:
 		return g.generateString(p)
 	default: // ast.TypeObject:
@@ -38,6 +38,25 @@ func TestValidXML(t *testing.T) {
 	}
 	content := `<root id="1024"><desc>1024</desc></root>`
 	a.Error(validXML(nil, p, []byte(content)))
+
+	// 约束条件
+	p = &ast.Request{
+		Name: &ast.Attribute{Value: xmlenc.String{Value: "root"}},
+		Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeObject}},
+		Items: []*ast.Param{
+			{
+				Name:        &ast.Attribute{Value: xmlenc.String{Value: "tag"}},
+				Type:        &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeString}},
+				Array:       newBoolAttribute(true),
+				XML:         ast.XML{XMLWrapped: &ast.Attribute{Value: xmlenc.String{Value: "tags"}}},
+				MaxItems:    newNumberAttribute(2),
+				UniqueItems: newBoolAttribute(true),
+			},
+		},
+	}
+	a.NotError(validXML(nil, p, []byte(`<root><tags><tag>a</tag><tag>b</tag></tags></root>`)))
+	a.Error(validXML(nil, p, []byte(`<root><tags><tag>a</tag><tag>b</tag><tag>c</tag></tags></root>`)))
+	a.Error(validXML(nil, p, []byte(`<root><tags><tag>a</tag><tag>a</tag></tags></root>`)))
 }
 
 func TestBuildXML(t *testing.T) {
@@ -309,6 +328,24 @@ func TestValidXMLParamValue(t *testing.T) {
 	a.NotError(validXMLValue(&ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeString}}}, "", "fxy0"))
 	a.NotError(validXMLValue(&ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeString}}}, "", ""))
 
+	// 扩展的子类型
+	a.NotError(validXMLValue(&ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeInt64}}}, "", "4294967296"))
+	a.Error(validXMLValue(&ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeInt32}}}, "", "4294967296"))
+	a.NotError(validXMLValue(&ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeDouble}}}, "", "1.5"))
+	a.NotError(validXMLValue(&ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeUUID}}}, "", "123e4567-e89b-12d3-a456-426614174000"))
+	a.Error(validXMLValue(&ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeUUID}}}, "", "123"))
+	a.NotError(validXMLValue(&ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeIPv6}}}, "", "::1"))
+	a.Error(validXMLValue(&ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeHostname}}}, "", "-example.com"))
+	a.Error(validXMLValue(&ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeByte}}}, "", "apidoc!"))
+	a.NotError(validXMLValue(&ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeBinary}}}, "", "apidoc!"))
+	a.NotError(validXMLValue(&ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypePassword}}}, "", "apidoc!"))
+
+	// 约束条件
+	a.NotError(validXMLValue(&ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeNumber}}, Max: newNumberAttribute(5)}, "", "5"))
+	a.Error(validXMLValue(&ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeNumber}}, Max: newNumberAttribute(5)}, "", "6"))
+	a.NotError(validXMLValue(&ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeString}}, MinLength: newNumberAttribute(2)}, "", "ab"))
+	a.Error(validXMLValue(&ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeString}}, MinLength: newNumberAttribute(2)}, "", "a"))
+
 	// Bool
 	a.NotError(validXMLValue(&ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeBool}}}, "", "true"))
 	a.NotError(validXMLValue(&ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeBool}}}, "", "false"))
@@ -319,13 +356,9 @@ func TestValidXMLParamValue(t *testing.T) {
 	a.NotError(validXMLValue(&ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeObject}}}, "", ""))
 	a.NotError(validXMLValue(&ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeObject}}}, "", "{}"))
 
-	// panic
-	a.Panic(func() {
-		validXMLValue(&ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: "xxx"}}}, "", "{}")
-	})
-	a.Panic(func() {
-		validXMLValue(&ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: "xxx"}}}, "", "")
-	})
+	// 无效的类型
+	a.Error(validXMLValue(&ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: "xxx"}}}, "", "{}"))
+	a.Error(validXMLValue(&ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: "xxx"}}}, "", ""))
 
 	// bool enum
 	p := &ast.Param{
@@ -371,26 +404,26 @@ func TestValidXMLParamValue(t *testing.T) {
 func TestGenXMLValue(t *testing.T) {
 	a := assert.New(t, false)
 
-	v := genXMLValue(testOptions, &ast.Param{})
-	a.Equal(v, "")
+	v, err := genXMLValue(testOptions, &ast.Param{})
+	a.NotError(err).Equal(v, "")
 
-	v = genXMLValue(testOptions, &ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeNone}}})
-	a.Equal(v, "")
+	v, err = genXMLValue(testOptions, &ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeNone}}})
+	a.NotError(err).Equal(v, "")
 
-	v = genXMLValue(testOptions, &ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeBool}}})
-	a.Equal(v, true)
+	v, err = genXMLValue(testOptions, &ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeBool}}})
+	a.NotError(err).Equal(v, true)
 
-	v = genXMLValue(testOptions, &ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeNumber}}})
-	a.Equal(v, 1024)
+	v, err = genXMLValue(testOptions, &ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeNumber}}})
+	a.NotError(err).Equal(v, 1024)
 
-	v = genXMLValue(testOptions, &ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeString}}})
-	a.Equal(v, "1024")
+	v, err = genXMLValue(testOptions, &ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeString}}})
+	a.NotError(err).Equal(v, "1024")
 
 	a.Panic(func() {
-		v = genXMLValue(testOptions, &ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeObject}}})
+		v, err = genXMLValue(testOptions, &ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeObject}}})
 	})
 
 	a.Panic(func() {
-		v = genXMLValue(testOptions, &ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: "not-exists"}}})
+		v, err = genXMLValue(testOptions, &ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: "not-exists"}}})
 	})
 }
@@ -12,6 +12,7 @@
 // node-type 表示当前节点的类型，可以是以下值：
 //   - elem 表示这是一个子元素；
 //   - attr 表示为一个 XML 属性；
+//   - attrs 表示名称以 name 开头的所有属性，字段类型必须为切片，比如 x- 开头的扩展属性；
 //   - cdata 表示为 CDATA 数据；
 //   - content 表示为普通的字符串值；
 //   - meta 表示这个字段仅用于描述当前元素的元数据，比如元素的名称等；
@@ -39,11 +40,13 @@ const (
 	element
 	cdata
 	content
-	meta // 用于描述节点的一些元数据
+	meta       // 用于描述节点的一些元数据
+	attributes // 名称前缀相同的一组属性
 )
 
 var stringNodeMap = map[string]Type{
 	"attr":    attribute,
+	"attrs":   attributes,
 	"elem":    element,
 	"cdata":   cdata,
 	"content": content,
@@ -55,6 +58,7 @@ type Node struct {
 	Attributes     []*Value // 当前标签的属性值列表
 	Elements       []*Value // 当前标签的元素列表
 	CData, Content *Value   // 当前标签如果没有子元素，则可能有普通的内容或是 CDATA 内容
+	AnyAttributes  *Value   // 名称以 AnyAttributes.Name 开头的属性列表，这些属性不能与 Attributes 中的同名。
 	Value          Value    // 当前节点本身代表的值
 	TypeName       string   // 当前节点的类型名称
 }
@@ -95,6 +99,8 @@ func New(name string, rv reflect.Value) *Node {
 		switch node {
 		case attribute:
 			n.appendAttr(NewValue(fieldName, v, omitempty, usage))
+		case attributes:
+			n.setAnyAttributes(NewValue(fieldName, v, omitempty, usage))
 		case element:
 			n.appendElem(NewValue(fieldName, v, omitempty, usage))
 		case meta:
@@ -125,6 +131,10 @@ func (n *Node) appendAnonymous(v reflect.Value) {
 		n.appendElem(elem)
 	}
 
+	if anonymous.AnyAttributes != nil {
+		n.setAnyAttributes(anonymous.AnyAttributes)
+	}
+
 	if anonymous.CData != nil {
 		n.setCData(anonymous.CData)
 	}
@@ -134,6 +144,16 @@ func (n *Node) appendAnonymous(v reflect.Value) {
 	}
 }
 
+func (n *Node) setAnyAttributes(v *Value) {
+	if n.AnyAttributes != nil {
+		panic("已经定义了一个节点用于表示 attrs 内容")
+	}
+	if k := RealType(v.Type()).Kind(); k != reflect.Slice {
+		panic(fmt.Sprintf("attrs 的类型必须为切片，当前为 %s", k))
+	}
+	n.AnyAttributes = v
+}
+
 func (n *Node) setCData(v *Value) {
 	if n.CData != nil {
 		panic("已经定义了一个节点用于表示 cdata 内容")
@@ -170,6 +190,14 @@ func (n *Node) Attribute(name string) (*Value, bool) {
 	return n.findElem(name, n.Attributes)
 }
 
+// AnyAttribute 判断名称为 name 的属性是否可以保存在 AnyAttributes 中
+func (n *Node) AnyAttribute(name string) (*Value, bool) {
+	if n.AnyAttributes == nil || !strings.HasPrefix(name, n.AnyAttributes.Name) {
+		return nil, false
+	}
+	return n.AnyAttributes, true
+}
+
 func (n *Node) findElem(name string, elems []*Value) (*Value, bool) {
 	for _, e := range elems {
 		if e.Name == name {
@@ -323,6 +323,42 @@ func TestNewNode(t *testing.T) {
 	})
 }
 
+func TestNode_AnyAttribute(t *testing.T) {
+	a := assert.New(t, false)
+
+	n := New("root", reflect.ValueOf(&struct {
+		Attr1 intAttr    `apidoc:"attr1,attr,usage"`
+		X     []*intAttr `apidoc:"x-,attrs,usage,omitempty"`
+	}{}))
+	a.Length(n.Attributes, 1).NotNil(n.AnyAttributes)
+
+	v, found := n.AnyAttribute("x-team")
+	a.True(found).Equal(v.Name, "x-")
+	v, found = n.AnyAttribute("attr1")
+	a.False(found).Nil(v)
+
+	n = New("root", reflect.ValueOf(&struct {
+		Attr1 intAttr `apidoc:"attr1,attr,usage"`
+	}{}))
+	v, found = n.AnyAttribute("x-team")
+	a.False(found).Nil(v)
+
+	// 非切片
+	a.Panic(func() {
+		New("root", reflect.ValueOf(&struct {
+			X intAttr `apidoc:"x-,attrs,usage"`
+		}{}))
+	})
+
+	// 多个 attrs
+	a.Panic(func() {
+		New("root", reflect.ValueOf(&struct {
+			X []*intAttr `apidoc:"x-,attrs,usage"`
+			Y []*intAttr `apidoc:"y-,attrs,usage"`
+		}{}))
+	})
+}
+
 func TestParseTag(t *testing.T) {
 	a := assert.New(t, false)
 
@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/caixw/apidoc/v7/internal/ast"
+)
+
+// Extensions 扩展字段
+//
+// 键名都以 x- 开头，YAML 可以通过 inline 直接输出至所在对象，
+// JSON 则需要由所在对象实现 json.Marshaler 接口。
+type Extensions map[string]any
+
+// 由 x- 开头的属性和 extension 元素生成扩展字段
+func newExtensions(attrs []*ast.Attribute, exts []*ast.Extension) Extensions {
+	if len(attrs) == 0 && len(exts) == 0 {
+		return nil
+	}
+
+	ret := make(Extensions, len(attrs)+len(exts))
+	for _, attr := range attrs {
+		ret[attr.AttributeName.Local.Value] = attr.V()
+	}
+	for _, ext := range exts {
+		var v any
+		if err := json.Unmarshal([]byte(ext.V()), &v); err != nil { // 格式错误的内容在解析文档时已经报错
+			continue
+		}
+		ret[ext.Name.V()] = v
+	}
+	return ret
+}
+
+// 将 ext 合并到 v 的 JSON 内容中
+//
+// v 不能实现 json.Marshaler 接口，否则会造成死循环。
+func marshalJSONWithExtensions(v any, ext Extensions) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(ext) == 0 {
+		return data, err
+	}
+
+	extData, err := json.Marshal(ext)
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.Equal(data, []byte("{}")) {
+		return extData, nil
+	}
+	data = append(data[:len(data)-1], ',')
+	return append(data, extData[1:]...), nil
+}
+
+// MarshalJSON json.Marshaler
+func (o *Operation) MarshalJSON() ([]byte, error) {
+	type operation Operation
+	return marshalJSONWithExtensions((*operation)(o), o.Extensions)
+}
+
+// MarshalJSON json.Marshaler
+func (p *Parameter) MarshalJSON() ([]byte, error) {
+	type parameter Parameter
+	return marshalJSONWithExtensions((*parameter)(p), p.Extensions)
+}
+
+// MarshalJSON json.Marshaler
+func (h *Header) MarshalJSON() ([]byte, error) {
+	return (*Parameter)(h).MarshalJSON()
+}
+
+// MarshalJSON json.Marshaler
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	type schema Schema
+	return marshalJSONWithExtensions((*schema)(s), s.Extensions)
+}
+
+// MarshalJSON json.Marshaler
+func (mt *MediaType) MarshalJSON() ([]byte, error) {
+	type mediaType MediaType
+	return marshalJSONWithExtensions((*mediaType)(mt), mt.Extensions)
+}
+
+// MarshalJSON json.Marshaler
+func (srv *Server) MarshalJSON() ([]byte, error) {
+	type server Server
+	return marshalJSONWithExtensions((*server)(srv), srv.Extensions)
+}
+
+// MarshalJSON json.Marshaler
+func (tag *Tag) MarshalJSON() ([]byte, error) {
+	type t Tag
+	return marshalJSONWithExtensions((*t)(tag), tag.Extensions)
+}
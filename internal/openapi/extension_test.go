@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/issue9/assert/v3"
+	"gopkg.in/yaml.v3"
+
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/core/messagetest"
+	"github.com/caixw/apidoc/v7/internal/ast"
+	"github.com/caixw/apidoc/v7/internal/xmlenc"
+)
+
+func TestMarshalJSONWithExtensions(t *testing.T) {
+	a := assert.New(t, false)
+
+	data, err := json.Marshal(&Tag{Name: "t1"})
+	a.NotError(err).Equal(string(data), `{"name":"t1"}`)
+
+	data, err = json.Marshal(&Tag{Name: "t1", Extensions: Extensions{"x-team": "api", "x-rate": map[string]int{"limit": 100}}})
+	a.NotError(err).Equal(string(data), `{"name":"t1","x-rate":{"limit":100},"x-team":"api"}`)
+
+	data, err = json.Marshal(&MediaType{Extensions: Extensions{"x-team": "api"}})
+	a.NotError(err).Equal(string(data), `{"x-team":"api"}`)
+
+	data, err = json.Marshal(&Header{Description: "h", Extensions: Extensions{"x-team": "api"}})
+	a.NotError(err).Equal(string(data), `{"description":"h","x-team":"api"}`)
+
+	data, err = yaml.Marshal(&Tag{Name: "t1", Extensions: Extensions{"x-team": "api"}})
+	a.NotError(err).Equal(string(data), "name: t1\nx-team: api\n")
+}
+
+func TestConvert_extensions(t *testing.T) {
+	a := assert.New(t, false)
+
+	rslt := messagetest.NewMessageHandler()
+	p, err := xmlenc.NewParser(rslt.Handler, core.Block{Data: []byte(`<apidoc version="1.0.0">
+	<title>title</title>
+	<mimetype>application/json</mimetype>
+	<tag name="t1" title="t1" x-owner="team1" />
+	<server name="s1" url="https://example.com" x-region="cn" />
+	<api method="GET" x-tier="gold">
+		<extension name="x-rate"><![CDATA[{"limit":100}]]></extension>
+		<path path="/users">
+			<query name="page" type="number" summary="page" x-internal="true" />
+		</path>
+		<response status="200" type="object" mimetype="application/json" summary="ok" x-cache="60">
+			<param name="id" type="number" summary="id" x-db="users.id" />
+		</response>
+	</api>
+</apidoc>`)})
+	a.NotError(err)
+	doc := &ast.APIDoc{}
+	xmlenc.Decode(p, doc, core.XMLNamespace)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors)
+
+	o, err := convert(doc)
+	a.NotError(err).NotNil(o)
+	a.Equal(o.Tags[0].Extensions, Extensions{"x-owner": "team1"}).
+		Equal(o.Servers[0].Extensions, Extensions{"x-region": "cn"})
+
+	get := o.Paths["/users"].Get
+	a.Equal(get.Extensions, Extensions{"x-tier": "gold", "x-rate": map[string]any{"limit": float64(100)}})
+
+	page := get.Parameters[0]
+	a.Equal(page.Extensions, Extensions{"x-internal": "true"}).
+		Nil(page.Schema.Extensions)
+
+	content := get.Responses["200"].Content["application/json"]
+	a.Equal(content.Extensions, Extensions{"x-cache": "60"}).
+		Equal(content.Schema.Properties["id"].Extensions, Extensions{"x-db": "users.id"})
+
+	data, err := JSON(doc)
+	a.NotError(err).
+		Contains(string(data), `"x-tier": "gold"`).
+		Contains(string(data), `"x-db": "users.id"`)
+}
@@ -29,6 +29,10 @@ type OpenAPI struct {
 	Security     []*SecurityRequirement `json:"security,omitempty" yaml:"security,omitempty"`
 	Tags         []*Tag                 `json:"tags,omitempty" yaml:"tags,omitempty"`
 	ExternalDocs *ExternalDocumentation `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
+
+	// openapi 3.0 并不支持 webhooks，以扩展字段的形式输出，
+	// 其结构与 openapi 3.1 中的 webhooks 相同。
+	Webhooks map[string]*PathItem `json:"x-webhooks,omitempty" yaml:"x-webhooks,omitempty"`
 }
 
 // Components 可复用的对象
@@ -67,6 +71,8 @@ type Tag struct {
 	Name         string                 `json:"name" yaml:"name"`
 	Description  string                 `json:"description,omitempty" yaml:"description,omitempty"`
 	ExternalDocs *ExternalDocumentation `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
+
+	Extensions Extensions `json:"-" yaml:",inline"`
 }
 
 // Example 示例代码
@@ -86,6 +92,7 @@ func newTag(tag *ast.Tag) *Tag {
 	return &Tag{
 		Name:        tag.Name.V(),
 		Description: tag.Title.V(),
+		Extensions:  newExtensions(tag.XAttributes, tag.Extensions),
 	}
 }
 
@@ -130,6 +137,13 @@ func (oa *OpenAPI) sanitize() *core.Error {
 		}
 	}
 
+	for k, webhook := range oa.Webhooks {
+		if err := webhook.sanitize(); err != nil {
+			err.Field = "x-webhooks[" + k + "]." + err.Field
+			return err
+		}
+	}
+
 	if oa.Components != nil {
 		if err := oa.Components.sanitize(); err != nil {
 			err.Field = "components." + err.Field
@@ -190,6 +204,13 @@ func (c *Components) sanitize() *core.Error {
 		}
 	}
 
+	for key, item := range c.SecuritySchemes {
+		if err := item.sanitize(); err != nil {
+			err.Field = "securitySchemes[" + key + "]." + err.Field
+			return err
+		}
+	}
+
 	for key, item := range c.Links {
 		if err := item.sanitize(); err != nil {
 			err.Field = "links[" + key + "]." + err.Field
@@ -209,6 +230,10 @@ func (ext *ExternalDocumentation) sanitize() *core.Error {
 }
 
 func (l *Link) sanitize() *core.Error {
+	if l.Server == nil {
+		return nil
+	}
+
 	if err := l.Server.sanitize(); err != nil {
 		err.Field = "server." + err.Field
 		return err
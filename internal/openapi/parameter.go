@@ -34,6 +34,8 @@ type Parameter struct {
 	Content         map[string]*MediaType `json:"content,omitempty" yaml:"content,omitempty"`
 
 	Ref string `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+
+	Extensions Extensions `json:"-" yaml:",inline"`
 }
 
 func (p *Parameter) sanitize() *core.Error {
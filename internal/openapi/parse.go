@@ -4,7 +4,6 @@ package openapi
 
 import (
 	"encoding/json"
-	"strconv"
 	"strings"
 
 	"gopkg.in/yaml.v3"
@@ -47,9 +46,28 @@ func convert(doc *ast.APIDoc) (*OpenAPI, error) {
 		openapi.Tags = append(openapi.Tags, newTag(tag))
 	}
 
+	if len(doc.Types) > 0 || len(doc.Securities) > 0 {
+		openapi.Components = &Components{}
+	}
+	if len(doc.Types) > 0 {
+		openapi.Components.Schemas = make(map[string]*Schema, len(doc.Types))
+		for _, t := range doc.Types {
+			openapi.Components.Schemas[t.Name.V()] = newSchemaFromTypeDef(doc, t)
+		}
+	}
+	if len(doc.Securities) > 0 {
+		openapi.Components.SecuritySchemes = make(map[string]*SecurityScheme, len(doc.Securities))
+		for _, s := range doc.Securities {
+			openapi.Components.SecuritySchemes[s.Name.V()] = newSecurityScheme(s)
+		}
+	}
+
 	if err := parsePaths(openapi, doc); err != nil {
 		return nil, err
 	}
+	if err := parseWebhooks(openapi, doc); err != nil {
+		return nil, err
+	}
 
 	if err := openapi.sanitize(); err != nil {
 		return nil, err
@@ -59,10 +77,11 @@ func convert(doc *ast.APIDoc) (*OpenAPI, error) {
 
 func parsePaths(openapi *OpenAPI, d *ast.APIDoc) *core.Error {
 	for _, api := range d.APIs {
-		p := openapi.Paths[api.Path.Path.V()]
+		path := api.Path.Template()
+		p := openapi.Paths[path]
 		if p == nil {
 			p = &PathItem{}
-			openapi.Paths[api.Path.Path.V()] = p
+			openapi.Paths[path] = p
 		}
 
 		operation, err := setOperation(p, api.Method.V())
@@ -87,7 +106,18 @@ func parsePaths(openapi *OpenAPI, d *ast.APIDoc) *core.Error {
 		if api.Description != nil {
 			operation.Description = api.Description.V()
 		}
-		setOperationParams(d, operation, api)
+		operation.Extensions = newExtensions(api.XAttributes, api.Extensions)
+		setOperationParams(d, operation, api.Path, api.Headers, api.Cookies, api.Requests)
+
+		// security
+		if len(api.Securities) > 0 {
+			operation.Security = make([]*SecurityRequirement, 0, len(api.Securities))
+			for _, s := range api.Securities {
+				if d.Security(s.V()) != nil {
+					operation.Security = append(operation.Security, newSecurityRequirement(s))
+				}
+			}
+		}
 
 		// servers
 		// 不为 PathItem 设置 servers，直接写在 operation
@@ -113,103 +143,319 @@ func parsePaths(openapi *OpenAPI, d *ast.APIDoc) *core.Error {
 			}
 		}
 
-		// requests
-		if len(api.Requests) > 0 {
-			content := make(map[string]*MediaType, len(api.Requests))
-			for _, r := range api.Requests {
-				examples := make(map[string]*Example, len(r.Examples))
-				for _, exp := range r.Examples {
-					examples[exp.Mimetype.V()] = &Example{
-						Value: ExampleValue(exp.Content.Value.Value),
-					}
-				}
+		operation.RequestBody = newRequestBody(d, api.Requests)
+		operation.Responses = newResponses(d, api.Responses)
 
-				content[r.Mimetype.V()] = &MediaType{
-					Schema:   newSchemaFromRequest(d, r, true),
-					Examples: examples,
-				}
+		// callbacks
+		for _, cb := range api.Callbacks {
+			expr, item, err := newCallback(d, cb)
+			if err != nil {
+				err.Field = "paths." + path + ".callbacks." + err.Field
+				return err
+			}
+			if expr == "" { // 无法确定回调地址，ast 中已经给出警告
+				continue
 			}
 
-			operation.RequestBody = &RequestBody{
-				Content: content,
+			if operation.Callbacks == nil {
+				operation.Callbacks = make(map[string]*Callback, len(api.Callbacks))
 			}
+			operation.Callbacks[callbackName(cb)] = &Callback{expr: item}
 		}
+	} // end for doc.Apis
 
-		// responses
-		operation.Responses = make(map[string]*Response, len(api.Responses))
-		for _, resp := range api.Responses {
-			status := strconv.Itoa(resp.Status.V())
-			r, found := operation.Responses[status]
-			if !found {
-				r = &Response{
-					Description: getDescription(resp.Description, resp.Summary),
-					Headers:     make(map[string]*Header, 10),
-					Content:     make(map[string]*MediaType, 10),
-				}
-				operation.Responses[status] = r
+	return nil
+}
+
+func parseWebhooks(openapi *OpenAPI, d *ast.APIDoc) *core.Error {
+	for _, webhook := range d.Webhooks {
+		_, item, err := newCallback(d, webhook)
+		if err != nil {
+			err.Field = "x-webhooks." + err.Field
+			return err
+		}
+
+		if openapi.Webhooks == nil {
+			openapi.Webhooks = make(map[string]*PathItem, len(d.Webhooks))
+		}
+		openapi.Webhooks[webhook.Name.V()] = item
+	}
+
+	return nil
+}
+
+// 回调在 openapi 中的名称
+func callbackName(cb *ast.Callback) string {
+	if name := cb.Name.V(); name != "" {
+		return name
+	}
+	return "callback"
+}
+
+// 将回调转换成 PathItem
+//
+// expr 为回调的地址，未指定 expression 时采用 path 的值，两者都可以为空。
+// webhook 不需要地址，仅使用 item 的值。
+func newCallback(d *ast.APIDoc, cb *ast.Callback) (expr string, item *PathItem, err *core.Error) {
+	expr = cb.Expression.V()
+	if expr == "" && cb.Path != nil {
+		expr = cb.Path.Template()
+	}
+
+	item = &PathItem{}
+	operation, err := setOperation(item, cb.Method.V())
+	if err != nil {
+		return "", nil, err
+	}
+
+	operation.Deprecated = cb.Deprecated != nil
+	operation.Summary = cb.Summary.V()
+	operation.Description = cb.Description.V()
+	setOperationParams(d, operation, cb.Path, cb.Headers, nil, cb.Requests)
+	operation.RequestBody = newRequestBody(d, cb.Requests)
+	operation.Responses = newResponses(d, cb.Responses)
+
+	return expr, item, nil
+}
+
+func newRequestBody(d *ast.APIDoc, requests []*ast.Request) *RequestBody {
+	if len(requests) == 0 {
+		return nil
+	}
+
+	content := make(map[string]*MediaType, len(requests))
+	for _, r := range requests {
+		examples := make(map[string]*Example, len(r.Examples))
+		for _, exp := range r.Examples {
+			examples[exp.Mimetype.V()] = &Example{
+				Value: ExampleValue(exp.Content.Value.Value),
 			}
+		}
 
-			for _, h := range resp.Headers {
-				r.Headers[h.Name.V()] = &Header{
-					Style:       Style{Style: StyleSimple},
-					Description: getDescription(h.Description, h.Summary),
-				}
+		content[r.Mimetype.V()] = &MediaType{
+			Schema:     newSchemaFromRequest(d, r, true),
+			Examples:   examples,
+			Encoding:   newEncoding(r),
+			Extensions: newExtensions(r.XAttributes, r.Extensions),
+		}
+	}
+
+	return &RequestBody{Content: content}
+}
+
+func newResponses(d *ast.APIDoc, responses []*ast.Request) map[string]*Response {
+	ret := make(map[string]*Response, len(responses))
+	for _, resp := range responses {
+		status := resp.Status.V()
+		r, found := ret[status]
+		if !found {
+			r = &Response{
+				Description: getDescription(resp.Description, resp.Summary),
+				Headers:     make(map[string]*Header, 10),
+				Content:     make(map[string]*MediaType, 10),
 			}
+			ret[status] = r
+		}
 
-			examples := make(map[string]*Example, len(resp.Examples))
-			for _, exp := range resp.Examples {
-				examples[exp.Mimetype.V()] = &Example{
-					Summary: exp.Summary.V(),
-					Value:   ExampleValue(exp.Content.Value.Value),
-				}
+		for _, h := range resp.Headers {
+			r.Headers[h.Name.V()] = &Header{
+				Style:       Style{Style: StyleSimple},
+				Description: getDescription(h.Description, h.Summary),
+				Extensions:  newExtensions(h.XAttributes, h.Extensions),
 			}
-			r.Content[resp.Mimetype.V()] = &MediaType{
-				Schema:   newSchemaFromRequest(d, resp, true),
-				Examples: examples,
+		}
+		if len(resp.Cookies) > 0 {
+			r.Headers["Set-Cookie"] = newSetCookieHeader(resp.Cookies)
+		}
+		for _, link := range resp.Links {
+			if r.Links == nil {
+				r.Links = make(map[string]*Link, len(resp.Links))
 			}
+			r.Links[link.Name.V()] = newLink(link)
 		}
-	} // end for doc.Apis
 
-	return nil
+		examples := make(map[string]*Example, len(resp.Examples))
+		for _, exp := range resp.Examples {
+			examples[exp.Mimetype.V()] = &Example{
+				Summary: exp.Summary.V(),
+				Value:   ExampleValue(exp.Content.Value.Value),
+			}
+		}
+		r.Content[resp.Mimetype.V()] = &MediaType{
+			Schema:     newSchemaFromRequest(d, resp, true),
+			Examples:   examples,
+			Extensions: newExtensions(resp.XAttributes, resp.Extensions),
+		}
+	}
+	return ret
 }
 
-func setOperationParams(doc *ast.APIDoc, operation *Operation, api *ast.API) {
-	l := len(api.Path.Params) + len(api.Path.Queries)
+// headers 和 cookies 为所有请求共用的报头和 cookie，requests 中各自的报头和 cookie 也会一并写入。
+func setOperationParams(doc *ast.APIDoc, operation *Operation, path *ast.Path, headers, cookies []*ast.Param, requests []*ast.Request) {
+	if path == nil {
+		path = &ast.Path{}
+	}
+	l := len(path.Params) + len(path.Queries)
 	operation.Parameters = make([]*Parameter, 0, l)
 
-	for _, param := range api.Path.Params {
-		operation.Parameters = append(operation.Parameters, &Parameter{
+	for _, param := range path.Params {
+		schema := newSchema(doc, param, true)
+		if pattern := path.Pattern(param.Name.V()); pattern != "" && schema.Ref == "" && schema.Type != TypeArray && schema.Pattern == "" {
+			schema.Pattern = ast.AnchorPattern(pattern) // 与 ast 中的检测保持一致，匹配整个值
+		}
+
+		operation.Parameters = append(operation.Parameters, newParameter(param, &Parameter{
 			Name:        param.Name.V(),
 			IN:          ParameterINPath,
 			Description: getDescription(param.Description, param.Summary),
 			Required:    !param.Optional.V(),
-			Schema:      newSchema(doc, param, true),
-		})
+			Schema:      schema,
+		}))
 	}
 
-	for _, param := range api.Path.Queries {
-		operation.Parameters = append(operation.Parameters, &Parameter{
+	for _, param := range path.Queries {
+		operation.Parameters = append(operation.Parameters, newParameter(param, &Parameter{
 			Name:        param.Name.V(),
 			IN:          ParameterINQuery,
 			Description: getDescription(param.Description, param.Summary),
 			Required:    !param.Optional.V(),
 			Schema:      newSchema(doc, param, true),
-		})
+		}))
+	}
+
+	for _, param := range headers {
+		operation.Parameters = append(operation.Parameters, newHeaderParameter(param))
 	}
 
-	// 将各个类型的 Request 中的报头都集中到 operation.Parameters
-	for _, r := range api.Requests {
+	for _, param := range cookies {
+		operation.Parameters = append(operation.Parameters, newCookieParameter(doc, param))
+	}
+
+	// 将各个类型的 Request 中的报头和 cookie 都集中到 operation.Parameters
+	for _, r := range requests {
 		for _, param := range r.Headers {
-			operation.Parameters = append(operation.Parameters, &Parameter{
-				Style:       Style{Style: StyleSimple},
-				Name:        param.Name.V(),
-				IN:          ParameterINHeader,
-				Description: getDescription(param.Description, param.Summary),
-			})
+			operation.Parameters = append(operation.Parameters, newHeaderParameter(param))
+		}
+
+		for _, param := range r.Cookies {
+			operation.Parameters = append(operation.Parameters, newCookieParameter(doc, param))
 		}
 	}
 }
 
+func newHeaderParameter(param *ast.Param) *Parameter {
+	return newParameter(param, &Parameter{
+		Style:       Style{Style: StyleSimple},
+		Name:        param.Name.V(),
+		IN:          ParameterINHeader,
+		Description: getDescription(param.Description, param.Summary),
+	})
+}
+
+func newCookieParameter(doc *ast.APIDoc, param *ast.Param) *Parameter {
+	return newParameter(param, &Parameter{
+		Style:       Style{Style: StyleForm},
+		Name:        param.Name.V(),
+		IN:          ParameterINCookie,
+		Description: getDescription(param.Description, param.Summary),
+		Required:    !param.Optional.V(),
+		Schema:      newSchema(doc, param, true),
+	})
+}
+
+// 为 p 添加 param 中的扩展内容
+//
+// 扩展内容只出现在 p 中，而不是 p.Schema。
+func newParameter(param *ast.Param, p *Parameter) *Parameter {
+	p.Extensions = newExtensions(param.XAttributes, param.Extensions)
+	if p.Schema != nil {
+		p.Schema.Extensions = nil
+	}
+	return p
+}
+
+func newLink(link *ast.ResponseLink) *Link {
+	l := &Link{
+		OperationID: link.API.V(),
+		Description: getDescription(link.Description, link.Summary),
+	}
+
+	if len(link.Params) > 0 {
+		l.Parameters = make(map[string]string, len(link.Params))
+		for _, p := range link.Params {
+			l.Parameters[p.Name.V()] = responseBodyExpression(p.Field.V())
+		}
+	}
+
+	return l
+}
+
+// 将以 . 分隔的返回字段转换成 openapi 的运行时表达式
+//
+// 比如 data.id 会被转换成 $response.body#/data/id。
+func responseBodyExpression(field string) string {
+	r := strings.NewReplacer("~", "~0", "/", "~1")
+
+	fields := strings.Split(field, ".")
+	for i, f := range fields {
+		fields[i] = r.Replace(f)
+	}
+	return "$response.body#/" + strings.Join(fields, "/")
+}
+
+// 将返回内容中的 cookie 转换成 Set-Cookie 报头
+//
+// openapi 无法单独描述返回的 cookie，只能将所有 cookie 的说明合并到 Set-Cookie 报头中。
+func newSetCookieHeader(cookies []*ast.Param) *Header {
+	descs := make([]string, 0, len(cookies))
+	for _, cookie := range cookies {
+		desc := cookie.Name.V()
+		if d := getDescription(cookie.Description, cookie.Summary); d != "" {
+			desc += ": " + d
+		}
+		descs = append(descs, desc)
+	}
+
+	return &Header{
+		Style:       Style{Style: StyleSimple},
+		Description: strings.Join(descs, "\n"),
+	}
+}
+
+// 生成表单类请求中各个字段的编码方式
+//
+// 仅 application/x-www-form-urlencoded 和 multipart/form-data 需要，其它类型返回 nil。
+func newEncoding(r *ast.Request) map[string]*Encoding {
+	switch r.Mimetype.V() {
+	case "application/x-www-form-urlencoded", "multipart/form-data":
+	default:
+		return nil
+	}
+
+	if len(r.Items) == 0 {
+		return nil
+	}
+
+	encoding := make(map[string]*Encoding, len(r.Items))
+	for _, item := range r.Items {
+		en := &Encoding{Style: Style{
+			Style:   StyleForm,
+			Explode: !item.ArrayStyle.V(),
+		}}
+
+		switch item.Type.V() {
+		case ast.TypeBinary:
+			en.ContentType = "application/octet-stream"
+		case ast.TypeObject:
+			en.ContentType = "application/json"
+		}
+
+		encoding[item.Name.V()] = en
+	}
+
+	return encoding
+}
+
 func getDescription(desc *ast.Richtext, summary *ast.Attribute) string {
 	if desc.V() != "" {
 		return desc.V()
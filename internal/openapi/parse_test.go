@@ -11,7 +11,10 @@ import (
 	"github.com/issue9/assert/v3"
 
 	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/core/messagetest"
+	"github.com/caixw/apidoc/v7/internal/ast"
 	"github.com/caixw/apidoc/v7/internal/ast/asttest"
+	"github.com/caixw/apidoc/v7/internal/xmlenc"
 )
 
 func TestJSON(t *testing.T) {
@@ -43,3 +46,230 @@ func TestYAML(t *testing.T) {
 	data, err := YAML(asttest.Get())
 	a.NotError(err).NotNil(data)
 }
+
+func TestNewEncoding(t *testing.T) {
+	a := assert.New(t, false)
+
+	newParam := func(name, typ string, arrayStyle bool) *ast.Param {
+		return &ast.Param{
+			Name:       &ast.Attribute{Value: xmlenc.String{Value: name}},
+			Type:       &ast.TypeAttribute{Value: xmlenc.String{Value: typ}},
+			ArrayStyle: &ast.BoolAttribute{Value: ast.Bool{Value: arrayStyle}},
+		}
+	}
+
+	r := &ast.Request{
+		Mimetype: &ast.Attribute{Value: xmlenc.String{Value: "application/json"}},
+		Items:    []*ast.Param{newParam("name", ast.TypeString, false)},
+	}
+	a.Nil(newEncoding(r))
+
+	r.Mimetype.Value.Value = "multipart/form-data"
+	r.Items = append(r.Items, newParam("file", ast.TypeBinary, false), newParam("obj", ast.TypeObject, true))
+	en := newEncoding(r)
+	a.Length(en, 3)
+	a.Equal(en["name"].Style.Style, StyleForm).True(en["name"].Explode).Empty(en["name"].ContentType)
+	a.Equal(en["file"].ContentType, "application/octet-stream")
+	a.Equal(en["obj"].ContentType, "application/json").False(en["obj"].Explode)
+	for _, item := range en {
+		a.NotError(item.sanitize())
+	}
+
+	r.Mimetype.Value.Value = "application/x-www-form-urlencoded"
+	a.Length(newEncoding(r), 3)
+
+	r.Items = nil
+	a.Nil(newEncoding(r))
+}
+
+func TestSetOperationParams(t *testing.T) {
+	a := assert.New(t, false)
+
+	newParam := func(name string) *ast.Param {
+		return &ast.Param{
+			Name: &ast.Attribute{Value: xmlenc.String{Value: name}},
+			Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeString}},
+		}
+	}
+
+	api := &ast.API{
+		Path: &ast.Path{
+			Path:    &ast.Attribute{Value: xmlenc.String{Value: `/users/{id:\d+}`}},
+			Params:  []*ast.Param{newParam("id")},
+			Queries: []*ast.Param{newParam("page")},
+		},
+		Headers: []*ast.Param{newParam("h0")},
+		Cookies: []*ast.Param{newParam("session")},
+		Requests: []*ast.Request{
+			{
+				Headers: []*ast.Param{newParam("h1")},
+				Cookies: []*ast.Param{newParam("c1")},
+			},
+		},
+	}
+
+	operation := &Operation{}
+	setOperationParams(&ast.APIDoc{}, operation, api.Path, api.Headers, api.Cookies, api.Requests)
+	a.Length(operation.Parameters, 6)
+
+	id := operation.Parameters[0]
+	a.Equal(id.Name, "id").
+		Equal(id.IN, ParameterINPath).
+		Equal(id.Schema.Pattern, `^(?:\d+)$`)
+
+	h0 := operation.Parameters[2]
+	a.Equal(h0.Name, "h0").Equal(h0.IN, ParameterINHeader)
+
+	session := operation.Parameters[3]
+	a.Equal(session.Name, "session").
+		Equal(session.IN, ParameterINCookie).
+		True(session.Required).
+		NotNil(session.Schema).
+		NotError(session.sanitize())
+
+	c1 := operation.Parameters[5]
+	a.Equal(c1.Name, "c1").Equal(c1.IN, ParameterINCookie)
+
+	// 回调可以没有 path
+	operation = &Operation{}
+	setOperationParams(&ast.APIDoc{}, operation, nil, nil, nil, api.Requests)
+	a.Length(operation.Parameters, 2)
+}
+
+func TestConvert_callbacks(t *testing.T) {
+	a := assert.New(t, false)
+
+	rslt := messagetest.NewMessageHandler()
+	p, err := xmlenc.NewParser(rslt.Handler, core.Block{Data: []byte(`<apidoc version="1.0.0">
+	<title>title</title>
+	<mimetype>application/json</mimetype>
+	<api method="POST">
+		<path path="/orders" />
+		<response status="201" summary="created" />
+		<callback name="paid" method="POST" expression="{$request.body#/notify}">
+			<request type="object" mimetype="application/json"><param name="id" type="number" summary="id" /></request>
+			<response status="200" summary="ok" />
+		</callback>
+		<callback name="refunded" method="POST">
+			<path path="/refunds/{id}"><param name="id" type="number" summary="id" /></path>
+			<header name="x-signature" type="string" summary="signature" />
+			<request type="string" mimetype="application/json" />
+			<response status="200" summary="ok" />
+		</callback>
+		<callback name="ignored" method="POST">
+			<request type="string" mimetype="application/json" />
+			<response status="200" summary="ok" />
+		</callback>
+	</api>
+	<webhook name="created" method="POST" summary="user created">
+		<request type="string" mimetype="application/json" />
+		<response status="200" summary="ok" />
+	</webhook>
+</apidoc>`)})
+	a.NotError(err)
+	doc := &ast.APIDoc{}
+	xmlenc.Decode(p, doc, core.XMLNamespace)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors).Length(rslt.Warns, 1) // ignored 没有指定回调地址
+
+	o, err := convert(doc)
+	a.NotError(err).NotNil(o)
+
+	callbacks := o.Paths["/orders"].Post.Callbacks
+	a.Length(callbacks, 2)
+	paid := (*callbacks["paid"])["{$request.body#/notify}"]
+	a.NotNil(paid).NotNil(paid.Post).NotNil(paid.Post.RequestBody).NotNil(paid.Post.Responses["200"])
+	refunded := (*callbacks["refunded"])["/refunds/{id}"]
+	a.NotNil(refunded).Length(refunded.Post.Parameters, 2).
+		Equal(refunded.Post.Parameters[1].Name, "x-signature").
+		Equal(refunded.Post.Parameters[1].IN, ParameterINHeader)
+
+	a.Length(o.Webhooks, 1)
+	created := o.Webhooks["created"]
+	a.NotNil(created).Equal(created.Post.Summary, "user created")
+
+	data, err := json.Marshal(o)
+	a.NotError(err).Contains(string(data), `"x-webhooks":{"created"`)
+}
+
+func TestConvert_links(t *testing.T) {
+	a := assert.New(t, false)
+
+	rslt := messagetest.NewMessageHandler()
+	doc := &ast.APIDoc{}
+	doc.ParseBlocks(rslt.Handler, func(blocks chan core.Block) {
+		blocks <- core.Block{Data: []byte(`<apidoc version="1.0.0"><title>title</title><mimetype>application/json</mimetype></apidoc>`)}
+		blocks <- core.Block{Data: []byte(`<api method="GET" id="get-order">
+	<path path="/orders/{id}"><param name="id" type="number" summary="id" /></path>
+	<response status="200" summary="ok" />
+</api>`)}
+		blocks <- core.Block{Data: []byte(`<api method="POST" id="post-order">
+	<path path="/orders" />
+	<response status="201" type="object" mimetype="application/json" summary="created">
+		<param name="data" type="object" summary="data"><param name="id" type="number" summary="id" /></param>
+		<link name="order" api="get-order" summary="get order"><param name="id" field="data.id" /></link>
+	</response>
+</api>`)}
+	})
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors)
+
+	o, err := convert(doc)
+	a.NotError(err).NotNil(o)
+	link := o.Paths["/orders"].Post.Responses["201"].Links["order"]
+	a.NotNil(link).
+		Equal(link.OperationID, "get-order").
+		Equal(link.Description, "get order").
+		Equal(link.Parameters, map[string]string{"id": "$response.body#/data/id"})
+}
+
+func TestConvert_statusRange(t *testing.T) {
+	a := assert.New(t, false)
+
+	rslt := messagetest.NewMessageHandler()
+	doc := &ast.APIDoc{}
+	doc.ParseBlocks(rslt.Handler, func(blocks chan core.Block) {
+		blocks <- core.Block{Data: []byte(`<apidoc version="1.0.0"><title>title</title><mimetype>application/json</mimetype></apidoc>`)}
+		blocks <- core.Block{Data: []byte(`<api method="GET">
+	<path path="/users" />
+	<response status="200" summary="ok" />
+	<response status="4xx" summary="client error" />
+	<response status="default" summary="error" />
+</api>`)}
+	})
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors)
+
+	o, err := convert(doc)
+	a.NotError(err).NotNil(o)
+	responses := o.Paths["/users"].Get.Responses
+	a.Length(responses, 3).
+		NotNil(responses["200"]).
+		NotNil(responses["4XX"]).
+		NotNil(responses["default"])
+}
+
+func TestResponseBodyExpression(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.Equal(responseBodyExpression("id"), "$response.body#/id")
+	a.Equal(responseBodyExpression("data.id"), "$response.body#/data/id")
+	a.Equal(responseBodyExpression("a/b.c~d"), "$response.body#/a~1b/c~0d")
+}
+
+func TestNewSetCookieHeader(t *testing.T) {
+	a := assert.New(t, false)
+
+	h := newSetCookieHeader([]*ast.Param{
+		{
+			Name:    &ast.Attribute{Value: xmlenc.String{Value: "c1"}},
+			Summary: &ast.Attribute{Value: xmlenc.String{Value: "s1"}},
+		},
+		{
+			Name: &ast.Attribute{Value: xmlenc.String{Value: "c2"}},
+		},
+	})
+	a.NotNil(h).
+		Equal(h.Description, "c1: s1\nc2").
+		NotError(h.sanitize())
+}
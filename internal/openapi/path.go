@@ -40,6 +40,8 @@ type Operation struct {
 	Deprecated   bool                   `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
 	Security     []*SecurityRequirement `json:"security,omitempty" yaml:"security,omitempty"`
 	Servers      []*Server              `json:"servers,omitempty" yaml:"servers,omitempty"`
+
+	Extensions Extensions `json:"-" yaml:",inline"`
 }
 
 // RequestBody 请求内容
@@ -57,6 +59,8 @@ type MediaType struct {
 	Example  ExampleValue         `json:"example,omitempty" yaml:"example,omitempty"`
 	Examples map[string]*Example  `json:"examples,omitempty" yaml:"examples,omitempty"`
 	Encoding map[string]*Encoding `json:"encoding,omitempty" yaml:"encoding,omitempty"`
+
+	Extensions Extensions `json:"-" yaml:",inline"`
 }
 
 // Encoding 定义编码
@@ -69,7 +73,9 @@ type Encoding struct {
 }
 
 // Callback Object
-type Callback PathItem
+//
+// 键名为回调地址的表达式，比如 {$request.body#/url}。
+type Callback map[string]*PathItem
 
 // Response 每个 API 的返回信息
 type Response struct {
@@ -135,10 +141,11 @@ func (o *Operation) sanitize() *core.Error {
 	}
 
 	for name, call := range o.Callbacks {
-		p := (*PathItem)(call)
-		if err := p.sanitize(); err != nil {
-			err.Field = "callbacks[" + name + "]." + err.Field
-			return err
+		for expr, p := range *call {
+			if err := p.sanitize(); err != nil {
+				err.Field = "callbacks[" + name + "][" + expr + "]." + err.Field
+				return err
+			}
 		}
 	}
 
@@ -3,6 +3,8 @@
 package openapi
 
 import (
+	"reflect"
+
 	"github.com/caixw/apidoc/v7/core"
 	"github.com/caixw/apidoc/v7/internal/ast"
 )
@@ -10,6 +12,7 @@ import (
 // Schema.Type 需要的一些预定义数据类型
 const (
 	TypeInt      = "integer"
+	TypeNumber   = "number"
 	TypeLong     = "long"
 	TypeFloat    = "float"
 	TypeDouble   = "double"
@@ -19,46 +22,73 @@ const (
 	TypeArray    = "array"
 )
 
-var typeMaps = map[string]string{
-	ast.TypeBool:     TypeBool,
-	ast.TypeString:   TypeString,
-	ast.TypeNumber:   TypeDouble,
-	ast.TypeInt:      TypeLong,
-	ast.TypeFloat:    TypeFloat,
-	ast.TypeURL:      TypeString,
-	ast.TypeEmail:    TypeString,
-	ast.TypeImage:    TypeString,
-	ast.TypeDate:     TypeString,
-	ast.TypeTime:     TypeString,
-	ast.TypeDateTime: TypeString,
+// Schema.Format 需要的一些预定义格式
+const (
+	FormatInt32    = "int32"
+	FormatInt64    = "int64"
+	FormatDouble   = "double"
+	FormatUUID     = "uuid"
+	FormatIPv4     = "ipv4"
+	FormatIPv6     = "ipv6"
+	FormatHostname = "hostname"
+	FormatByte     = "byte"
+	FormatBinary   = "binary"
+	FormatPassword = "password"
+)
+
+// 文档中的类型对应的 Schema.Type 和 Schema.Format
+var typeMaps = map[string][2]string{
+	ast.TypeBool:     {TypeBool},
+	ast.TypeString:   {TypeString},
+	ast.TypeNumber:   {TypeDouble},
+	ast.TypeInt:      {TypeLong},
+	ast.TypeFloat:    {TypeFloat},
+	ast.TypeURL:      {TypeString},
+	ast.TypeEmail:    {TypeString},
+	ast.TypeImage:    {TypeString},
+	ast.TypeDate:     {TypeString},
+	ast.TypeTime:     {TypeString},
+	ast.TypeDateTime: {TypeString},
+	ast.TypeUUID:     {TypeString, FormatUUID},
+	ast.TypeIPv4:     {TypeString, FormatIPv4},
+	ast.TypeIPv6:     {TypeString, FormatIPv6},
+	ast.TypeHostname: {TypeString, FormatHostname},
+	ast.TypeByte:     {TypeString, FormatByte},
+	ast.TypeBinary:   {TypeString, FormatBinary},
+	ast.TypePassword: {TypeString, FormatPassword},
+	ast.TypeInt32:    {TypeInt, FormatInt32},
+	ast.TypeInt64:    {TypeInt, FormatInt64},
+	ast.TypeDouble:   {TypeNumber, FormatDouble},
 }
 
-func fromDocType(t string) string {
-	return typeMaps[t]
+func fromDocType(t string) (typ, format string) {
+	v := typeMaps[t]
+	return v[0], v[1]
 }
 
 // Schema 定义了输出和输出的数据类型
 type Schema struct {
-	Type string `json:"type,omitempty" yaml:"type,omitempty"`
-	Enum []any  `json:"enum,omitempty" yaml:"enum,omitempty"`
+	Type   string `json:"type,omitempty" yaml:"type,omitempty"`
+	Format string `json:"format,omitempty" yaml:"format,omitempty"`
+	Enum   []any  `json:"enum,omitempty" yaml:"enum,omitempty"`
 
 	// 数值验证
-	MultipleOf       int  `json:"multipleOf,omitempty" yaml:"multipleOf,omitempty"`
-	Maximum          int  `json:"maximum,omitempty" yaml:"maximum,omitempty"`
-	ExclusiveMaximum bool `json:"exclusiveMaximum,omitempty" yaml:"exclusiveMaximum,omitempty"`
-	Minimum          int  `json:"minimum,omitempty" yaml:"minimum,omitempty"`
-	ExclusiveMinimum bool `json:"exclusiveMinimum,omitempty" yaml:"exclusiveMinimum,omitempty"`
+	MultipleOf       int      `json:"multipleOf,omitempty" yaml:"multipleOf,omitempty"`
+	Maximum          *float64 `json:"maximum,omitempty" yaml:"maximum,omitempty"` // 0 也是有效值，所以采用指针
+	ExclusiveMaximum bool     `json:"exclusiveMaximum,omitempty" yaml:"exclusiveMaximum,omitempty"`
+	Minimum          *float64 `json:"minimum,omitempty" yaml:"minimum,omitempty"`
+	ExclusiveMinimum bool     `json:"exclusiveMinimum,omitempty" yaml:"exclusiveMinimum,omitempty"`
 
 	// 字符串验证
-	MaxLength int    `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
-	MinLength int    `json:"minLength,omitempty" yaml:"minLength,omitempty"`
+	MaxLength *int   `json:"maxLength,omitempty" yaml:"maxLength,omitempty"` // 0 也是有效值，所以采用指针
+	MinLength *int   `json:"minLength,omitempty" yaml:"minLength,omitempty"`
 	Pattern   string `json:"pattern,omitempty" yaml:"pattern,omitempty"`
 
 	// 数组验证
 	Items           *Schema `json:"items,omitempty" yaml:"items,omitempty"`
 	AdditionalItems *Schema `json:"additionalItems,omitempty" yaml:"additionalItems,omitempty"`
-	MaxItems        int     `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
-	MinItems        int     `json:"minItems,omitempty" yaml:"minItems,omitempty"`
+	MaxItems        *int    `json:"maxItems,omitempty" yaml:"maxItems,omitempty"` // 0 也是有效值，所以采用指针
+	MinItems        *int    `json:"minItems,omitempty" yaml:"minItems,omitempty"`
 	UniqueItems     bool    `json:"uniqueItems,omitempty" yaml:"uniqueItems,omitempty"`
 	Contains        *Schema `json:"contains,omitempty" yaml:"contains,omitempty"`
 
@@ -91,6 +121,8 @@ type Schema struct {
 	ExternalDocs  *ExternalDocumentation `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
 	Example       ExampleValue           `json:"example,omitempty" yaml:"example,omitempty"`
 	Deprecated    bool                   `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+
+	Extensions Extensions `json:"-" yaml:",inline"`
 }
 
 // XML 将 Schema 转换为 XML 的相关声明
@@ -103,8 +135,6 @@ type XML struct {
 }
 
 // Discriminator Object
-//
-// NOTE: 暂时未用到。
 type Discriminator struct {
 	PropertyName string            `json:"propertyName" yaml:"propertyName"`
 	Mapping      map[string]string `json:"mapping,omitempty" yaml:"mapping,omitempty"`
@@ -135,6 +165,31 @@ func (s *Schema) sanitize() *core.Error {
 	return nil
 }
 
+// 返回长度或数量的值，未指定时返回 nil。
+func newSize(attr *ast.NumberAttribute) *int {
+	if attr == nil {
+		return nil
+	}
+	size := attr.IntValue()
+	return &size
+}
+
+// 返回按类型转换之后的默认值，无法转换时返回原始的字符串。
+func newDefault(p *ast.Param) any {
+	if v, ok := p.DefaultValue(); ok {
+		return v
+	}
+	return p.Default.V()
+}
+
+// 返回按类型 t 转换之后的 v，无法转换时返回 v 本身。
+func newValue(t, v string) any {
+	if val, ok := ast.ParseValue(t, v); ok {
+		return val
+	}
+	return v
+}
+
 func newXML(doc *ast.APIDoc, p *ast.Param) *XML {
 	var ns string
 	prefix := p.XMLNSPrefix.V()
@@ -153,28 +208,53 @@ func newXML(doc *ast.APIDoc, p *ast.Param) *XML {
 // chkArray 是否需要检测当前类型是否为数组
 func newSchema(doc *ast.APIDoc, p *ast.Param, chkArray bool) *Schema {
 	if chkArray && p.Array.V() {
-		return &Schema{
-			Type:  TypeArray,
-			Items: newSchema(doc, p, false),
-			XML:   newXML(doc, p),
+		s := &Schema{
+			Type:        TypeArray,
+			Items:       newSchema(doc, p, false),
+			XML:         newXML(doc, p),
+			MinItems:    newSize(p.MinItems),
+			MaxItems:    newSize(p.MaxItems),
+			UniqueItems: p.UniqueItems.V(),
+			Default:     newDefault(p),
 		}
+		s.Items.Default = nil // 数组的默认值作用于整个数组
+		s.Extensions, s.Items.Extensions = s.Items.Extensions, nil
+		return s
 	}
 
+	typ, format := fromDocType(p.Type.V())
 	s := &Schema{
-		Type:        fromDocType(p.Type.V()),
+		Type:        typ,
+		Format:      format,
 		Title:       p.Summary.V(),
 		Description: p.Description.V(),
-		Default:     p.Default.V(),
+		Default:     newDefault(p),
 		Deprecated:  p.Deprecated != nil,
 		Required:    make([]string, 0, len(p.Items)),
 		XML:         newXML(doc, p),
+		Extensions:  newExtensions(p.XAttributes, p.Extensions),
+
+		ExclusiveMinimum: p.ExclusiveMin.V(),
+		ExclusiveMaximum: p.ExclusiveMax.V(),
+		MinLength:        newSize(p.MinLength),
+		MaxLength:        newSize(p.MaxLength),
+		Pattern:          p.Pattern.V(),
+	}
+
+	if p.Min != nil {
+		min := p.Min.Float64()
+		s.Minimum = &min
+	}
+	if p.Max != nil {
+		max := p.Max.Float64()
+		s.Maximum = &max
 	}
 
 	// enum
 	if len(p.Enums) > 0 {
 		s.Enum = make([]any, 0, len(p.Enums))
 		for _, e := range p.Enums {
-			s.Enum = append(s.Enum, e.Value.V())
+			s.Enum = append(s.Enum, newValue(p.Type.V(), e.Value.V()))
 		}
 	}
 
@@ -196,10 +276,109 @@ func newSchema(doc *ast.APIDoc, p *ast.Param, chkArray bool) *Schema {
 		}
 	}
 
+	// 组合类型
+	s.OneOf = newCompositionSchemas(doc, p.OneOf)
+	s.AnyOf = newCompositionSchemas(doc, p.AnyOf)
+	s.AllOf = newCompositionSchemas(doc, p.AllOf)
+	for _, c := range []*ast.Composition{p.OneOf, p.AnyOf, p.AllOf} {
+		if c != nil && c.Discriminator.V() != "" {
+			s.Discriminator = newDiscriminator(doc, c)
+			break
+		}
+	}
+
+	if p.Ref.V() != "" && doc.TypeDef(p.Ref.V()) != nil {
+		return newRefSchema(p, s)
+	}
 	return s
 }
 
+// 返回引用了类型定义的 Schema
+//
+// s 为根据 p 生成的完整 Schema，会从中去掉继承自类型定义的内容，
+// 如果还有剩余的内容，表示 p 覆盖了类型定义中的部分内容，
+// 因为 $ref 旁边的内容会被忽略，所以采用 allOf 的形式输出。
+func newRefSchema(p *ast.Param, s *Schema) *Schema {
+	ref := &Schema{Ref: schemaRef(p.Ref.V())}
+
+	in := p.Ref.Inherited()
+	if in.Type || p.Type == nil {
+		s.Type, s.Format = "", ""
+	}
+	if in.Items {
+		s.Properties, s.Required = nil, nil
+	}
+	if in.Enums {
+		s.Enum = nil
+	}
+	if in.Summary {
+		s.Title = ""
+	}
+	if in.Description {
+		s.Description = ""
+	}
+	if in.Deprecated {
+		s.Deprecated = false
+	}
+	if len(s.Required) == 0 {
+		s.Required = nil
+	}
+	s.XML = nil
+
+	if reflect.DeepEqual(s, &Schema{}) {
+		return ref
+	}
+	s.AllOf = append([]*Schema{ref}, s.AllOf...)
+	return s
+}
+
+func newCompositionSchemas(doc *ast.APIDoc, c *ast.Composition) []*Schema {
+	if c == nil {
+		return nil
+	}
+
+	schemas := make([]*Schema, 0, len(c.Items))
+	for _, item := range c.Items {
+		schemas = append(schemas, newSchema(doc, item, true))
+	}
+	return schemas
+}
+
+// 只有引用了类型定义的分支才会出现在 Mapping 中，
+// 其它分支无法通过 $ref 引用，只能由调用方根据 PropertyName 自行判断。
+func newDiscriminator(doc *ast.APIDoc, c *ast.Composition) *Discriminator {
+	d := &Discriminator{PropertyName: c.Discriminator.V()}
+
+	for _, item := range c.Items {
+		if ref := item.Ref.V(); ref != "" && doc.TypeDef(ref) != nil {
+			if d.Mapping == nil {
+				d.Mapping = make(map[string]string, len(c.Items))
+			}
+			d.Mapping[item.Name.V()] = schemaRef(ref)
+		}
+	}
+
+	return d
+}
+
 // chkArray 是否需要检测当前类型是否为数组
 func newSchemaFromRequest(doc *ast.APIDoc, p *ast.Request, chkArray bool) *Schema {
 	return newSchema(doc, p.Param(), chkArray)
 }
+
+// 将 ast.TypeDef 转换成 components.schemas 中的对象
+func newSchemaFromTypeDef(doc *ast.APIDoc, t *ast.TypeDef) *Schema {
+	return newSchema(doc, &ast.Param{
+		Type:        t.Type,
+		Deprecated:  t.Deprecated,
+		Items:       t.Items,
+		Summary:     t.Summary,
+		Enums:       t.Enums,
+		Description: t.Description,
+	}, false)
+}
+
+// 返回引用 components.schemas 中名为 name 的对象地址
+func schemaRef(name string) string {
+	return "#/components/schemas/" + name
+}
@@ -11,6 +11,25 @@ import (
 	"github.com/caixw/apidoc/v7/internal/xmlenc"
 )
 
+func TestFromDocType(t *testing.T) {
+	a := assert.New(t, false)
+
+	typ, format := fromDocType(ast.TypeString)
+	a.Equal(typ, TypeString).Empty(format)
+
+	typ, format = fromDocType(ast.TypeInt64)
+	a.Equal(typ, TypeInt).Equal(format, FormatInt64)
+
+	typ, format = fromDocType(ast.TypeDouble)
+	a.Equal(typ, TypeNumber).Equal(format, FormatDouble)
+
+	typ, format = fromDocType(ast.TypeUUID)
+	a.Equal(typ, TypeString).Equal(format, FormatUUID)
+
+	typ, format = fromDocType("not-exists")
+	a.Empty(typ).Empty(format)
+}
+
 func TestNewSchema(t *testing.T) {
 	a := assert.New(t, false)
 
@@ -27,7 +46,8 @@ func TestNewSchema(t *testing.T) {
 	output := newSchema(d, input, true)
 	a.Equal(output.Type, TypeBool).
 		True(output.Deprecated).
-		Equal(output.Title, input.Summary.V())
+		Equal(output.Title, input.Summary.V()).
+		Equal(output.Default, true)
 
 	input.Array = &ast.BoolAttribute{Value: ast.Bool{Value: true}}
 	output = newSchema(d, input, true)
@@ -54,6 +74,21 @@ func TestNewSchema(t *testing.T) {
 		Equal(2, len(output.Enum)).
 		Equal(output.Enum, []string{"v1", "v2"})
 
+	// 带类型的默认值和枚举
+	input = &ast.Param{
+		Type:    &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeNumber}},
+		Array:   &ast.BoolAttribute{Value: ast.Bool{Value: true}},
+		Default: &ast.Attribute{Value: xmlenc.String{Value: "[1,2]"}},
+		Enums: []*ast.Enum{
+			{Value: &ast.Attribute{Value: xmlenc.String{Value: "1"}}},
+			{Value: &ast.Attribute{Value: xmlenc.String{Value: "2"}}},
+		},
+	}
+	output = newSchema(d, input, true)
+	a.Equal(output.Default, []any{int64(1), int64(2)}).
+		Nil(output.Items.Default).
+		Equal(output.Items.Enum, []any{int64(1), int64(2)})
+
 	input = &ast.Param{
 		Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeNumber}},
 		Items: []*ast.Param{
@@ -74,4 +109,148 @@ func TestNewSchema(t *testing.T) {
 		Equal(output.Properties["p2"].Type, TypeDouble)
 
 	a.NotError(output.sanitize())
+
+	// ref
+	d.Types = []*ast.TypeDef{
+		{
+			Name: &ast.Attribute{Value: xmlenc.String{Value: "user"}},
+			Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeObject}},
+			Items: []*ast.Param{
+				{
+					Name: &ast.Attribute{Value: xmlenc.String{Value: "id"}},
+					Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeInt}},
+				},
+			},
+		},
+	}
+	input = &ast.Param{
+		Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeObject}},
+		Items: []*ast.Param{
+			{
+				Name:  &ast.Attribute{Value: xmlenc.String{Value: "user"}},
+				Ref:   &ast.RefAttribute{Value: xmlenc.String{Value: "user"}},
+				Array: &ast.BoolAttribute{Value: ast.Bool{Value: true}},
+			},
+			{
+				Name: &ast.Attribute{Value: xmlenc.String{Value: "group"}},
+				Ref:  &ast.RefAttribute{Value: xmlenc.String{Value: "not-exists"}},
+				Type: &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeString}},
+			},
+		},
+	}
+	output = newSchema(d, input, true)
+	a.Equal(output.Properties["user"].Type, TypeArray).
+		Equal(output.Properties["user"].Items.Ref, "#/components/schemas/user").
+		Equal(output.Properties["group"].Ref, "").
+		Equal(output.Properties["group"].Type, TypeString)
+
+	output = newSchemaFromTypeDef(d, d.Types[0])
+	a.Equal(output.Properties["id"].Type, TypeLong)
+
+	// ref 覆盖了类型定义中的部分内容
+	input = &ast.Param{
+		Name:      &ast.Attribute{Value: xmlenc.String{Value: "user"}},
+		Ref:       &ast.RefAttribute{Value: xmlenc.String{Value: "user"}},
+		Summary:   &ast.Attribute{Value: xmlenc.String{Value: "local"}},
+		Default:   &ast.Attribute{Value: xmlenc.String{Value: "{}"}},
+		MinLength: &ast.NumberAttribute{Value: ast.Number{Int: 1}},
+	}
+	output = newSchema(d, input, true)
+	a.Equal(output.Ref, "").
+		Equal(len(output.AllOf), 1).
+		Equal(output.AllOf[0].Ref, "#/components/schemas/user").
+		Equal(output.Title, "local").
+		Equal(output.Default, "{}").
+		Equal(*output.MinLength, 1).
+		Empty(output.Type).
+		Nil(output.Properties)
+	a.NotError(output.sanitize())
+
+	// 约束条件
+	input = &ast.Param{
+		Type:         &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeInt}},
+		Array:        &ast.BoolAttribute{Value: ast.Bool{Value: true}},
+		Min:          &ast.NumberAttribute{Value: ast.Number{Int: 0}},
+		Max:          &ast.NumberAttribute{Value: ast.Number{Float: 10.5, IsFloat: true}},
+		ExclusiveMax: &ast.BoolAttribute{Value: ast.Bool{Value: true}},
+		MinItems:     &ast.NumberAttribute{Value: ast.Number{Int: 1}},
+		MaxItems:     &ast.NumberAttribute{Value: ast.Number{Int: 5}},
+		UniqueItems:  &ast.BoolAttribute{Value: ast.Bool{Value: true}},
+	}
+	output = newSchema(d, input, true)
+	a.Equal(output.Type, TypeArray).
+		Equal(*output.MinItems, 1).
+		Equal(*output.MaxItems, 5).
+		True(output.UniqueItems).
+		Equal(*output.Items.Minimum, 0).
+		Equal(*output.Items.Maximum, 10.5).
+		False(output.Items.ExclusiveMinimum).
+		True(output.Items.ExclusiveMaximum)
+
+	input = &ast.Param{
+		Type:      &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeString}},
+		MinLength: &ast.NumberAttribute{Value: ast.Number{Int: 0}},
+		MaxLength: &ast.NumberAttribute{Value: ast.Number{Int: 5}},
+		Pattern:   &ast.Attribute{Value: xmlenc.String{Value: "^[a-z]+$"}},
+	}
+	output = newSchema(d, input, true)
+	a.NotNil(output.MinLength).
+		Equal(*output.MinLength, 0).
+		Nil(output.MinItems).
+		Equal(*output.MaxLength, 5).
+		Equal(output.Pattern, "^[a-z]+$").
+		Nil(output.Minimum).
+		Nil(output.Maximum)
+}
+
+func TestNewSchema_composition(t *testing.T) {
+	a := assert.New(t, false)
+
+	newAttr := func(v string) *ast.Attribute {
+		return &ast.Attribute{Value: xmlenc.String{Value: v}}
+	}
+	newType := func(v string) *ast.TypeAttribute {
+		return &ast.TypeAttribute{Value: xmlenc.String{Value: v}}
+	}
+
+	d := &ast.APIDoc{Types: []*ast.TypeDef{
+		{Name: newAttr("card"), Type: newType(ast.TypeObject)},
+	}}
+	input := &ast.Param{
+		Name: newAttr("payment"),
+		OneOf: &ast.Composition{
+			Discriminator: newAttr("kind"),
+			Items: []*ast.Param{
+				{Name: newAttr("card"), Ref: &ast.RefAttribute{Value: xmlenc.String{Value: "card"}}},
+				{
+					Name:  newAttr("bank"),
+					Type:  newType(ast.TypeObject),
+					Items: []*ast.Param{{Name: newAttr("kind"), Type: newType(ast.TypeString)}},
+				},
+			},
+		},
+		AllOf: &ast.Composition{
+			Items: []*ast.Param{{Name: newAttr("s"), Type: newType(ast.TypeString)}},
+		},
+	}
+
+	output := newSchema(d, input, true)
+	a.Empty(output.Type).
+		Length(output.OneOf, 2).
+		Nil(output.AnyOf).
+		Length(output.AllOf, 1).
+		NotNil(output.Discriminator)
+	a.Equal(output.OneOf[0].Ref, "#/components/schemas/card").
+		Equal(output.OneOf[1].Properties["kind"].Type, TypeString).
+		Equal(output.AllOf[0].Type, TypeString)
+	a.Equal(output.Discriminator, &Discriminator{
+		PropertyName: "kind",
+		Mapping:      map[string]string{"card": "#/components/schemas/card"},
+	})
+
+	// 通过 Request 指定
+	output = newSchemaFromRequest(d, &ast.Request{AnyOf: input.OneOf}, true)
+	a.Length(output.AnyOf, 2).
+		Nil(output.OneOf).
+		Equal(output.Discriminator.PropertyName, "kind")
 }
@@ -2,6 +2,12 @@
 
 package openapi
 
+import (
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/internal/ast"
+	"github.com/caixw/apidoc/v7/internal/locale"
+)
+
 // SecurityScheme.IN 的可选值
 const (
 	SecurityInQuery  = "query"
@@ -11,7 +17,7 @@ const (
 
 // Security.Type 的可选值
 const (
-	SecurityTypeAPIKey        = "apikey"
+	SecurityTypeAPIKey        = "apiKey"
 	SecurityTypeHTTP          = "http"
 	SecurityTypeOAuth2        = "oauth2"
 	SecurityTypeOpenIDConnect = "openIdConnect"
@@ -21,19 +27,19 @@ const (
 //
 // 键名指向的是 Components.SecuritySchemes 中的名称。
 // 若 SecurityScheme.Type 是 oauth2 或是 openIDConnect，
-// 则 SecurityRequirement 的键值必须是个空值，否则键值为一个 scope 列表。
+// 则 SecurityRequirement 的键值为一个 scope 列表，否则键值必须是个空值。
 type SecurityRequirement map[string][]string
 
 // SecurityScheme Object
 type SecurityScheme struct {
 	Type             string      `json:"type" yaml:"type"`
 	Description      string      `json:"description,omitempty" yaml:"description,omitempty"`
-	Name             string      `json:"name" yaml:"name"` // 报头或是 cookie 的名称
-	IN               string      `json:"in" yaml:"in"`     // 位置, header, query 和 cookie
-	Scheme           string      `json:"scheme" yaml:"scheme"`
+	Name             string      `json:"name,omitempty" yaml:"name,omitempty"` // 报头或是 cookie 的名称
+	IN               string      `json:"in,omitempty" yaml:"in,omitempty"`     // 位置, header, query 和 cookie
+	Scheme           string      `json:"scheme,omitempty" yaml:"scheme,omitempty"`
 	BearerFormat     string      `json:"bearerFormat,omitempty" yaml:"bearerFormat,omitempty"`
-	Flows            *OAuthFlows `json:"flows" yaml:"flows"`
-	OpenIDConnectURL string      `json:"openIdConnectUrl" yaml:"openIdConnectUrl"`
+	Flows            *OAuthFlows `json:"flows,omitempty" yaml:"flows,omitempty"`
+	OpenIDConnectURL string      `json:"openIdConnectUrl,omitempty" yaml:"openIdConnectUrl,omitempty"`
 
 	Ref string `json:"$ref,omitempty" yaml:"$ref,omitempty"`
 }
@@ -51,5 +57,88 @@ type OAuthFlow struct {
 	AuthorizationURL string            `json:"authorizationUrl,omitempty" yaml:"authorizationUrl,omitempty"`
 	TokenURL         string            `json:"tokenUrl,omitempty" yaml:"tokenUrl,omitempty"`
 	RefreshURL       string            `json:"refreshUrl,omitempty" yaml:"refreshUrl,omitempty"`
-	Scopes           map[string]string `json:"scopes,omitempty" yaml:"scopes,omitempty"`
+	Scopes           map[string]string `json:"scopes" yaml:"scopes"`
+}
+
+func newSecurityScheme(s *ast.Security) *SecurityScheme {
+	desc := s.Summary.V()
+	if s.Description.V() != "" {
+		desc = s.Description.V()
+	}
+
+	ss := &SecurityScheme{Description: desc}
+	switch s.Type.V() {
+	case ast.SecurityTypeHTTP:
+		ss.Type = SecurityTypeHTTP
+		ss.Scheme = s.Scheme.V()
+		ss.BearerFormat = s.BearerFormat.V()
+	case ast.SecurityTypeAPIKey:
+		ss.Type = SecurityTypeAPIKey
+		ss.Name = s.Key.V()
+		ss.IN = s.IN.V()
+	case ast.SecurityTypeOAuth2:
+		ss.Type = SecurityTypeOAuth2
+		ss.Flows = &OAuthFlows{}
+		for _, f := range s.Flows {
+			flow := &OAuthFlow{
+				AuthorizationURL: f.AuthorizationURL.V(),
+				TokenURL:         f.TokenURL.V(),
+				RefreshURL:       f.RefreshURL.V(),
+				Scopes:           make(map[string]string, len(f.Scopes)),
+			}
+			for _, scope := range f.Scopes {
+				flow.Scopes[scope.Name.V()] = scope.Summary.V()
+			}
+
+			switch f.Type.V() {
+			case ast.OAuthFlowImplicit:
+				ss.Flows.Implicit = flow
+			case ast.OAuthFlowPassword:
+				ss.Flows.Password = flow
+			case ast.OAuthFlowClientCredentials:
+				ss.Flows.ClientCredentials = flow
+			case ast.OAuthFlowAuthorizationCode:
+				ss.Flows.AuthorizationCode = flow
+			}
+		}
+	}
+
+	return ss
+}
+
+func newSecurityRequirement(s *ast.SecurityValue) *SecurityRequirement {
+	scopes := s.Scopes()
+	if scopes == nil {
+		scopes = []string{}
+	}
+	return &SecurityRequirement{s.V(): scopes}
+}
+
+func (ss *SecurityScheme) sanitize() *core.Error {
+	switch ss.Type {
+	case SecurityTypeHTTP:
+		if ss.Scheme == "" {
+			return core.NewError(locale.ErrIsEmpty, "scheme").WithField("scheme")
+		}
+	case SecurityTypeAPIKey:
+		if ss.Name == "" {
+			return core.NewError(locale.ErrIsEmpty, "name").WithField("name")
+		}
+
+		if ss.IN != SecurityInQuery && ss.IN != SecurityInHeader && ss.IN != SecurityInCookie {
+			return core.NewError(locale.ErrInvalidValue).WithField("in")
+		}
+	case SecurityTypeOAuth2:
+		if ss.Flows == nil {
+			return core.NewError(locale.ErrIsEmpty, "flows").WithField("flows")
+		}
+	case SecurityTypeOpenIDConnect:
+		if ss.OpenIDConnectURL == "" {
+			return core.NewError(locale.ErrIsEmpty, "openIdConnectUrl").WithField("openIdConnectUrl")
+		}
+	default:
+		return core.NewError(locale.ErrInvalidValue).WithField("type")
+	}
+
+	return nil
 }
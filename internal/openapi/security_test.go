@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v3"
+
+	"github.com/caixw/apidoc/v7/internal/ast"
+	"github.com/caixw/apidoc/v7/internal/xmlenc"
+)
+
+func TestNewSecurityScheme(t *testing.T) {
+	a := assert.New(t, false)
+
+	input := &ast.Security{
+		Name:         &ast.Attribute{Value: xmlenc.String{Value: "bearer"}},
+		Type:         &ast.Attribute{Value: xmlenc.String{Value: ast.SecurityTypeHTTP}},
+		Scheme:       &ast.Attribute{Value: xmlenc.String{Value: "bearer"}},
+		BearerFormat: &ast.Attribute{Value: xmlenc.String{Value: "JWT"}},
+		Summary:      &ast.Attribute{Value: xmlenc.String{Value: "summary"}},
+	}
+	output := newSecurityScheme(input)
+	a.Equal(output.Type, SecurityTypeHTTP).
+		Equal(output.Scheme, "bearer").
+		Equal(output.BearerFormat, "JWT").
+		Equal(output.Description, "summary").
+		Nil(output.Flows)
+	a.NotError(output.sanitize())
+
+	input = &ast.Security{
+		Name: &ast.Attribute{Value: xmlenc.String{Value: "key"}},
+		Type: &ast.Attribute{Value: xmlenc.String{Value: ast.SecurityTypeAPIKey}},
+		IN:   &ast.Attribute{Value: xmlenc.String{Value: ast.SecurityInCookie}},
+		Key:  &ast.Attribute{Value: xmlenc.String{Value: "token"}},
+	}
+	output = newSecurityScheme(input)
+	a.Equal(output.Type, SecurityTypeAPIKey).
+		Equal(output.IN, SecurityInCookie).
+		Equal(output.Name, "token")
+	a.NotError(output.sanitize())
+
+	input = &ast.Security{
+		Name: &ast.Attribute{Value: xmlenc.String{Value: "oauth"}},
+		Type: &ast.Attribute{Value: xmlenc.String{Value: ast.SecurityTypeOAuth2}},
+		Flows: []*ast.OAuthFlow{
+			{
+				Type:             &ast.Attribute{Value: xmlenc.String{Value: ast.OAuthFlowAuthorizationCode}},
+				AuthorizationURL: &ast.Attribute{Value: xmlenc.String{Value: "https://example.com/auth"}},
+				TokenURL:         &ast.Attribute{Value: xmlenc.String{Value: "https://example.com/token"}},
+				Scopes: []*ast.OAuthScope{
+					{
+						Name:    &ast.Attribute{Value: xmlenc.String{Value: "read"}},
+						Summary: &ast.Attribute{Value: xmlenc.String{Value: "read"}},
+					},
+				},
+			},
+			{
+				Type:     &ast.Attribute{Value: xmlenc.String{Value: ast.OAuthFlowClientCredentials}},
+				TokenURL: &ast.Attribute{Value: xmlenc.String{Value: "https://example.com/token"}},
+			},
+		},
+	}
+	output = newSecurityScheme(input)
+	a.Equal(output.Type, SecurityTypeOAuth2).
+		NotNil(output.Flows.AuthorizationCode).
+		NotNil(output.Flows.ClientCredentials).
+		Nil(output.Flows.Implicit).
+		Equal(output.Flows.AuthorizationCode.Scopes, map[string]string{"read": "read"}).
+		Equal(output.Flows.AuthorizationCode.TokenURL, "https://example.com/token")
+	a.NotError(output.sanitize())
+}
+
+func TestNewSecurityRequirement(t *testing.T) {
+	a := assert.New(t, false)
+
+	input := &ast.SecurityValue{Content: ast.Content{Value: "bearer"}}
+	a.Equal(newSecurityRequirement(input), &SecurityRequirement{"bearer": []string{}})
+
+	input = &ast.SecurityValue{
+		Content: ast.Content{Value: "oauth"},
+		Scope:   &ast.Attribute{Value: xmlenc.String{Value: " read  write"}},
+	}
+	a.Equal(newSecurityRequirement(input), &SecurityRequirement{"oauth": []string{"read", "write"}})
+}
+
+func TestSecurityScheme_sanitize(t *testing.T) {
+	a := assert.New(t, false)
+
+	ss := &SecurityScheme{}
+	a.Error(ss.sanitize())
+
+	ss.Type = SecurityTypeHTTP
+	a.Error(ss.sanitize())
+	ss.Scheme = "basic"
+	a.NotError(ss.sanitize())
+
+	ss = &SecurityScheme{Type: SecurityTypeAPIKey, Name: "token"}
+	a.Error(ss.sanitize())
+	ss.IN = SecurityInQuery
+	a.NotError(ss.sanitize())
+
+	ss = &SecurityScheme{Type: SecurityTypeOAuth2}
+	a.Error(ss.sanitize())
+	ss.Flows = &OAuthFlows{}
+	a.NotError(ss.sanitize())
+
+	ss = &SecurityScheme{Type: SecurityTypeOpenIDConnect}
+	a.Error(ss.sanitize())
+	ss.OpenIDConnectURL = "https://example.com"
+	a.NotError(ss.sanitize())
+}
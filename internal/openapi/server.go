@@ -18,6 +18,8 @@ type Server struct {
 	URL         string                     `json:"url" yaml:"url"`
 	Description string                     `json:"description,omitempty" yaml:"description,omitempty"`
 	Variables   map[string]*ServerVariable `json:"variables,omitempty" yaml:"variables,omitempty"`
+
+	Extensions Extensions `json:"-" yaml:",inline"`
 }
 
 // ServerVariable Server 中 URL 模板中对应的参数变量值
@@ -33,9 +35,28 @@ func newServer(srv *ast.Server) *Server {
 		desc = srv.Description.V()
 	}
 
+	var vars map[string]*ServerVariable
+	if len(srv.Variables) > 0 {
+		vars = make(map[string]*ServerVariable, len(srv.Variables))
+		for _, v := range srv.Variables {
+			var enums []string
+			for _, e := range v.Enums {
+				enums = append(enums, e.Value.V())
+			}
+
+			vars[v.Name.V()] = &ServerVariable{
+				Enum:        enums,
+				Default:     v.Default.V(),
+				Description: v.Summary.V(),
+			}
+		}
+	}
+
 	return &Server{
 		URL:         srv.URL.V(),
 		Description: desc,
+		Variables:   vars,
+		Extensions:  newExtensions(srv.XAttributes, srv.Extensions),
 	}
 }
 
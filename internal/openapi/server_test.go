@@ -30,6 +30,34 @@ func TestNewServer(t *testing.T) {
 	a.NotNil(output).
 		Equal(output.URL, "https://example.com").
 		Equal(output.Description, "desc")
+
+	// variables
+	input = &ast.Server{
+		URL:  &ast.Attribute{Value: xmlenc.String{Value: "https://{region}.example.com/{base}"}},
+		Name: &ast.Attribute{Value: xmlenc.String{Value: "name"}},
+		Variables: []*ast.ServerVariable{
+			{
+				Name:    &ast.Attribute{Value: xmlenc.String{Value: "region"}},
+				Default: &ast.Attribute{Value: xmlenc.String{Value: "cn"}},
+				Summary: &ast.Attribute{Value: xmlenc.String{Value: "region"}},
+			},
+			{
+				Name:    &ast.Attribute{Value: xmlenc.String{Value: "base"}},
+				Default: &ast.Attribute{Value: xmlenc.String{Value: "v1"}},
+				Enums: []*ast.Enum{
+					{Value: &ast.Attribute{Value: xmlenc.String{Value: "v1"}}},
+					{Value: &ast.Attribute{Value: xmlenc.String{Value: "v2"}}},
+				},
+			},
+		},
+	}
+	output = newServer(input)
+	a.NotNil(output).
+		Equal(output.URL, "https://{region}.example.com/{base}").
+		Length(output.Variables, 2).
+		NotError(output.sanitize())
+	a.Equal(output.Variables["region"], &ServerVariable{Default: "cn", Description: "region"})
+	a.Equal(output.Variables["base"], &ServerVariable{Default: "v1", Enum: []string{"v1", "v2"}})
 }
 
 func TestServer_sanitize(t *testing.T) {
@@ -185,36 +185,60 @@ func (d *decoder) decodeAttributes(n *node.Node, start *StartElement) {
 	}
 
 	for _, attr := range start.Attributes {
+		if d.prefix != attr.Name.Prefix.Value { // 命名空间不匹配
+			continue
+		}
+
 		item, found := n.Attribute(attr.Name.Local.Value)
-		if !found || d.prefix != attr.Name.Prefix.Value { // 未找到或是命名空间不匹配
+		if !found {
+			if item, found = n.AnyAttribute(attr.Name.Local.Value); found {
+				d.decodeAnyAttribute(item, attr)
+			}
 			continue
 		}
 		v := node.RealValue(item.Value)
 		v.Set(reflect.New(v.Type()).Elem())
+		d.decodeAttribute(v, item, attr)
+	}
+}
+
+// 将 attr 解码并追加到切片 slice 之中
+func (d *decoder) decodeAnyAttribute(slice *node.Value, attr *Attribute) {
+	s := node.RealValue(slice.Value)
 
-		var impl bool
-		if item.CanInterface() && item.Type().Implements(attrDecoderType) {
-			if err := item.Interface().(AttrDecoder).DecodeXMLAttr(d.p, attr); err != nil {
+	elem := reflect.New(s.Type().Elem()).Elem()
+	if elem.Kind() == reflect.Ptr {
+		elem.Set(reflect.New(elem.Type().Elem()))
+	}
+	d.decodeAttribute(node.RealValue(elem), node.NewValue(slice.Name, elem, slice.Omitempty, slice.Usage), attr)
+
+	s.Set(reflect.Append(s, elem))
+}
+
+// 将 attr 解码至 item，v 为 item 的实际值。
+func (d *decoder) decodeAttribute(v reflect.Value, item *node.Value, attr *Attribute) {
+	var impl bool
+	if item.CanInterface() && item.Type().Implements(attrDecoderType) {
+		if err := item.Interface().(AttrDecoder).DecodeXMLAttr(d.p, attr); err != nil {
+			d.p.Error(err)
+		}
+		impl = true
+	} else if item.CanAddr() {
+		pv := item.Addr()
+		if pv.CanInterface() && pv.Type().Implements(attrDecoderType) {
+			if err := pv.Interface().(AttrDecoder).DecodeXMLAttr(d.p, attr); err != nil {
 				d.p.Error(err)
 			}
 			impl = true
-		} else if item.CanAddr() {
-			pv := item.Addr()
-			if pv.CanInterface() && pv.Type().Implements(attrDecoderType) {
-				if err := pv.Interface().(AttrDecoder).DecodeXMLAttr(d.p, attr); err != nil {
-					d.p.Error(err)
-				}
-				impl = true
-			}
-		}
-
-		if !impl {
-			panic(fmt.Sprintf("当前属性 %s 未实现 AttrDecoder 接口", attr.Name))
 		}
+	}
 
-		v.Addr().Interface().(attributeSetter).setAttribute(item.Usage, attr)
-		callSanitizer(v, d.p) // Sanitize 在最后调用，可以确保能取到 v.Range
+	if !impl {
+		panic(fmt.Sprintf("当前属性 %s 未实现 AttrDecoder 接口", attr.Name))
 	}
+
+	v.Addr().Interface().(attributeSetter).setAttribute(item.Usage, attr)
+	callSanitizer(v, d.p) // Sanitize 在最后调用，可以确保能取到 v.Range
 }
 
 func (d *decoder) decodeElements(n *node.Node) (end *EndElement, ok bool) {
@@ -245,19 +269,7 @@ func (d *decoder) decodeElements(n *node.Node) (end *EndElement, ok bool) {
 				copyContentValue(n.Content.Value, reflect.ValueOf(elem))
 			}
 		case *StartElement:
-			item, found := n.Element(elem.Name.Local.Value)
-			if !found || d.prefix != elem.Name.Prefix.Value {
-				if err := d.p.endElement(elem); err != nil {
-					d.p.Error(err)
-					return nil, false
-				}
-
-				e := d.p.newError(elem.Location.Range.Start, d.p.Current().Position, elem.Name.String(), locale.ErrInvalidTag).
-					AddTypes(core.ErrorTypeUnused)
-				d.p.Warning(e)
-				break // 忽略不存在的子元素
-			}
-			if !d.decodeElement(elem, item) {
+			if !d.decodeChild(n, elem) {
 				return nil, false
 			}
 		case *Comment: // 忽略注释内容
@@ -268,6 +280,85 @@ func (d *decoder) decodeElements(n *node.Node) (end *EndElement, ok bool) {
 	}
 }
 
+// 将 elem 解码至 n 对应的子元素中
+func (d *decoder) decodeChild(n *node.Node, elem *StartElement) (ok bool) {
+	item, found := n.Element(elem.Name.Local.Value)
+	if !found && elem.Name.Local.Value == IncludeName && d.prefix == elem.Name.Prefix.Value {
+		return d.include(n, elem)
+	}
+
+	if !found || d.prefix != elem.Name.Prefix.Value {
+		if err := d.p.endElement(elem); err != nil {
+			d.p.Error(err)
+			return false
+		}
+
+		e := d.p.newError(elem.Location.Range.Start, d.p.Current().Position, elem.Name.String(), locale.ErrInvalidTag).
+			AddTypes(core.ErrorTypeUnused)
+		d.p.Warning(e)
+		return true // 忽略不存在的子元素
+	}
+
+	return d.decodeElement(elem, item)
+}
+
+// 将 include 元素引用的文件内容作为 n 的子元素进行解码
+//
+// 被引用文件中的错误不会影响当前文件的解析。
+func (d *decoder) include(n *node.Node, start *StartElement) (ok bool) {
+	if !start.SelfClose {
+		if err := d.p.endElement(start); err != nil {
+			d.p.Error(err)
+			return false
+		}
+	}
+
+	var src *Attribute
+	for _, attr := range start.Attributes {
+		if attr.Name.Local.Value == "src" && attr.Name.Prefix.Value == d.prefix {
+			src = attr
+		}
+	}
+	if src == nil {
+		d.p.Error(d.p.newError(start.Location.Range.Start, start.Location.Range.End, "@src", locale.ErrIsEmpty, "@src"))
+		return true
+	}
+
+	b, err := d.p.Include(src.Value.Value)
+	if err != nil {
+		d.p.Error(src.Location.WithError(err).WithField("@src"))
+		return true
+	}
+
+	p, err := d.p.includeParser(b)
+	if err != nil {
+		d.p.Error(err)
+		return true
+	}
+
+	sub := &decoder{p: p, prefix: d.prefix}
+	for {
+		t, loc, err := p.Token()
+		if errors.Is(err, io.EOF) {
+			return true
+		} else if err != nil {
+			p.Error(err)
+			return true
+		}
+
+		switch elem := t.(type) {
+		case *StartElement:
+			if !sub.decodeChild(n, elem) {
+				return true
+			}
+		case *Comment, *String, *Instruction: // 忽略注释和普通的文本内容
+		default:
+			p.Error(loc.NewError(locale.ErrInvalidXML))
+			return true
+		}
+	}
+}
+
 func copyContentValue(target, source reflect.Value) {
 	target = node.RealValue(target)
 	source = node.RealValue(source)
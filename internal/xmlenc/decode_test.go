@@ -3,6 +3,8 @@
 package xmlenc
 
 import (
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
@@ -1545,6 +1547,75 @@ func TestDecode_omitempty(t *testing.T) {
 	a.NotEmpty(rslt.Errors).Empty(rslt.Warns)
 }
 
+func TestDecode_include(t *testing.T) {
+	a := assert.New(t, false)
+
+	dir := t.TempDir()
+	a.NotError(os.WriteFile(filepath.Join(dir, "elems.xml"), []byte("<elem1>6</elem1>\n<elem2>7</elem2>"), os.ModePerm))
+	a.NotError(os.WriteFile(filepath.Join(dir, "cycle.xml"), []byte(`<include src="cycle.xml" />`), os.ModePerm))
+
+	type object struct {
+		BaseTag
+		RootName struct{} `apidoc:"apidoc,meta,usage-apidoc"`
+		Attr1    intAttr  `apidoc:"attr1,attr,usage"`
+		Elem1    intTag   `apidoc:"elem1,elem,usage"`
+		Elem2    *intTag  `apidoc:"elem2,elem,usage,omitempty"`
+	}
+
+	decode := func(xml string, v any) *messagetest.Result {
+		rslt := messagetest.NewMessageHandler()
+		b := core.Block{Data: []byte(xml), Location: core.Location{URI: core.FileURI(filepath.Join(dir, "doc.go"))}}
+		p, err := NewParser(rslt.Handler, b)
+		a.NotError(err).NotNil(p)
+		Decode(p, v, "")
+		rslt.Handler.Stop()
+		return rslt
+	}
+
+	v := &object{}
+	rslt := decode(`<apidoc attr1="5"><include src="elems.xml" /></apidoc>`, v)
+	a.Empty(rslt.Errors).Empty(rslt.Warns)
+	a.Equal(v.Attr1.Value, 5).Equal(v.Elem1.Value, 6).Equal(v.Elem2.Value, 7)
+	a.Equal(v.Elem2.Location, core.Location{
+		URI:   core.FileURI(filepath.Join(dir, "elems.xml")),
+		Range: core.Range{Start: core.Position{Line: 1}, End: core.Position{Line: 1, Character: 16}},
+	})
+
+	// 文件不存在
+	v = &object{}
+	rslt = decode(`<apidoc attr1="5"><elem1>6</elem1><include src="not-exists.xml"></include></apidoc>`, v)
+	a.Length(rslt.Errors, 1).Equal(v.Elem1.Value, 6)
+
+	// 缺少 src
+	v = &object{}
+	rslt = decode(`<apidoc attr1="5"><elem1>6</elem1><include /></apidoc>`, v)
+	a.Length(rslt.Errors, 1).Equal(v.Elem1.Value, 6)
+
+	// 循环引用
+	v = &object{}
+	rslt = decode(`<apidoc attr1="5"><elem1>6</elem1><include src="cycle.xml" /></apidoc>`, v)
+	a.Length(rslt.Errors, 1).Equal(v.Elem1.Value, 6)
+
+	// 嵌套引用，Included 包含所有被引用的文件。
+	a.NotError(os.WriteFile(filepath.Join(dir, "nested.xml"), []byte(`<elem1>6</elem1><include src="elem2.xml" />`), os.ModePerm))
+	a.NotError(os.WriteFile(filepath.Join(dir, "elem2.xml"), []byte(`<elem2>7</elem2>`), os.ModePerm))
+	rslt = messagetest.NewMessageHandler()
+	b := core.Block{
+		Data:     []byte(`<apidoc attr1="5"><include src="nested.xml" /></apidoc>`),
+		Location: core.Location{URI: core.FileURI(filepath.Join(dir, "doc.go"))},
+	}
+	p, err := NewParser(rslt.Handler, b)
+	a.NotError(err).NotNil(p).Empty(p.Included())
+	v = &object{}
+	Decode(p, v, "")
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors).Equal(v.Elem1.Value, 6).Equal(v.Elem2.Value, 7)
+	a.Equal(p.Included(), []core.URI{
+		core.FileURI(filepath.Join(dir, "nested.xml")),
+		core.FileURI(filepath.Join(dir, "elem2.xml")),
+	})
+}
+
 func TestDecode_decodeAttributes(t *testing.T) {
 	a := assert.New(t, false)
 
@@ -1655,4 +1726,28 @@ func TestDecode_decodeAttributes(t *testing.T) {
 			},
 		})
 	})
+
+	// attrs
+	val6 := &struct {
+		Name stringAttr    `apidoc:"name,attr,usage"`
+		X    []*stringAttr `apidoc:"x-,attrs,usage-x"`
+	}{}
+	o = node.New("root", reflect.ValueOf(val6))
+	d, rslt = newDecoder(a, "")
+	d.decodeAttributes(o, &StartElement{
+		Attributes: []*Attribute{
+			{Name: Name{Local: String{Value: "name"}}, Value: String{Value: "name"}},
+			{Name: Name{Local: String{Value: "x-team"}}, Value: String{Value: "t1"}},
+			{Name: Name{Local: String{Value: "y-team"}}, Value: String{Value: "t2"}},
+			{Name: Name{Local: String{Value: "x-tier"}}, Value: String{Value: "gold"}},
+		},
+	})
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors).
+		Length(val6.X, 2).
+		Equal(val6.X[0], &stringAttr{Value: "t1", BaseAttribute: BaseAttribute{
+			Base:          Base{UsageKey: "usage-x"},
+			AttributeName: Name{Local: String{Value: "x-team"}},
+		}}).
+		Equal(val6.X[1].Value, "gold")
 }
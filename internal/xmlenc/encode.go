@@ -25,11 +25,19 @@ type AttrEncoder interface {
 	EncodeXMLAttr() (string, error)
 }
 
+type attributeNamer interface {
+	attributeName() string
+}
+
 var (
 	attrEncoderType = reflect.TypeOf((*AttrEncoder)(nil)).Elem()
 	encoderType     = reflect.TypeOf((*Encoder)(nil)).Elem()
 )
 
+func (b *BaseAttribute) attributeName() string {
+	return b.AttributeName.Local.Value
+}
+
 // Encode 将 v 转换成 XML 内容
 //
 // namespace 指定 XML 的命名空间；
@@ -164,6 +172,28 @@ func buildStartElement(n *node.Node, namespace, prefix string, root bool) (xml.S
 		})
 	}
 
+	if n.AnyAttributes != nil {
+		attrs := node.RealValue(n.AnyAttributes.Value)
+		for i := 0; i < attrs.Len(); i++ {
+			elem := attrs.Index(i)
+			if elem.Kind() != reflect.Ptr {
+				elem = elem.Addr()
+			} else if elem.IsNil() {
+				continue
+			}
+
+			val, err := getAttributeValue(elem)
+			if err != nil {
+				return xml.StartElement{}, err
+			}
+
+			start.Attr = append(start.Attr, xml.Attr{
+				Name:  buildXMLName(elem.Interface().(attributeNamer).attributeName(), prefix),
+				Value: val,
+			})
+		}
+	}
+
 	if root && namespace != "" {
 		name := "xmlns"
 		if prefix != "" {
@@ -300,6 +300,29 @@ func TestEncode(t *testing.T) {
 	}
 }
 
+func TestEncode_anyAttributes(t *testing.T) {
+	a := assert.New(t, false)
+
+	v := &struct {
+		RootName struct{}      `apidoc:"apidoc,meta,usage-apidoc"`
+		Name     stringAttr    `apidoc:"name,attr,usage"`
+		X        []*stringAttr `apidoc:"x-,attrs,usage,omitempty"`
+	}{
+		Name: stringAttr{Value: "n"},
+		X: []*stringAttr{
+			{Value: "t1", BaseAttribute: BaseAttribute{AttributeName: Name{Local: String{Value: "x-team"}}}},
+			{Value: "gold", BaseAttribute: BaseAttribute{AttributeName: Name{Local: String{Value: "x-tier"}}}},
+		},
+	}
+	data, err := Encode("", v, core.XMLNamespace, "aa")
+	a.NotError(err).
+		Equal(string(data), `<aa:apidoc aa:name="n" aa:x-team="t1" aa:x-tier="gold" xmlns:aa="`+core.XMLNamespace+`"></aa:apidoc>`)
+
+	v.X = nil
+	data, err = Encode("", v, "", "")
+	a.NotError(err).Equal(string(data), `<apidoc name="n"></apidoc>`)
+}
+
 func TestNode_isOmitempty(t *testing.T) {
 	a := assert.New(t, false)
 
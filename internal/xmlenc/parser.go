@@ -6,9 +6,13 @@ import (
 	"bytes"
 	"errors"
 	"io"
+	"path"
+	"path/filepath"
+	"strings"
 	"unicode"
 	"unicode/utf8"
 
+	"github.com/issue9/sliceutil"
 	"golang.org/x/text/message"
 
 	"github.com/caixw/apidoc/v7/core"
@@ -26,6 +30,15 @@ const (
 type Parser struct {
 	*lexer.Lexer
 	*core.MessageHandler
+
+	// 引用外部文件时的备用目录
+	//
+	// 通过 src 属性或是 include 元素引用的相对路径，
+	// 如果在当前文件所在的目录中找不到，则会在此目录中查找。
+	Dir core.URI
+
+	includes []core.URI  // 引用链上的所有文件，用于检测循环引用。
+	included *[]core.URI // 通过 Include 读取的所有文件，与被引用文件的 Parser 共享。
 }
 
 // NewParser 声明新的 Parser 实例
@@ -41,6 +54,136 @@ func NewParser(h *core.MessageHandler, b core.Block) (*Parser, error) {
 	}, nil
 }
 
+// Include 读取 src 指向的文件内容
+//
+// src 只能是相对路径，会依次相对于当前文件所在的目录以及 Parser.Dir 进行查找，
+// 且最终指向的文件必须位于这两个目录之下。绝对路径以及包含协议的地址都会返回错误。
+// 返回的 core.Block 包含了文件的内容及其在文件中的定位信息。
+func (p *Parser) Include(src string) (core.Block, error) {
+	if src == "" {
+		return core.Block{}, locale.NewError(locale.ErrIsEmpty, "src")
+	}
+
+	if strings.Contains(src, "://") || filepath.IsAbs(src) || path.IsAbs(src) || filepath.VolumeName(src) != "" {
+		return core.Block{}, locale.NewError(locale.ErrInvalidURI, src)
+	}
+
+	dirs := []core.URI{parentURI(p.Location.URI)}
+	if p.Dir != "" {
+		dirs = append(dirs, p.Dir)
+	}
+
+	var invalid bool
+	for _, dir := range dirs {
+		uri := resolveURI(dir, src)
+		if sliceutil.Count(dirs, func(d core.URI) bool { return withinURI(d, uri) }) == 0 {
+			invalid = true
+			continue
+		}
+
+		exists, err := uri.Exists()
+		if err != nil {
+			return core.Block{}, err
+		}
+		if !exists {
+			continue
+		}
+
+		if uri == p.Location.URI || sliceutil.Count(p.includes, func(i core.URI) bool { return i == uri }) > 0 {
+			return core.Block{}, locale.NewError(locale.ErrCircularReference)
+		}
+
+		data, err := uri.ReadAll(nil)
+		if err != nil {
+			return core.Block{}, err
+		}
+		b := core.Block{Location: core.Location{URI: uri}, Data: data}
+
+		end, err := lexer.BlockEndPosition(b)
+		if err != nil {
+			return core.Block{}, err
+		}
+		b.Location.Range.End = end.Position
+
+		if p.included == nil {
+			p.included = &[]core.URI{}
+		}
+		if sliceutil.Count(*p.included, func(i core.URI) bool { return i == uri }) == 0 {
+			*p.included = append(*p.included, uri)
+		}
+		return b, nil
+	}
+
+	if invalid {
+		return core.Block{}, locale.NewError(locale.ErrInvalidURI, src)
+	}
+	return core.Block{}, locale.NewError(locale.ErrFileNotFound, src)
+}
+
+// Included 返回通过 Include 读取过的所有文件
+//
+// 包括被引用的文件中再次引用的文件。
+func (p *Parser) Included() []core.URI {
+	if p.included == nil {
+		return nil
+	}
+	return *p.included
+}
+
+// 声明用于解析被引用文件 b 的 Parser 实例
+func (p *Parser) includeParser(b core.Block) (*Parser, error) {
+	pp, err := NewParser(p.MessageHandler, b)
+	if err != nil {
+		return nil, err
+	}
+
+	pp.Dir = p.Dir
+	pp.included = p.included
+	pp.includes = make([]core.URI, 0, len(p.includes)+1)
+	pp.includes = append(pp.includes, p.includes...)
+	pp.includes = append(pp.includes, p.Location.URI)
+	return pp, nil
+}
+
+// 返回 uri 所在的目录
+func parentURI(uri core.URI) core.URI {
+	str := string(uri)
+	if index := strings.LastIndexAny(str, `/\`); index >= 0 {
+		return core.URI(str[:index+1])
+	}
+	return ""
+}
+
+// 以 dir 为基准目录解析相对路径 src
+func resolveURI(dir core.URI, src string) core.URI {
+	scheme, dirPath := dir.Parse()
+	switch scheme {
+	case "":
+		return core.URI(filepath.Join(dirPath, src))
+	case core.SchemeFile:
+		return core.FileURI(filepath.Join(dirPath, src))
+	default:
+		return core.URI(scheme + "://" + path.Join(dirPath, filepath.ToSlash(src)))
+	}
+}
+
+// uri 是否位于目录 dir 之下
+func withinURI(dir, uri core.URI) bool {
+	dirScheme, dirPath := dir.Parse()
+	scheme, p := uri.Parse()
+	if scheme != dirScheme {
+		return false
+	}
+
+	if scheme == "" || scheme == core.SchemeFile {
+		rel, err := filepath.Rel(filepath.Clean(dirPath), filepath.Clean(p))
+		return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+	}
+
+	dirPath = strings.TrimSuffix(path.Clean(dirPath), "/")
+	return strings.HasPrefix(path.Clean(p), dirPath+"/")
+}
+
 // Token 返回下一个 token 对象
 //
 // token 可能的类型为 *StartElement、*EndElement、*Instruction、*Attribute、*CData、*Comment 和 *String。
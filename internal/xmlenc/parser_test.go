@@ -4,6 +4,8 @@ package xmlenc
 
 import (
 	"io"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/issue9/assert/v3"
@@ -2360,3 +2362,81 @@ func TestParser_endElement(t *testing.T) {
 	rslt.Handler.Stop()
 	a.Empty(rslt.Errors)
 }
+
+func TestParser_Include(t *testing.T) {
+	a := assert.New(t, false)
+
+	dir := t.TempDir()
+	a.NotError(os.MkdirAll(filepath.Join(dir, "sub"), os.ModePerm))
+	a.NotError(os.WriteFile(filepath.Join(dir, "sub", "a.xml"), []byte("<a>\n</a>"), os.ModePerm))
+	a.NotError(os.WriteFile(filepath.Join(dir, "b.xml"), []byte("<b />"), os.ModePerm))
+
+	rslt := messagetest.NewMessageHandler()
+	uri := core.FileURI(filepath.Join(dir, "sub", "doc.go"))
+	p, err := NewParser(rslt.Handler, core.Block{Location: core.Location{URI: uri}})
+	a.NotError(err).NotNil(p)
+
+	// 相对于当前文件
+	b, err := p.Include("a.xml")
+	a.NotError(err).
+		Equal(b.Location.URI, core.FileURI(filepath.Join(dir, "sub", "a.xml"))).
+		Equal(string(b.Data), "<a>\n</a>").
+		Equal(b.Location.Range.End, core.Position{Line: 1, Character: 4})
+
+	// 相对于 Dir
+	_, err = p.Include("b.xml")
+	a.Error(err)
+	p.Dir = core.FileURI(dir)
+	b, err = p.Include("b.xml")
+	a.NotError(err).Equal(b.Location.URI, core.FileURI(filepath.Join(dir, "b.xml")))
+
+	_, err = p.Include("")
+	a.Error(err)
+	_, err = p.Include("not-exists.xml")
+	a.Error(err)
+
+	// 循环引用
+	a.NotError(os.WriteFile(filepath.Join(dir, "sub", "doc.go"), nil, os.ModePerm))
+	_, err = p.Include("doc.go")
+	a.Error(err)
+
+	pp, err := p.includeParser(b)
+	a.NotError(err).NotNil(pp).Equal(pp.Dir, p.Dir)
+	_, err = pp.Include("doc.go")
+	a.Error(err)
+	_, err = pp.Include("sub/a.xml")
+	a.NotError(err)
+
+	// 不能指向当前文件所在目录和 Dir 之外的文件
+	a.NotError(os.WriteFile(filepath.Join(filepath.Dir(dir), "outside.xml"), nil, os.ModePerm))
+	_, err = p.Include("../../outside.xml")
+	a.Error(err)
+	_, err = p.Include("../b.xml") // 位于 Dir 之下
+	a.NotError(err)
+	_, err = p.Include(filepath.Join(dir, "b.xml"))
+	a.Error(err)
+	_, err = p.Include("/etc/passwd")
+	a.Error(err)
+	_, err = p.Include("https://example.com/a.xml")
+	a.Error(err)
+	_, err = p.Include(string(core.FileURI(filepath.Join(dir, "b.xml"))))
+	a.Error(err)
+}
+
+func TestResolveURI(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.Equal(resolveURI("https://example.com/dir/", "a.xml"), "https://example.com/dir/a.xml")
+	a.Equal(resolveURI(core.FileURI("/dir/"), "../a.xml"), core.FileURI("/a.xml"))
+	a.Equal(resolveURI("/dir", "a.xml"), core.URI(filepath.Join("/dir", "a.xml")))
+
+	a.True(withinURI(core.FileURI("/dir/"), core.FileURI("/dir/sub/a.xml"))).
+		False(withinURI(core.FileURI("/dir/"), core.FileURI("/a.xml"))).
+		False(withinURI(core.FileURI("/dir"), core.FileURI("/dir2/a.xml"))).
+		False(withinURI("/dir", core.FileURI("/dir/a.xml"))).
+		True(withinURI("https://example.com/dir/", "https://example.com/dir/a.xml")).
+		False(withinURI("https://example.com/dir/", "https://example.com/a.xml"))
+
+	a.Equal(parentURI("https://example.com/dir/a.xml"), "https://example.com/dir/")
+	a.Equal(parentURI("a.xml"), "")
+}
@@ -10,6 +10,12 @@ import (
 	"github.com/caixw/apidoc/v7/internal/locale"
 )
 
+// IncludeName 引用外部 XML 片段的元素名称
+//
+// 在任意元素中，都可以通过 <include src="path" /> 将 path 指向的文件内容作为当前元素的子元素，
+// 前提是当前元素未定义同名的子元素。
+const IncludeName = "include"
+
 // Base 所有文档节点的基本元素
 type Base struct {
 	core.Location
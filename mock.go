@@ -3,8 +3,13 @@
 package apidoc
 
 import (
+	crand "crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"math"
 	"math/rand"
 	"net/http"
+	"net/netip"
 	"time"
 
 	"github.com/issue9/rands"
@@ -30,7 +35,7 @@ func (r *Range) sanitize() *core.Error {
 // MockOptions mock 的一些随机设置项
 type MockOptions struct {
 	Indent    string            // 缩进字符串
-	Servers   map[string]string // 为文档中所有 server 以及对应的路由前缀。
+	Servers   map[string]string // 为文档中所有 server 以及对应的路由前缀，键名为 server.variable 的项用于指定前缀中服务变量的值。
 	SliceSize Range             // 指定用于生成数组大小范围的数值
 
 	NumberSize  Range // 指定用于生成数值数据的范围
@@ -128,21 +133,27 @@ func (o *MockOptions) gen() (*mock.GenOptions, error) {
 
 	return &mock.GenOptions{
 		Number: func(p *ast.Param) any {
+			r := o.numberRange(p)
+
 			switch p.Type.V() {
 			case ast.TypeFloat:
-				return o.float()
-			case ast.TypeInt:
-				return o.integer()
+				return r.float()
+			case ast.TypeDouble:
+				return float64(r.float())
+			case ast.TypeInt, ast.TypeInt32:
+				return r.integer()
+			case ast.TypeInt64:
+				return int64(r.integer())
 			}
 
 			if !o.EnableFloat {
-				return o.integer()
+				return r.integer()
 			}
 
 			if rand.Int()%2 == 0 {
-				return o.integer()
+				return r.integer()
 			}
-			return o.float()
+			return r.float()
 		},
 
 		String: func(p *ast.Param) string {
@@ -159,8 +170,28 @@ func (o *MockOptions) gen() (*mock.GenOptions, error) {
 				return o.time()
 			case ast.TypeDateTime:
 				return o.dateTime()
+			case ast.TypeUUID:
+				return o.uuid()
+			case ast.TypeIPv4:
+				return o.ipv4()
+			case ast.TypeIPv6:
+				return o.ipv6()
+			case ast.TypeHostname:
+				return o.hostname()
+			case ast.TypeByte:
+				return o.base64(p)
+			}
+
+			alpha := o.StringAlpha
+			if p.Type.V() == ast.TypePassword {
+				alpha = rands.AlphaNumberPunctuation
+			}
+
+			r := o.stringRange(p)
+			if r.Max <= 1 { // max-length 为 0
+				return ""
 			}
-			return rands.String(o.StringSize.Min, o.StringSize.Max, o.StringAlpha)
+			return rands.String(r.Min, r.Max, alpha)
 		},
 
 		Bool: func() bool {
@@ -177,12 +208,66 @@ func (o *MockOptions) gen() (*mock.GenOptions, error) {
 	}, nil
 }
 
-func (o *MockOptions) integer() int {
-	return rand.Intn(o.NumberSize.Max-o.NumberSize.Min) + o.NumberSize.Min
+// 根据 p 中的 min 和 max 调整 NumberSize
+//
+// 仅指定了其中一个值时，会保持 NumberSize 的跨度不变。
+func (o *MockOptions) numberRange(p *ast.Param) Range {
+	r := o.NumberSize
+	size := r.Max - r.Min
+
+	if p.Min != nil {
+		r.Min = int(math.Ceil(p.Min.Float64()))
+		if p.ExclusiveMin.V() && float64(r.Min) == p.Min.Float64() {
+			r.Min++
+		}
+		if p.Max == nil && r.Max <= r.Min {
+			r.Max = r.Min + size
+		}
+	}
+
+	if p.Max != nil {
+		r.Max = int(math.Floor(p.Max.Float64()))
+		if p.Min == nil && r.Min >= r.Max {
+			r.Min = r.Max - size
+		}
+	}
+
+	return r
+}
+
+// 根据 p 中的 min-length 和 max-length 调整 StringSize
+//
+// rands.String 要求 Min 大于 0，所以返回值的 Min 最小为 1。
+func (o *MockOptions) stringRange(p *ast.Param) Range {
+	r := o.StringSize
+	if p.MinLength != nil {
+		r.Min = p.MinLength.IntValue()
+		if r.Min < 1 {
+			r.Min = 1
+		}
+		if r.Max <= r.Min {
+			r.Max = r.Min + 1
+		}
+	}
+	if p.MaxLength != nil {
+		r.Max = p.MaxLength.IntValue() + 1 // 生成的长度不包含 Max
+		if r.Min >= r.Max {
+			r.Min = r.Max - 1
+		}
+	}
+	return r
+}
+
+// 返回 [Min, Max) 之间的整数，如果范围为空，则返回 Min。
+func (r Range) integer() int {
+	if r.Max <= r.Min {
+		return r.Min
+	}
+	return rand.Intn(r.Max-r.Min) + r.Min
 }
 
-func (o *MockOptions) float() float32 {
-	return float32(o.NumberSize.Min) + rand.Float32()*float32(o.NumberSize.Max-o.NumberSize.Min)
+func (r Range) float() float32 {
+	return float32(r.Min) + rand.Float32()*float32(r.Max-r.Min)
 }
 
 func (o *MockOptions) url() string {
@@ -204,6 +289,55 @@ func (o *MockOptions) email() string {
 	return username + "@" + domain
 }
 
+func (o *MockOptions) hostname() string {
+	domain := o.EmailDomains[rand.Intn(len(o.EmailDomains))]
+	return rands.String(1, 10, rands.AlphaNumber) + "." + domain
+}
+
+// 返回 n 个随机字节
+func randBytes(n int) []byte {
+	bs := make([]byte, n)
+	if _, err := crand.Read(bs); err != nil {
+		panic(err) // 仅在系统的随机数生成器不可用时才会出错
+	}
+	return bs
+}
+
+// 生成 v4 版本的 UUID
+func (o *MockOptions) uuid() string {
+	bs := randBytes(16)
+	bs[6] = (bs[6] & 0x0f) | 0x40 // version 4
+	bs[8] = (bs[8] & 0x3f) | 0x80 // RFC4122 variant
+
+	h := hex.EncodeToString(bs)
+	return h[:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:]
+}
+
+func (o *MockOptions) ipv4() string {
+	bs := randBytes(4)
+	return netip.AddrFrom4(*(*[4]byte)(bs)).String()
+}
+
+func (o *MockOptions) ipv6() string {
+	bs := randBytes(16)
+	bs[0] = 0x20 // 避免生成 IPv4 映射的地址
+	return netip.AddrFrom16(*(*[16]byte)(bs)).String()
+}
+
+// 生成 base64 编码的内容，长度由 StringSize 以及 p 中的长度约束决定。
+func (o *MockOptions) base64(p *ast.Param) string {
+	r := o.stringRange(p)
+
+	// 编码后的长度始终为 4 的倍数，每 3 个字节编码为 4 个字符。
+	size := (r.integer() + 3) / 4 * 4
+	if size >= r.Max && size >= 4 {
+		size -= 4
+	}
+
+	bs := randBytes(size / 4 * 3)
+	return base64.StdEncoding.EncodeToString(bs)
+}
+
 func (o *MockOptions) image() string {
 	path := o.ImageBasePrefix
 	if path[len(path)-1] != '/' {
@@ -237,7 +371,7 @@ func Mock(h *core.MessageHandler, data []byte, o *MockOptions) (http.Handler, er
 	}
 
 	d := &ast.APIDoc{}
-	d.Parse(h, core.Block{Data: data})
+	d.ParseBlocks(h, func(blocks chan core.Block) { blocks <- core.Block{Data: data} })
 	return mock.New(h, d, o.Indent, o.ImageBasePrefix, o.Servers, g)
 }
 
@@ -3,7 +3,10 @@
 package apidoc
 
 import (
+	"encoding/base64"
 	"net/http"
+	"net/netip"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -120,6 +123,47 @@ func TestMockOptions_gen(t *testing.T) {
 		a.NotError(err)
 	}
 
+	// String.UUID
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	for i := 0; i < count; i++ {
+		str := g.String(&ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: "string.uuid"}}})
+		a.True(uuid.MatchString(str), str)
+	}
+
+	// String.IPv4 / String.IPv6
+	for i := 0; i < count; i++ {
+		ip, err := netip.ParseAddr(g.String(&ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: "string.ipv4"}}}))
+		a.NotError(err).True(ip.Is4())
+
+		ip, err = netip.ParseAddr(g.String(&ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: "string.ipv6"}}}))
+		a.NotError(err).True(ip.Is6()).False(ip.Is4In6())
+	}
+
+	// String.Hostname
+	for i := 0; i < count; i++ {
+		str := g.String(&ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: "string.hostname"}}})
+		a.True(strings.HasSuffix(str, "."+defaultMockOptions.EmailDomains[0]))
+	}
+
+	// String.Byte
+	for i := 0; i < count; i++ {
+		str := g.String(&ast.Param{
+			Type:      &ast.TypeAttribute{Value: xmlenc.String{Value: "string.byte"}},
+			MaxLength: &ast.NumberAttribute{Value: ast.Number{Int: 10}},
+		})
+		_, err := base64.StdEncoding.DecodeString(str)
+		a.NotError(err).True(len(str) <= 10)
+	}
+
+	// Number.Int64 / Number.Double
+	for i := 0; i < count; i++ {
+		_, ok := g.Number(&ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: "number.int64"}}}).(int64)
+		a.True(ok)
+
+		_, ok = g.Number(&ast.Param{Type: &ast.TypeAttribute{Value: xmlenc.String{Value: "number.double"}}}).(float64)
+		a.True(ok)
+	}
+
 	// Number
 	defaultMockOptions.EnableFloat = false
 	g, err = defaultMockOptions.gen()
@@ -254,3 +298,50 @@ func TestMockFile(t *testing.T) {
 
 	rslt.Handler.Stop()
 }
+
+func TestMockOptions_numberRange(t *testing.T) {
+	a := assert.New(t, false)
+
+	o := &MockOptions{NumberSize: Range{Min: 100, Max: 1000}}
+	a.Equal(o.numberRange(&ast.Param{}), Range{Min: 100, Max: 1000})
+
+	p := &ast.Param{
+		Min: &ast.NumberAttribute{Value: ast.Number{Int: 5}},
+		Max: &ast.NumberAttribute{Value: ast.Number{Float: 10.5, IsFloat: true}},
+	}
+	a.Equal(o.numberRange(p), Range{Min: 5, Max: 10})
+
+	p = &ast.Param{
+		Min:          &ast.NumberAttribute{Value: ast.Number{Int: 5000}},
+		ExclusiveMin: &ast.BoolAttribute{Value: ast.Bool{Value: true}},
+	}
+	a.Equal(o.numberRange(p), Range{Min: 5001, Max: 5901})
+
+	p = &ast.Param{Max: &ast.NumberAttribute{Value: ast.Number{Int: 0}}}
+	a.Equal(o.numberRange(p), Range{Min: -900, Max: 0})
+
+	p = &ast.Param{
+		Min: &ast.NumberAttribute{Value: ast.Number{Int: 5}},
+		Max: &ast.NumberAttribute{Value: ast.Number{Int: 5}},
+	}
+	a.Equal(o.numberRange(p).integer(), 5)
+}
+
+func TestMockOptions_stringRange(t *testing.T) {
+	a := assert.New(t, false)
+
+	o := &MockOptions{StringSize: Range{Min: 50, Max: 100}}
+	a.Equal(o.stringRange(&ast.Param{}), Range{Min: 50, Max: 100})
+
+	p := &ast.Param{MaxLength: &ast.NumberAttribute{Value: ast.Number{Int: 10}}}
+	a.Equal(o.stringRange(p), Range{Min: 10, Max: 11})
+
+	p = &ast.Param{
+		MinLength: &ast.NumberAttribute{Value: ast.Number{Int: 0}},
+		MaxLength: &ast.NumberAttribute{Value: ast.Number{Int: 10}},
+	}
+	a.Equal(o.stringRange(p), Range{Min: 1, Max: 11})
+
+	p = &ast.Param{MinLength: &ast.NumberAttribute{Value: ast.Number{Int: 200}}}
+	a.Equal(o.stringRange(p), Range{Min: 200, Max: 201})
+}